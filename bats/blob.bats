@@ -0,0 +1,93 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE docs (
+    pk int PRIMARY KEY,
+    body longtext,
+    data blob
+);
+INSERT INTO docs VALUES (1, 'he said "hi", then left', 'abc'), (2, 'plain', NULL);
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "long text values round trip" {
+    dolt sql -q "INSERT INTO docs VALUES (3, REPEAT('a long document. ', 10000), 'def');"
+    dolt sql -q "UPDATE docs SET body = CONCAT(body, 'fin.') WHERE pk = 3;"
+    run dolt sql -q "SELECT LENGTH(body), SUBSTRING(body, 170001), data FROM docs WHERE pk = 3;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "170004,fin.,def" ]
+    run dolt sql -q "SELECT pk FROM docs WHERE body = 'plain';" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "export blob columns to csv" {
+    run dolt table export docs docs.csv
+    [ $status -eq 0 ]
+    run cat docs.csv
+    [ "${lines[0]}" = "pk,body,data" ]
+    [ "${lines[1]}" = '1,"he said ""hi"", then left",abc' ]
+    [ "${lines[2]}" = "2,plain," ]
+}
+
+@test "diff blob columns" {
+    dolt sql -q "UPDATE docs SET body = 'changed' WHERE pk = 2;"
+    run dolt diff
+    [ $status -eq 0 ]
+    [[ "$output" =~ "|  <  | 2  | plain   | <NULL> |" ]] || false
+    [[ "$output" =~ "|  >  | 2  | changed | <NULL> |" ]] || false
+}
+
+@test "blob columns can't be keys" {
+    run dolt sql -q "CREATE INDEX idx ON docs (body);"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "cannot be used in a primary key or index" ]] || false
+
+    run dolt sql -q "CREATE TABLE bad (pk longtext PRIMARY KEY);"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "cannot be used in a primary key or index" ]] || false
+}
+
+@test "binary and varbinary columns can be keys" {
+    dolt sql <<SQL
+CREATE TABLE hashes (
+    h varbinary(20) PRIMARY KEY,
+    b binary(4),
+    v varbinary(10),
+    INDEX (v),
+    UNIQUE KEY (b)
+);
+INSERT INTO hashes VALUES ('abc', 'wxyz', 'one'), ('abd', 'wxy1', 'two'), ('ab', 'wx12', 'one');
+SQL
+    run dolt sql -q "SELECT h FROM hashes WHERE h = 'abd';" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "abd" ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt sql -q "SELECT h FROM hashes WHERE v = 'one' ORDER BY h;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "ab" ]
+    [ "${lines[2]}" = "abc" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt sql -q "INSERT INTO hashes VALUES ('abc', 'aaaa', 'three');"
+    [ $status -ne 0 ]
+    run dolt sql -q "INSERT INTO hashes VALUES ('abe', 'wxyz', 'three');"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "UNIQUE constraint violation" ]] || false
+
+    run dolt index cat hashes v -r csv
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+}
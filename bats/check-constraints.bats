@@ -0,0 +1,102 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int PRIMARY KEY,
+    a int CHECK (a > 0),
+    b int,
+    CONSTRAINT b_lt_a CHECK (b < a)
+);
+INSERT INTO test VALUES (1, 5, 1), (2, 3, NULL);
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "schema show lists check constraints" {
+    run dolt schema show test
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONSTRAINT \`test_chk_1\` CHECK (a > 0)" ]] || false
+    [[ "$output" =~ "CONSTRAINT \`b_lt_a\` CHECK (b < a)" ]] || false
+}
+
+@test "writes must satisfy check constraints" {
+    run dolt sql -q "INSERT INTO test VALUES (3, -1, -2);"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Check constraint 'test_chk_1' is violated." ]] || false
+
+    run dolt sql -q "UPDATE test SET b = 10 WHERE pk = 1;"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Check constraint 'b_lt_a' is violated." ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+}
+
+@test "alter table adds and drops check constraints" {
+    run dolt sql -q "ALTER TABLE test ADD CONSTRAINT a_big CHECK (a > 4);"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Check constraint 'a_big' is violated." ]] || false
+
+    dolt sql -q "ALTER TABLE test ADD CONSTRAINT a_big CHECK (a > 4) NOT ENFORCED;"
+    run dolt schema show test
+    [[ "$output" =~ "CONSTRAINT \`a_big\` CHECK (a > 4) NOT ENFORCED" ]] || false
+    dolt sql -q "INSERT INTO test VALUES (3, 1, NULL);"
+
+    dolt sql -q "ALTER TABLE test DROP CHECK a_big;"
+    dolt sql -q "ALTER TABLE test DROP CONSTRAINT b_lt_a;"
+    run dolt schema show test
+    [[ ! "$output" =~ "a_big" ]] || false
+    [[ ! "$output" =~ "b_lt_a" ]] || false
+    dolt sql -q "UPDATE test SET b = 10 WHERE pk = 1;"
+}
+
+@test "columns used by check constraints can't be dropped" {
+    run dolt sql -q "ALTER TABLE test DROP COLUMN b;"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Check constraint 'b_lt_a' uses column 'b'" ]] || false
+}
+
+@test "import rejects rows that violate check constraints" {
+    cat <<DELIM > rows.csv
+pk,a,b
+3,7,2
+4,-1,
+5,9,1
+DELIM
+    run dolt table import -u test rows.csv
+    [ $status -ne 0 ]
+    run dolt table import -u --continue test rows.csv
+    [ $status -eq 0 ]
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk;" -r csv
+    [ "${lines[3]}" = "3" ]
+    [ "${lines[4]}" = "5" ]
+    [ "${#lines[@]}" -eq 5 ]
+}
+
+@test "merge reports rows that violate check constraints" {
+    dolt checkout -b other
+    dolt sql -q "ALTER TABLE test ADD CONSTRAINT a_small CHECK (a < 50);"
+    dolt add .
+    dolt commit -m "add a_small"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (3, 70, 1);"
+    dolt add .
+    dolt commit -m "add row"
+
+    run dolt merge other
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT (check constraint): Merge result violates check constraints in test" ]] || false
+    [[ "$output" =~ "a_small" ]] || false
+    run dolt status
+    [[ "$output" =~ "Changes not staged for commit" ]] || false
+}
@@ -0,0 +1,153 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE companies (
+    pk int PRIMARY KEY,
+    name varchar(50),
+    code varchar(10) COLLATE utf8mb4_bin,
+    INDEX (name)
+);
+INSERT INTO companies VALUES (1, 'acme', 'x'), (2, 'Beta', 'X'), (3, 'ACME', 'y'), (4, 'alpha', 'Y');
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "comparisons of text columns are case-insensitive" {
+    run dolt sql -q "SELECT pk FROM companies WHERE name = 'Acme' ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    [ "${lines[2]}" = "3" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt sql -q "SELECT pk FROM companies WHERE code = 'X'" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "comparisons of text columns are accent-insensitive" {
+    dolt sql -q "INSERT INTO companies VALUES (5, 'café', 'z')"
+    run dolt sql -q "SELECT pk FROM companies WHERE name = 'CAFE'" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "5" ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "in lists and ranges on indexed text columns use the collation" {
+    run dolt sql -q "SELECT pk FROM companies WHERE name IN ('BETA', 'Alpha') ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+    [ "${lines[2]}" = "4" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt sql -q "SELECT pk FROM companies WHERE name > 'b' ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt sql -q "SELECT pk FROM companies WHERE name >= 'ALPHA' AND name < 'C' ORDER BY name" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "4" ]
+    [ "${lines[2]}" = "2" ]
+    [ "${#lines[@]}" -eq 3 ]
+}
+
+@test "comparisons of unindexed text columns are case-insensitive" {
+    dolt sql <<SQL
+CREATE TABLE notes (pk int PRIMARY KEY, v varchar(20));
+INSERT INTO notes VALUES (1, 'acme'), (2, 'ACME'), (3, 'beta');
+SQL
+    run dolt sql -q "SELECT pk FROM notes WHERE v = 'Acme' ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    [ "${lines[2]}" = "2" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt sql -q "SELECT pk FROM notes WHERE 'Acme' < v" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "order by sorts text columns using their collation" {
+    run dolt sql -q "SELECT name FROM companies ORDER BY name, pk" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "acme" ]
+    [ "${lines[2]}" = "ACME" ]
+    [ "${lines[3]}" = "alpha" ]
+    [ "${lines[4]}" = "Beta" ]
+}
+
+@test "unique indexes reject values equal according to the collation" {
+    dolt sql -q "CREATE TABLE users (pk int PRIMARY KEY, email varchar(50), UNIQUE KEY (email))"
+    dolt sql -q "INSERT INTO users VALUES (1, 'ada@example.com')"
+    run dolt sql -q "INSERT INTO users VALUES (2, 'ADA@example.com')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "UNIQUE constraint violation" ]] || false
+
+    run dolt sql -q "CREATE UNIQUE INDEX code_idx ON companies (code)"
+    [ $status -eq 0 ]
+}
+
+@test "foreign keys match referenced values using the collation" {
+    dolt sql <<SQL
+CREATE TABLE users (pk int PRIMARY KEY, email varchar(50), UNIQUE KEY (email));
+INSERT INTO users VALUES (1, 'a@example.com');
+CREATE TABLE logins (pk int PRIMARY KEY, email varchar(50), INDEX (email), FOREIGN KEY (email) REFERENCES users (email));
+SQL
+    run dolt sql -q "INSERT INTO logins VALUES (1, 'A@Example.com')"
+    [ $status -eq 0 ]
+    run dolt sql -q "INSERT INTO logins VALUES (2, 'b@example.com')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "foreign key" ]] || false
+}
+
+@test "primary keys reject values equal according to the collation" {
+    dolt sql -q "CREATE TABLE tags (name varchar(20) PRIMARY KEY)"
+    dolt sql -q "INSERT INTO tags VALUES ('go')"
+    run dolt sql -q "INSERT INTO tags VALUES ('Go')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "duplicate primary key" ]] || false
+    run dolt sql -q "SELECT name FROM tags WHERE name = 'GO'" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "go" ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "primary keys keep their declared collation" {
+    dolt sql -q "CREATE TABLE names (name varchar(20) COLLATE utf8mb4_general_ci PRIMARY KEY, n int)"
+    dolt sql -q "INSERT INTO names VALUES ('b', 1), ('A', 2), ('c', 3)"
+    run dolt sql -q "SHOW CREATE TABLE names"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "\`name\` varchar(20) collate utf8mb4_general_ci NOT NULL" ]] || false
+    run dolt sql -q "SELECT name FROM names ORDER BY name" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "A" ]
+    [ "${lines[2]}" = "b" ]
+    [ "${lines[3]}" = "c" ]
+    run dolt sql -q "SELECT n FROM names WHERE name = 'B'" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+}
+
+@test "diff where matches values using the collation" {
+    dolt sql -q "UPDATE companies SET code = 'z' WHERE pk = 3"
+    run dolt diff --where "to_name=acme"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "| 3  | ACME | z" ]] || false
+}
+
+@test "index cat shows collation keys" {
+    run dolt index cat companies name -r csv
+    [ $status -eq 0 ]
+    [[ "${lines[1]}" =~ ^0x ]] || false
+}
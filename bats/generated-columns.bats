@@ -0,0 +1,86 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE people (
+    pk int PRIMARY KEY,
+    fname varchar(50),
+    sname varchar(50),
+    full_name varchar(101) GENERATED ALWAYS AS (concat(fname, ' ', sname)) STORED
+);
+INSERT INTO people (pk, fname, sname) VALUES (1, 'Ada', 'Lovelace'), (2, 'Alan', 'Turing');
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "schema show lists generated columns" {
+    run dolt schema show people
+    [ $status -eq 0 ]
+    [[ "$output" =~ "\`full_name\` varchar(101) GENERATED ALWAYS AS (concat(fname, ' ', sname)) STORED" ]] || false
+}
+
+@test "writes compute generated columns" {
+    dolt sql -q "UPDATE people SET sname = 'Byron' WHERE pk = 1;"
+    dolt sql -q "INSERT INTO people (pk, fname, sname) VALUES (3, 'Grace', 'Hopper');"
+    run dolt sql -q "SELECT full_name FROM people ORDER BY pk;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "Ada Byron" ]
+    [ "${lines[2]}" = "Alan Turing" ]
+    [ "${lines[3]}" = "Grace Hopper" ]
+}
+
+@test "alter table adds generated columns" {
+    dolt sql -q "ALTER TABLE people ADD COLUMN lower_sname varchar(50) AS (lower(sname));"
+    run dolt sql -q "SELECT lower_sname FROM people ORDER BY pk;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "lovelace" ]
+    [ "${lines[2]}" = "turing" ]
+
+    run dolt sql -q "ALTER TABLE people ADD COLUMN v int AS (pk + 1) VIRTUAL;"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "VIRTUAL generated columns are not supported" ]] || false
+}
+
+@test "columns used by generated columns can't be dropped" {
+    run dolt sql -q "ALTER TABLE people DROP COLUMN sname;"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Column 'sname' has a generated column dependency." ]] || false
+}
+
+@test "import computes generated columns" {
+    cat <<DELIM > rows.csv
+pk,fname,sname
+2,Alan,Kay
+3,Grace,Hopper
+DELIM
+    dolt table import -u people rows.csv
+    run dolt sql -q "SELECT full_name FROM people ORDER BY pk;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[2]}" = "Alan Kay" ]
+    [ "${lines[3]}" = "Grace Hopper" ]
+}
+
+@test "merge recomputes generated columns" {
+    dolt checkout -b other
+    dolt sql -q "UPDATE people SET fname = 'Augusta' WHERE pk = 1;"
+    dolt add .
+    dolt commit -m "fname"
+    dolt checkout master
+    dolt sql -q "UPDATE people SET sname = 'King' WHERE pk = 1;"
+    dolt add .
+    dolt commit -m "sname"
+
+    run dolt merge other
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+    run dolt sql -q "SELECT full_name FROM people WHERE pk = 1;" -r csv
+    [ "${lines[1]}" = "Augusta King" ]
+}
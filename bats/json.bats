@@ -0,0 +1,71 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE js (
+    pk int PRIMARY KEY,
+    doc json
+);
+INSERT INTO js VALUES (1, '{"b": [1, 2.0], "a": "x"}'), (2, 'null');
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "json documents are stored canonically" {
+    run dolt sql -q "SELECT doc FROM js WHERE pk = 1;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = '"{""a"":""x"",""b"":[1,2]}"' ]
+
+    dolt sql -q "UPDATE js SET doc = '{\"a\": \"x\", \"b\": [1.0, 2]}' WHERE pk = 1;"
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "json columns distinguish json null from sql null" {
+    dolt sql -q "INSERT INTO js VALUES (3, NULL);"
+    run dolt sql -q "SELECT pk FROM js WHERE doc IS NULL;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+    [ "${#lines[@]}" -eq 2 ]
+    run dolt sql -q "SELECT doc FROM js WHERE pk = 2;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "null" ]
+}
+
+@test "json functions" {
+    run dolt sql -q "SELECT JSON_UNQUOTE(JSON_EXTRACT(doc, '$.a')) FROM js WHERE pk = 1;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "x" ]
+}
+
+@test "diff json documents by path" {
+    dolt sql -q "UPDATE js SET doc = '{\"a\": \"y\", \"b\": [1, 2, 3]}' WHERE pk = 1;"
+    run dolt diff
+    [ $status -eq 0 ]
+    [[ "$output" =~ '|  <  | 1  | $.a: "x"            |' ]] || false
+    [[ "$output" =~ '|  >  | 1  | $.a: "y", $.b[2]: 3 |' ]] || false
+
+    run dolt diff -r sql
+    [ $status -eq 0 ]
+    [[ "$output" =~ 'UPDATE `js` SET `doc`=' ]] || false
+}
+
+@test "import invalid json" {
+    cat <<CSV > docs.csv
+pk,doc
+3,"{""c"": 3}"
+4,not json
+CSV
+    run dolt table import -u js docs.csv
+    [ $status -ne 0 ]
+    [[ "$output" =~ "invalid JSON text" ]] || false
+}
@@ -0,0 +1,125 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE keyless (
+    c0 int,
+    c1 int
+);
+INSERT INTO keyless VALUES (0,0),(2,2),(1,1),(1,1);
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "keyless tables allow duplicate rows" {
+    run dolt sql -q "SELECT * FROM keyless ORDER BY c0;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "c0,c1" ]
+    [ "${lines[1]}" = "0,0" ]
+    [ "${lines[2]}" = "1,1" ]
+    [ "${lines[3]}" = "1,1" ]
+    [ "${lines[4]}" = "2,2" ]
+
+    dolt sql -q "DELETE FROM keyless WHERE c0 = 1 LIMIT 1;"
+    run dolt sql -q "SELECT count(*) FROM keyless WHERE c0 = 1;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+}
+
+@test "keyless tables keep rows which become identical when updated" {
+    dolt sql -q "UPDATE keyless SET c1 = 1 WHERE c0 = 2;"
+    dolt sql -q "UPDATE keyless SET c0 = 1 WHERE c1 = 1;"
+    run dolt sql -q "SELECT * FROM keyless WHERE c0 = 1;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "1,1" ]
+    [ "${lines[2]}" = "1,1" ]
+    [ "${lines[3]}" = "1,1" ]
+    [ "${#lines[@]}" -eq 4 ]
+}
+
+@test "keyless tables store nulls" {
+    dolt sql -q "INSERT INTO keyless VALUES (3, NULL), (3, NULL);"
+    run dolt sql -q "SELECT count(*) FROM keyless;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "6" ]
+    run dolt sql -q "SELECT c0 FROM keyless WHERE c1 IS NULL;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "3" ]
+    [ "${lines[2]}" = "3" ]
+    [ "${#lines[@]}" -eq 3 ]
+}
+
+@test "keyless tables can't be indexed" {
+    run dolt sql -q "CREATE INDEX idx ON keyless (c1);"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "without a primary key" ]] || false
+}
+
+@test "diff a keyless table" {
+    dolt sql -q "DELETE FROM keyless WHERE c0 = 0;"
+    dolt sql -q "INSERT INTO keyless VALUES (1,1);"
+    run dolt diff -r sql
+    [ $status -eq 0 ]
+    [[ "$output" =~ 'DELETE FROM `keyless` WHERE (`c0`=0 AND `c1`=0) LIMIT 1;' ]] || false
+    [[ "$output" =~ 'INSERT INTO `keyless` (`c0`,`c1`) VALUES (1,1);' ]] || false
+
+    run dolt diff --summary
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1 Row Added" ]] || false
+    [[ "$output" =~ "1 Row Deleted" ]] || false
+}
+
+@test "merge keyless tables" {
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO keyless VALUES (1,1);"
+    dolt add .
+    dolt commit -m "other"
+    dolt checkout master
+    dolt sql -q "INSERT INTO keyless VALUES (1,1);"
+    dolt sql -q "DELETE FROM keyless WHERE c0 = 0;"
+    dolt add .
+    dolt commit -m "master"
+
+    run dolt merge other
+    [ $status -eq 0 ]
+    run dolt sql -q "SELECT count(*) FROM keyless WHERE c0 = 1;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "4" ]
+    run dolt sql -q "SELECT count(*) FROM keyless WHERE c0 = 0;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "blame a keyless table" {
+    dolt sql -q "INSERT INTO keyless VALUES (3,3);"
+    dolt add .
+    dolt commit -m "added a row"
+    run dolt blame keyless
+    [ $status -eq 0 ]
+    [[ "$output" =~ "| 3  | 3  | added a row" ]] || false
+    [[ "$output" =~ "| 0  | 0  | init" ]] || false
+}
+
+@test "import a csv with duplicate rows" {
+    cat <<CSV > logs.csv
+ts,msg
+1,hello
+1,hello
+2,bye
+CSV
+    run dolt table import -c logs logs.csv
+    [ $status -eq 0 ]
+    run dolt table import -u logs logs.csv
+    [ $status -eq 0 ]
+    run dolt sql -q "SELECT count(*) FROM logs WHERE ts = 1;" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "4" ]
+}
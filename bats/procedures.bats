@@ -0,0 +1,78 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int PRIMARY KEY,
+    v1 int
+);
+INSERT INTO test VALUES (1, 1), (2, 4);
+SQL
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "procedures: CREATE PROCEDURE and CALL" {
+    dolt sql <<SQL
+CREATE PROCEDURE double_v1(x INT) SELECT v1 * 2 FROM test WHERE pk = x;
+CREATE PROCEDURE test_count() SELECT COUNT(*) FROM test;
+SQL
+    run dolt sql -q "CALL double_v1(2)" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "8" ]] || false
+    [[ "${#lines[@]}" = "2" ]] || false
+    run dolt sql -q "CALL test_count" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "2" ]] || false
+    run dolt sql -q "SELECT type, name FROM dolt_schemas ORDER BY name" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "type,name" ]] || false
+    [[ "$output" =~ "procedure,double_v1" ]] || false
+    [[ "$output" =~ "procedure,test_count" ]] || false
+    run dolt sql -q "CALL double_v1()"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "incorrect number of arguments for PROCEDURE double_v1; expected 1, got 0" ]] || false
+}
+
+@test "procedures: CALL runs every statement of a BEGIN END body" {
+    dolt sql -q "CREATE PROCEDURE add_row(p INT, v INT) BEGIN INSERT INTO test VALUES (p, v); SELECT SUM(v1) FROM test; END"
+    run dolt sql -q "CALL add_row(3, 9)" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "14" ]] || false
+    run dolt sql -q "SELECT pk, v1 FROM test WHERE pk = 3" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "3,9" ]] || false
+    dolt sql <<SQL
+CALL add_row(4, 16);
+CALL add_row(5, 25);
+SQL
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "5" ]] || false
+}
+
+@test "procedures: DROP PROCEDURE" {
+    dolt sql -q "CREATE PROCEDURE p1() SELECT 1"
+    run dolt sql -q "CREATE PROCEDURE P1() SELECT 2"
+    [ "$status" -eq "1" ]
+    dolt sql -q "DROP PROCEDURE p1"
+    run dolt sql -q "CALL p1()"
+    [ "$status" -eq "1" ]
+    run dolt sql -q "DROP PROCEDURE p1"
+    [ "$status" -eq "1" ]
+    dolt sql -q "DROP PROCEDURE IF EXISTS p1"
+    run dolt sql -q "SELECT COUNT(*) FROM dolt_schemas WHERE type = 'procedure'" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "procedures: only IN parameters are supported" {
+    run dolt sql -q "CREATE PROCEDURE p1(OUT x INT) SELECT 1"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "only IN parameters are supported in PROCEDURE p1" ]] || false
+}
@@ -134,3 +134,25 @@ SQL
     run dolt sql -q "INSERT INTO test2 (pk1, pk2new) VALUES (1, null)"
     [ "$status" -eq 1 ]
 }
+
+@test "alter table changes and drops the primary key" {
+    dolt sql -q 'insert into test values (1,10,0,0,0,0), (2,20,0,0,0,0)'
+    run dolt sql -q 'alter table test add primary key (c1)'
+    [ "$status" -eq 0 ]
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "PRIMARY KEY (\`c1\`)" ]] || false
+
+    run dolt sql -q 'alter table test add primary key (c2)'
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "duplicate primary key" ]] || false
+
+    run dolt sql -q 'alter table test drop primary key'
+    [ "$status" -eq 0 ]
+    run dolt schema show test
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "PRIMARY KEY" ]] || false
+    run dolt sql -q 'select count(*) from test' -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "2" ]
+}
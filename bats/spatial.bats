@@ -0,0 +1,101 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE regions (
+    pk int PRIMARY KEY,
+    center POINT,
+    boundary POLYGON,
+    shape GEOMETRY
+);
+INSERT INTO regions VALUES
+    (1, ST_GeomFromText('POINT(1 2)', 4326), ST_PolyFromText('POLYGON((0 0,4 0,4 4,0 4,0 0))'), ST_GeomFromText('LINESTRING(0 0,1 1)')),
+    (2, NULL, NULL, ST_GeomFromText('POINT(3 4)'));
+SQL
+    dolt add .
+    dolt commit -m "init"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "schema show lists spatial types" {
+    run dolt schema show regions
+    [ $status -eq 0 ]
+    [[ "$output" =~ "\`center\` point" ]] || false
+    [[ "$output" =~ "\`boundary\` polygon" ]] || false
+    [[ "$output" =~ "\`shape\` geometry" ]] || false
+}
+
+@test "ST functions read spatial values" {
+    run dolt sql -r csv -q "SELECT pk, ST_AsText(center), ST_X(center), ST_Y(center), ST_SRID(center), ST_GeometryType(shape) FROM regions ORDER BY pk;"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1,POINT(1 2),1,2,4326,LINESTRING" ]] || false
+    [[ "$output" =~ "2,,,,,POINT" ]] || false
+
+    run dolt sql -q "SELECT ST_AsGeoJSON(shape) FROM regions WHERE pk = 2;"
+    [ $status -eq 0 ]
+    [[ "$output" =~ '{"coordinates":[3,4],"type":"Point"}' ]] || false
+}
+
+@test "spatial columns only accept their own type" {
+    run dolt sql -q "INSERT INTO regions (pk, center) VALUES (3, ST_GeomFromText('LINESTRING(0 0,1 1)'));"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "cannot store a LINESTRING in a POINT field" ]] || false
+
+    run dolt sql -q "INSERT INTO regions (pk, shape) VALUES (3, ST_GeomFromText('POINT(1)'));"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "invalid GIS data" ]] || false
+}
+
+@test "alter table adds a spatial column" {
+    dolt sql -q "ALTER TABLE regions ADD COLUMN route LINESTRING;"
+    dolt sql -q "UPDATE regions SET route = ST_LineFromText('LINESTRING(0 0,2 2)') WHERE pk = 1;"
+    run dolt sql -r csv -q "SELECT ST_AsText(route) FROM regions WHERE pk = 1;"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "LINESTRING(0 0,2 2)" ]] || false
+    run dolt schema show regions
+    [[ "$output" =~ "\`route\` linestring" ]] || false
+}
+
+@test "spatial values round trip through csv and json" {
+    run dolt sql -r csv -q "SELECT pk, ST_AsText(center), ST_SRID(center), ST_AsText(boundary), ST_AsText(shape) FROM regions ORDER BY pk;"
+    [ $status -eq 0 ]
+    expected="$output"
+
+    dolt table export regions regions.csv
+    run cat regions.csv
+    [[ "$output" =~ "1,SRID=4326;POINT(1 2)" ]] || false
+
+    dolt table export regions regions.json
+    run cat regions.json
+    [[ "$output" =~ '"type":"Polygon"' ]] || false
+    [[ "$output" =~ '"name":"EPSG:4326"' ]] || false
+
+    dolt sql -q "DELETE FROM regions;"
+    dolt table import -u regions regions.csv
+    run dolt sql -r csv -q "SELECT pk, ST_AsText(center), ST_SRID(center), ST_AsText(boundary), ST_AsText(shape) FROM regions ORDER BY pk;"
+    [ $status -eq 0 ]
+    [ "$output" = "$expected" ]
+
+    dolt sql -q "DELETE FROM regions;"
+    dolt table import -u regions regions.json
+    run dolt sql -r csv -q "SELECT pk, ST_AsText(center), ST_SRID(center), ST_AsText(boundary), ST_AsText(shape) FROM regions ORDER BY pk;"
+    [ $status -eq 0 ]
+    [ "$output" = "$expected" ]
+}
+
+@test "diff shows spatial values" {
+    dolt sql -q "UPDATE regions SET center = ST_GeomFromText('POINT(5 6)', 4326) WHERE pk = 1;"
+    run dolt diff
+    [ $status -eq 0 ]
+    [[ "$output" =~ "SRID=4326;POINT(5 6)" ]] || false
+
+    run dolt diff -r sql
+    [ $status -eq 0 ]
+    [[ "$output" =~ "ST_GeomFromText('POINT(5 6)', 4326)" ]] || false
+}
@@ -486,10 +486,18 @@ SQL
     [[ "$output" =~ "c6" ]] || false
 }
 
-@test "sql alter table to change column type not supported" {
-    run dolt sql -q "alter table one_pk modify column c5 varchar(80)"
+@test "sql alter table to change column type" {
+    dolt sql -q "update one_pk set c5 = 12345 where pk = 0"
+    dolt sql -q "alter table one_pk modify column c5 varchar(80)"
+    run dolt schema show one_pk
+    [ $status -eq 0 ]
+    [[ "$output" =~ '`c5` varchar(80)' ]] || false
+    run dolt sql -q "select c5 from one_pk where pk = 0" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "12345" ]] || false
+    run dolt sql -q "alter table one_pk modify column c5 tinyint"
     [ $status -eq 1 ]
-    [[ "$output" =~ "unsupported feature: column types cannot be changed" ]] || false
+    [[ "$output" =~ "cannot convert value" ]] || false
 }
 
 @test "sql alter table modify column with no actual change" {
@@ -0,0 +1,65 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE parent (
+    pk int PRIMARY KEY,
+    v1 int NOT NULL
+);
+CREATE TABLE child (
+    pk int PRIMARY KEY,
+    parent_pk int,
+    INDEX (parent_pk),
+    CONSTRAINT fk_parent FOREIGN KEY (parent_pk) REFERENCES parent(pk)
+);
+INSERT INTO parent VALUES (1, 1), (2, 2);
+INSERT INTO child VALUES (1, 1), (2, 2);
+SQL
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "verify: no violations in a valid repo" {
+    run dolt verify
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "No constraint violations found." ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_constraint_violations" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt sql -q "SELECT DOLT_VERIFY()" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "0" ]] || false
+}
+
+@test "verify: reports foreign key violations written with checks disabled" {
+    dolt sql <<SQL
+SET FOREIGN_KEY_CHECKS=0;
+INSERT INTO child VALUES (3, 5);
+SQL
+
+    run dolt verify
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "child: foreign key \`fk_parent\`" ]] || false
+    [[ "$output" =~ "Found 1 constraint violation(s)." ]] || false
+
+    run dolt sql -q "SELECT table_name, violation_type, name FROM dolt_constraint_violations" -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "child,foreign key,fk_parent" ]] || false
+    [ "${#lines[@]}" -eq "2" ]
+
+    run dolt sql -q "SELECT DOLT_VERIFY()" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "1" ]] || false
+}
+
+@test "verify: rejects arguments" {
+    run dolt verify child
+    [ "$status" -ne "0" ]
+}
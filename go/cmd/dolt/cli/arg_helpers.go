@@ -154,7 +154,10 @@ func ParseKeyValues(nbf *types.NomsBinFormat, sch schema.Schema, args []string)
 				return types.String(*v), nil
 			}
 		} else {
-			convFuncs[tag] = col.TypeInfo.ParseValue
+			// primary key columns are never stored out-of-line, so no ValueReadWriter is needed
+			convFuncs[tag] = func(v *string) (types.Value, error) {
+				return col.TypeInfo.ParseValue(context.TODO(), nil, v)
+			}
 		}
 		return false, nil
 	})
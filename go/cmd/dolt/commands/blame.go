@@ -48,6 +48,9 @@ type blameInfo struct {
 	// Key represents the primary key of the row
 	Key types.Value
 
+	// Value is the value of the row, which identifies the rows of keyless tables
+	Value types.Value
+
 	// CommitHash is the commit hash of the commit which last modified the row
 	CommitHash string
 
@@ -176,12 +179,17 @@ func runBlame(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, tab
 		return err
 	}
 
-	pkColNames, err := pkColNamesFromCommit(ctx, commit, tableName)
+	sch, err := schemaFromCommit(ctx, commit, tableName)
+	if err != nil {
+		return fmt.Errorf("error getting schema for commit: %v", err)
+	}
+
+	str, err := blameGraph.String(ctx, sch)
 	if err != nil {
 		return err
 	}
 
-	cli.Println(blameGraph.String(ctx, pkColNames))
+	cli.Println(str)
 	return nil
 }
 
@@ -398,14 +406,6 @@ func schemaFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) (
 	return schema, nil
 }
 
-func pkColNamesFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) ([]string, error) {
-	schema, err := schemaFromCommit(ctx, c, tableName)
-	if err != nil {
-		return nil, fmt.Errorf("error getting schema for commit: %v", err)
-	}
-	return schema.GetPKCols().GetColumnNames(), nil
-}
-
 // rowChanged returns true if the row identified by `rowPK` changed between the parent-child commit pair
 // represented by `input`
 func rowChanged(ctx context.Context, input blameInput, rowPK types.Value) (bool, error) {
@@ -455,6 +455,11 @@ func rowChanged(ctx context.Context, input blameInput, rowPK types.Value) (bool,
 		return true, nil
 	}
 
+	// the rows of keyless tables are identified by their contents, so they only change in number
+	if row.GetCardinality(*parentRow) != row.GetCardinality(*childRow) {
+		return true, nil
+	}
+
 	return !row.AreEqual(*parentRow, *childRow, input.ParentSchema), nil
 }
 
@@ -465,7 +470,7 @@ func blameGraphFromRows(ctx context.Context, nbf *types.NomsBinFormat, rows type
 		if err != nil {
 			return err
 		}
-		graph[hash] = blameInfo{Key: key}
+		graph[hash] = blameInfo{Key: key, Value: val}
 		return nil
 	})
 	if err != nil {
@@ -492,13 +497,13 @@ func (bg *blameGraph) AssignBlame(rowPK types.Value, nbf *types.NomsBinFormat, c
 		return fmt.Errorf("error getting PK hash for commit %s: %v", commitHash.String(), err)
 	}
 
-	(*bg)[pkHash] = blameInfo{
-		Key:         rowPK,
-		CommitHash:  commitHash.String(),
-		Author:      meta.Name,
-		Description: meta.Description,
-		Timestamp:   meta.UserTimestamp,
-	}
+	info := (*bg)[pkHash]
+	info.Key = rowPK
+	info.CommitHash = commitHash.String()
+	info.Author = meta.Name
+	info.Description = meta.Description
+	info.Timestamp = meta.UserTimestamp
+	(*bg)[pkHash] = info
 
 	return nil
 }
@@ -517,6 +522,25 @@ func getPKStrs(ctx context.Context, pk types.Value) (strs []string) {
 	return strs
 }
 
+// getKeylessRowStrs returns the values of every column of a row of a keyless table, which identify the row.
+func getKeylessRowStrs(sch schema.Schema, key, val types.Value) ([]string, error) {
+	r, err := row.FromNoms(sch, key.(types.Tuple), val.(types.Tuple))
+	if err != nil {
+		return nil, err
+	}
+
+	var strs []string
+	for _, tag := range sch.GetAllCols().Tags {
+		colVal, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(colVal) {
+			strs = append(strs, "NULL")
+		} else {
+			strs = append(strs, fmt.Sprintf("%v", colVal))
+		}
+	}
+	return strs, nil
+}
+
 func truncateString(str string, maxLength int) string {
 	if maxLength < 0 || len(str) <= maxLength {
 		return str
@@ -535,19 +559,35 @@ func truncateString(str string, maxLength int) string {
 
 var dataColNames = []string{"Commit Msg", "Author", "Time", "Commit"}
 
-// String returns the string representation of this blame graph
-func (bg *blameGraph) String(ctx context.Context, pkColNames []string) string {
+// String returns the string representation of this blame graph. Rows are identified by their primary key, or by the
+// values of all their columns for keyless tables.
+func (bg *blameGraph) String(ctx context.Context, sch schema.Schema) (string, error) {
+	keyless := schema.IsKeyless(sch)
+	keyColNames := sch.GetPKCols().GetColumnNames()
+	if keyless {
+		keyColNames = sch.GetAllCols().GetColumnNames()
+	}
+
 	// here we have two []string and need one []interface{} (aka table.Row)
 	// this works but is not beautiful. if you know a better way, have at it!
 	header := []interface{}{}
-	for _, cellText := range append(pkColNames, dataColNames...) {
+	for _, cellText := range append(keyColNames, dataColNames...) {
 		header = append(header, cellText)
 	}
 
 	t := table.NewWriter()
 	t.AppendHeader(header)
 	for _, v := range *bg {
-		pkVals := getPKStrs(ctx, v.Key)
+		var pkVals []string
+		if keyless {
+			var err error
+			pkVals, err = getKeylessRowStrs(sch, v.Key, v.Value)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			pkVals = getPKStrs(ctx, v.Key)
+		}
 		dataVals := []string{
 			truncateString(v.Description, 50),
 			v.Author,
@@ -564,5 +604,5 @@ func (bg *blameGraph) String(ctx context.Context, pkColNames []string) string {
 		}
 		t.AppendRow(row)
 	}
-	return t.Render()
+	return t.Render(), nil
 }
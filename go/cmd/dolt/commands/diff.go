@@ -392,8 +392,12 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 		}
 
 		if dArgs.diffParts&Summary != 0 {
-			numCols := fromSch.GetAllCols().Size()
-			verr = diffSummary(ctx, fromMap, toMap, numCols)
+			if schema.ArePrimaryKeySetsDiffable(fromSch, toSch) {
+				numCols := fromSch.GetAllCols().Size()
+				verr = diffSummary(ctx, fromMap, toMap, numCols)
+			} else {
+				cli.PrintErrf("Primary key sets differ between revisions for table %s, skipping diff summary\n", tblName)
+			}
 		}
 
 		if dArgs.diffParts&SchemaOnlyDiff != 0 {
@@ -406,7 +410,11 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 			} else if td.IsAdd() {
 				fromSch = toSch
 			}
-			verr = diffRows(ctx, fromMap, toMap, fromSch, toSch, dArgs, tblName)
+			if schema.ArePrimaryKeySetsDiffable(fromSch, toSch) {
+				verr = diffRows(ctx, toRoot.VRW(), fromMap, toMap, fromSch, toSch, dArgs, tblName)
+			} else {
+				cli.PrintErrf("Primary key sets differ between revisions for table %s, skipping data diff\n", tblName)
+			}
 		}
 
 		if verr != nil {
@@ -487,11 +495,15 @@ func tabularSchemaDiff(ctx context.Context, td diff.TableDelta, fromSchemas, toS
 		}
 	}
 
-	if !schema.ColCollsAreEqual(fromSch.GetPKCols(), toSch.GetPKCols()) {
-		panic("primary key sets must be the same")
+	if schema.ArePrimaryKeySetsDiffable(fromSch, toSch) {
+		pkStr := strings.Join(toSch.GetPKCols().GetColumnNames(), ", ")
+		cli.Print(sqlfmt.FmtColPrimaryKey(4, pkStr))
+	} else {
+		fromPkStr := strings.Join(fromSch.GetPKCols().GetColumnNames(), ", ")
+		toPkStr := strings.Join(toSch.GetPKCols().GetColumnNames(), ", ")
+		cli.Print("<" + sqlfmt.FmtColPrimaryKey(3, color.YellowString(fromPkStr)))
+		cli.Print(">" + sqlfmt.FmtColPrimaryKey(3, color.YellowString(toPkStr)))
 	}
-	pkStr := strings.Join(fromSch.GetPKCols().GetColumnNames(), ", ")
-	cli.Print(sqlfmt.FmtColPrimaryKey(4, pkStr))
 
 	for _, idxDiff := range diff.DiffSchIndexes(fromSch, toSch) {
 		switch idxDiff.DiffType {
@@ -566,10 +578,17 @@ func sqlSchemaDiff(ctx context.Context, td diff.TableDelta, toSchemas map[string
 			case diff.SchDiffRemoved:
 				cli.Print(sqlfmt.AlterTableDropColStmt(td.ToName, cd.Old.Name))
 			case diff.SchDiffModified:
-				cli.Print(sqlfmt.AlterTableRenameColStmt(td.ToName, cd.Old.Name, cd.New.Name))
+				if cd.Old.Name != cd.New.Name {
+					cli.Print(sqlfmt.AlterTableRenameColStmt(td.ToName, cd.Old.Name, cd.New.Name))
+				}
 			}
 		}
 
+		if !schema.ArePrimaryKeySetsDiffable(fromSch, toSch) {
+			cli.Println(sqlfmt.AlterTableDropPrimaryKeyStmt(td.ToName))
+			cli.Println(sqlfmt.AlterTableAddPrimaryKeyStmt(td.ToName, toSch.GetPKCols().GetColumnNames()))
+		}
+
 		for _, idxDiff := range diff.DiffSchIndexes(fromSch, toSch) {
 			switch idxDiff.DiffType {
 			case diff.SchDiffNone:
@@ -631,7 +650,7 @@ func fromNamer(name string) string {
 	return diff.From + "_" + name
 }
 
-func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch schema.Schema, dArgs *diffArgs, tblName string) errhand.VerboseError {
+func diffRows(ctx context.Context, vrw types.ValueReadWriter, fromRows, toRows types.Map, fromSch, toSch schema.Schema, dArgs *diffArgs, tblName string) errhand.VerboseError {
 	joiner, err := rowconv.NewJoiner(
 		[]rowconv.NamedSchema{
 			{Name: diff.From, Sch: fromSch},
@@ -644,7 +663,7 @@ func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch sc
 		return errhand.BuildDError("").AddCause(err).Build()
 	}
 
-	unionSch, ds, verr := createSplitter(fromSch, toSch, joiner, dArgs)
+	unionSch, ds, verr := createSplitter(ctx, vrw, fromSch, toSch, joiner, dArgs)
 	if verr != nil {
 		return verr
 	}
@@ -678,7 +697,7 @@ func diffRows(ctx context.Context, fromRows, toRows types.Map, fromSch, toSch sc
 	if dArgs.diffOutput == TabularDiffOutput {
 		sink, err = diff.NewColorDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, numHeaderRows)
 	} else {
-		sink, err = diff.NewSQLDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, tblName)
+		sink, err = diff.NewSQLDiffSink(ctx, iohelp.NopWrCloser(cli.CliOut), unionSch, tblName)
 	}
 
 	if err != nil {
@@ -803,7 +822,7 @@ func mapTagToColName(sch, untypedUnionSch schema.Schema) (map[uint64]string, err
 	return tagToCol, nil
 }
 
-func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.Joiner, dArgs *diffArgs) (schema.Schema, *diff.DiffSplitter, errhand.VerboseError) {
+func createSplitter(ctx context.Context, vrw types.ValueReadWriter, fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.Joiner, dArgs *diffArgs) (schema.Schema, *diff.DiffSplitter, errhand.VerboseError) {
 
 	var unionSch schema.Schema
 	if dArgs.diffOutput == TabularDiffOutput {
@@ -836,7 +855,7 @@ func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.
 			return nil, nil, errhand.BuildDError("Error creating unioned mapping").AddCause(err).Build()
 		}
 
-		newToUnionConv, _ = rowconv.NewRowConverter(newToUnionMapping)
+		newToUnionConv, _ = rowconv.NewRowConverter(ctx, vrw, newToUnionMapping)
 	}
 
 	oldToUnionConv := rowconv.IdentityConverter
@@ -847,10 +866,10 @@ func createSplitter(fromSch schema.Schema, toSch schema.Schema, joiner *rowconv.
 			return nil, nil, errhand.BuildDError("Error creating unioned mapping").AddCause(err).Build()
 		}
 
-		oldToUnionConv, _ = rowconv.NewRowConverter(oldToUnionMapping)
+		oldToUnionConv, _ = rowconv.NewRowConverter(ctx, vrw, oldToUnionMapping)
 	}
 
-	ds := diff.NewDiffSplitter(joiner, oldToUnionConv, newToUnionConv)
+	ds := diff.NewDiffSplitter(ctx, joiner, oldToUnionConv, newToUnionConv)
 	return unionSch, ds, nil
 }
 
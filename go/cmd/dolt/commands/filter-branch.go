@@ -24,7 +24,6 @@ import (
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/auth"
 	"github.com/dolthub/go-mysql-server/sql"
-	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/vitess/go/vt/sqlparser"
 	"github.com/fatih/color"
 	"gopkg.in/src-d/go-errors.v1"
@@ -201,7 +200,7 @@ func monoSqlEngine(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValu
 	}
 
 	parallelism := runtime.GOMAXPROCS(0)
-	azr := analyzer.NewBuilder(cat).WithParallelism(parallelism).Build()
+	azr := dsqle.NewAnalyzerBuilder(cat).WithParallelism(parallelism).Build()
 
 	engine := sqle.New(cat, azr, &sqle.Config{Auth: new(auth.None)})
 	engine.AddDatabase(db)
@@ -16,6 +16,7 @@ package indexcmds
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -137,7 +138,7 @@ func (cmd CatCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		return HandleErr(errhand.BuildDError("The index `%s` does not have a data map.", indexName).Build(), nil)
 	}
 
-	err = cmd.prettyPrintResults(ctx, index.Schema(), indexRowData)
+	err = cmd.prettyPrintResults(ctx, index, indexRowData)
 	if err != nil {
 		return HandleErr(errhand.BuildDError("Unable to display data for `%s`.", indexName).AddCause(err).Build(), nil)
 	}
@@ -146,8 +147,9 @@ func (cmd CatCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 }
 
 // TODO: merge this with the cmd/sql.go code, which is what this was modified from
-func (cmd CatCmd) prettyPrintResults(ctx context.Context, doltSch schema.Schema, rowData types.Map) error {
+func (cmd CatCmd) prettyPrintResults(ctx context.Context, index schema.Index, rowData types.Map) error {
 	nbf := types.Format_Default
+	doltSch := index.Schema()
 
 	untypedSch, err := untyped.UntypeUnkeySchema(doltSch)
 	if err != nil {
@@ -238,7 +240,14 @@ func (cmd CatCmd) prettyPrintResults(ctx context.Context, doltSch schema.Schema,
 				}
 				if val != types.NullValue {
 					tag := uint64(tagVal.(types.Uint))
-					strPtr, err := doltSch.GetAllCols().TagToCol[tag].TypeInfo.FormatValue(val)
+					if blob, ok := val.(types.InlineBlob); ok {
+						if col, _ := index.GetColumn(tag); schema.UsesCollationKeys(col) {
+							// collation keys aren't readable text
+							taggedValues[tag] = types.String("0x" + hex.EncodeToString([]byte(blob)))
+							continue
+						}
+					}
+					strPtr, err := doltSch.GetAllCols().TagToCol[tag].TypeInfo.FormatValue(ctx, val)
 					if err != nil {
 						return nil, err
 					}
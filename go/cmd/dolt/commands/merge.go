@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -400,9 +401,15 @@ func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, me
 
 	if verr == nil {
 		hasConflicts := printSuccessStats(tblToStats)
+		hasViolations, err := printCheckViolations(ctx, mergedRoot, tblToStats)
+		if err != nil {
+			return errhand.BuildDError("error: failed to print check constraint violations").AddCause(err).Build()
+		}
 
 		if hasConflicts {
 			cli.Println("Automatic merge failed; fix conflicts and then commit the result.")
+		} else if hasViolations {
+			cli.Println("Automatic merge failed; fix check constraint violations and then commit the result.")
 		} else {
 			err = actions.SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
 			if err != nil {
@@ -456,6 +463,29 @@ func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	return hasConflicts
 }
 
+// printCheckViolations prints the rows of the merged tables that violate their check constraints, and returns whether
+// there were any.
+func printCheckViolations(ctx context.Context, mergedRoot *doltdb.RootValue, tblToStats map[string]*merge.MergeStats) (bool, error) {
+	tblNames := merge.TablesWithCheckViolations(tblToStats)
+	for _, tblName := range tblNames {
+		tbl, _, err := mergedRoot.GetTable(ctx, tblName)
+		if err != nil {
+			return false, err
+		}
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		cli.Println("CONFLICT (check constraint): Merge result violates check constraints in", tblName)
+		for _, violation := range tblToStats[tblName].CheckViolations {
+			cli.Printf("\t%s: %s\n", violation.Check.Name(), row.Fmt(ctx, violation.Row, sch))
+		}
+	}
+
+	return len(tblNames) > 0, nil
+}
+
 func printModifications(tblToStats map[string]*merge.MergeStats) {
 	maxNameLen := 0
 	maxModCount := 0
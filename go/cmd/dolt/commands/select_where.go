@@ -15,6 +15,7 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"strings"
 
@@ -72,13 +73,17 @@ func ParseWhere(sch schema.Schema, whereClause string) (FilterFn, error) {
 		if typeinfo.IsStringType(cols[0].TypeInfo) {
 			val = types.String(valStr)
 		} else {
+			// values stored out-of-line are compared by their hash, so they needn't be written anywhere that lasts
 			var err error
-			val, err = cols[0].TypeInfo.ParseValue(&valStr)
+			val, err = cols[0].TypeInfo.ParseValue(context.Background(), types.NewMemoryValueStore(), &valStr)
 			if err != nil {
 				return nil, errors.New("unable to convert '" + valStr + "' to " + col.TypeInfo.String())
 			}
 		}
 
+		// values of collated columns match values that are equal according to the column's collation
+		collated := schema.UsesCollationKeys(cols[0])
+
 		return func(r row.Row) bool {
 			for _, tag := range tags {
 				rowVal, ok := r.GetColVal(tag)
@@ -87,7 +92,7 @@ func ParseWhere(sch schema.Schema, whereClause string) (FilterFn, error) {
 					continue
 				}
 
-				if val.Equals(rowVal) {
+				if val.Equals(rowVal) || collated && typeinfo.ValuesEqual(cols[0].TypeInfo, val, rowVal) {
 					return true
 				}
 			}
@@ -27,7 +27,6 @@ import (
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/auth"
 	"github.com/dolthub/go-mysql-server/sql"
-	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/information_schema"
 	"github.com/dolthub/go-mysql-server/sql/parse"
@@ -44,6 +43,7 @@ import (
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
@@ -321,10 +321,21 @@ func (cmd SqlCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
-	// If the SQL session wrote a new root value, update the working set with it
+	// If the SQL session wrote a new root value, update the working set with it. The working set of a branch the
+	// session switched to with DOLT_CHECKOUT is only kept by the session, so changes to it which weren't committed
+	// are lost.
 	for name, origRoot := range initialRoots {
 		root := roots[name]
-		if origRoot != root {
+		if headRef, ok := dsess.GetHeadRef(name); ok && !ref.Equals(headRef, mrEnv[name].RepoState.CWBHeadRef()) {
+			dirty, err := dsess.HasUncommittedChanges(sqlCtx, name)
+			if err != nil {
+				verr = errhand.VerboseErrorFromError(err)
+			} else if dirty {
+				verr = errhand.BuildDError("error: uncommitted changes to branch %s were discarded", headRef.GetPath()).
+					AddDetails("Only the working set of the checked out branch is saved. Commit changes to other branches before exiting.").
+					Build()
+			}
+		} else if origRoot != root {
 			currEnv := mrEnv[name]
 			verr = UpdateWorkingWithVErr(currEnv, root)
 		}
@@ -376,11 +387,11 @@ func execBatch(sqlCtx *sql.Context, readOnly bool, mrEnv env.MultiRepoEnv, roots
 type createDBFunc func(name string, dEnv *env.DoltEnv) dsqle.Database
 
 func newDatabase(name string, dEnv *env.DoltEnv) dsqle.Database {
-	return dsqle.NewDatabase(name, dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter())
+	return dsqle.NewDatabase(name, dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter()).WithRemotes(dEnv)
 }
 
 func newBatchedDatabase(name string, dEnv *env.DoltEnv) dsqle.Database {
-	return dsqle.NewBatchedDatabase(name, dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter())
+	return dsqle.NewBatchedDatabase(name, dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter()).WithRemotes(dEnv)
 }
 
 func execQuery(sqlCtx *sql.Context, readOnly bool, mrEnv env.MultiRepoEnv, roots map[string]*doltdb.RootValue, query string, format resultFormat) (newRoot map[string]*doltdb.RootValue, verr errhand.VerboseError) {
@@ -811,6 +822,17 @@ func prepend(s string, ss []string) []string {
 // Processes a single query. The Root of the sqlEngine will be updated if necessary.
 // Returns the schema and the row iterator for the results, which may be nil, and an error if one occurs.
 func processQuery(ctx *sql.Context, query string, se *sqlEngine) (sql.Schema, sql.RowIter, error) {
+	// the engine doesn't support stored procedures, so CALL statements are replaced with the procedure's body
+	query, err := dsqle.ExpandCall(ctx, se.engine, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the engine doesn't support CHECK constraints or spatial types, so statements that use them are run before parsing
+	if handled, err := dsqle.ExecuteExtendedDDL(ctx, se.engine, query); handled || err != nil {
+		return nil, nil, err
+	}
+
 	sqlStatement, err := sqlparser.Parse(query)
 	if err == sqlparser.ErrEmpty {
 		// silently skip empty statements
@@ -907,6 +929,30 @@ func flushBatchedEdits(ctx *sql.Context, se *sqlEngine) error {
 
 // Processes a single query in batch mode. The Root of the sqlEngine may or may not be changed.
 func processBatchQuery(ctx *sql.Context, query string, se *sqlEngine) error {
+	// the engine doesn't support stored procedures, so CALL statements are replaced with the procedure's body, which is
+	// run after the edits batched so far
+	if dsqle.IsCall(query) {
+		if err := flushBatchedEdits(ctx, se); err != nil {
+			return err
+		}
+		expanded, err := dsqle.ExpandCall(ctx, se.engine, query)
+		if err != nil {
+			return err
+		}
+		query = expanded
+	}
+
+	// the engine doesn't support CHECK constraints or spatial types, so statements that use them are run after the
+	// edits batched so far
+	if dsqle.MayBeExtendedDDL(query) {
+		if err := flushBatchedEdits(ctx, se); err != nil {
+			return err
+		}
+		if handled, err := dsqle.ExecuteExtendedDDL(ctx, se.engine, query); handled || err != nil {
+			return err
+		}
+	}
+
 	sqlStatement, err := sqlparser.Parse(query)
 	if err == sqlparser.ErrEmpty {
 		// silently skip empty statements
@@ -1131,7 +1177,7 @@ func newSqlEngine(sqlCtx *sql.Context, readOnly bool, mrEnv env.MultiRepoEnv, ro
 	}
 
 	parallelism := runtime.GOMAXPROCS(0)
-	engine := sqle.New(c, analyzer.NewBuilder(c).WithParallelism(parallelism).Build(), &sqle.Config{Auth: au})
+	engine := sqle.New(c, dsqle.NewAnalyzerBuilder(c).WithParallelism(parallelism).Build(), &sqle.Config{Auth: au})
 	engine.AddDatabase(information_schema.NewInformationSchemaDatabase(engine.Catalog))
 
 	dsess := dsqle.DSessFromSess(sqlCtx.Session)
@@ -1226,6 +1272,8 @@ func PrettyPrintResults(ctx context.Context, resultFormat resultFormat, sqlSch s
 	}
 
 	nbf := types.Format_Default
+	// values of result rows which are stored out-of-line are only held while they're printed
+	vrw := types.NewMemoryValueStore()
 
 	doltSch, err := sqlutil.ToDoltResultSchema(sqlSch)
 	if err != nil {
@@ -1302,15 +1350,26 @@ func PrettyPrintResults(ctx context.Context, resultFormat resultFormat, sqlSch s
 	switch resultFormat {
 	case FormatJson:
 		rowFn = func(r sql.Row) (r2 row.Row, err error) {
-			return sqlutil.SqlRowToDoltRow(nbf, r, doltSch)
+			return sqlutil.SqlRowToDoltRow(ctx, vrw, r, doltSch)
 		}
 	default:
 		rowFn = func(r sql.Row) (row.Row, error) {
 			taggedVals := make(row.TaggedValues)
 			for i, col := range r {
-				if col != nil {
-					taggedVals[uint64(i)] = types.String(fmt.Sprintf("%v", col))
+				if col == nil {
+					continue
+				}
+				if doc, ok := col.([]byte); ok && sqlSch[i].Type == sql.JSON {
+					col = string(doc)
+				} else if sqlSch[i].Type == sql.JSON {
+					// the results of functions such as JSON_EXTRACT are values within documents, so print their encoding
+					doc, err := sql.JSON.Convert(col)
+					if err != nil {
+						return nil, err
+					}
+					col = string(doc.([]byte))
 				}
+				taggedVals[uint64(i)] = types.String(fmt.Sprintf("%v", col))
 			}
 			return row.New(nbf, untypedSch, taggedVals)
 		}
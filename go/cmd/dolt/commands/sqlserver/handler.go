@@ -0,0 +1,350 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"errors"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	querypb "github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+// doltHandler wraps the go-mysql-server Handler so that clients can work on a particular branch or commit of a
+// database by naming it in their connection string, e.g. `mydb/feature-branch` or `mydb@<commit>`. The connection's
+// session switches to the revision named, as DOLT_CHECKOUT does, so nothing is added to the engine's catalog.
+type doltHandler struct {
+	*server.Handler
+	sqlEngine *sqle.Engine
+	sm        *server.SessionManager
+	limits    queryLimits
+	tracker   *sessionTracker
+}
+
+var _ mysql.Handler = &doltHandler{}
+
+var errPreparedStatementParams = errors.New("CALL statements and statements using check constraints, spatial types or generated columns, changing a primary key, or creating a stored procedure can't have parameters in a prepared statement")
+
+// newDoltHandler returns a handler for the engine given which creates sessions with the session builder given,
+// enforces the query limits given, and records the state of every connection in the tracker given.
+func newDoltHandler(cfg server.Config, sqlEngine *sqle.Engine, sb server.SessionBuilder, limits queryLimits, tracker *sessionTracker) *doltHandler {
+	tracer := cfg.Tracer
+	if tracer == nil {
+		tracer = opentracing.NoopTracer{}
+	}
+
+	sm := server.NewSessionManager(sb, tracer, sqlEngine.Catalog.HasDB, sqlEngine.Catalog.MemoryManager, cfg.Address)
+	return &doltHandler{
+		Handler:   server.NewHandler(sqlEngine, sm, cfg.ConnReadTimeout),
+		sqlEngine: sqlEngine,
+		sm:        sm,
+		limits:    limits,
+		tracker:   tracker,
+	}
+}
+
+// newListener returns a listener for the server configured by cfg, which serves its connections with the handler
+// given. server.NewServer can't be given a handler of its own, so the listener is created here.
+func newListener(cfg server.Config, handler *doltHandler) (*mysql.Listener, error) {
+	l, err := server.NewListener(cfg.Protocol, cfg.Address, handler.Handler)
+	if err != nil {
+		return nil, err
+	}
+
+	vtListener, err := mysql.NewListenerWithConfig(mysql.ListenerConfig{
+		Listener:           l,
+		AuthServer:         cfg.Auth.Mysql(),
+		Handler:            handler,
+		ConnReadTimeout:    cfg.ConnReadTimeout,
+		ConnWriteTimeout:   cfg.ConnWriteTimeout,
+		MaxConns:           cfg.MaxConnections,
+		ConnReadBufferSize: mysql.DefaultConnBufferSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Version != "" {
+		vtListener.ServerVersion = cfg.Version
+	}
+
+	return vtListener, nil
+}
+
+// NewConnection implements mysql.Handler.
+func (h *doltHandler) NewConnection(c *mysql.Conn) {
+	h.tracker.connectionOpened(c.ConnectionID, c.RemoteAddr().String())
+	h.Handler.NewConnection(c)
+}
+
+// ConnectionClosed implements mysql.Handler.
+func (h *doltHandler) ConnectionClosed(c *mysql.Conn) {
+	h.tracker.connectionClosed(c.ConnectionID)
+	h.Handler.ConnectionClosed(c)
+}
+
+// ComInitDB implements mysql.Handler. A database name which names a branch or commit of a database selects the
+// database, and switches the connection's session to the revision named.
+func (h *doltHandler) ComInitDB(c *mysql.Conn, schemaName string) error {
+	baseName, revision, isCommit, ok := dsqle.SplitRevisionDbName(schemaName)
+	if ok && !h.sqlEngine.Catalog.HasDB(schemaName) && h.sqlEngine.Catalog.HasDB(baseName) {
+		err := h.switchRevision(c, baseName, revision, isCommit)
+		if err != nil {
+			return err
+		}
+
+		schemaName = baseName
+	}
+
+	err := h.Handler.ComInitDB(c, schemaName)
+	h.recordSessionState(c)
+
+	return err
+}
+
+// switchRevision switches the connection's session to the branch or commit of the database given.
+func (h *doltHandler) switchRevision(c *mysql.Conn, dbName, revision string, isCommit bool) error {
+	sqlCtx, err := h.sm.NewContext(c)
+	if err != nil {
+		return err
+	}
+
+	dSess := dsqle.DSessFromSess(sqlCtx.Session)
+	if !isCommit {
+		branch := ref.NewBranchRef(revision)
+		if !doltdb.IsValidBranchRef(branch) {
+			return doltdb.ErrInvBranchName
+		}
+
+		_, err = dSess.SwitchBranch(sqlCtx, dbName, branch)
+		return err
+	}
+
+	cs, err := doltdb.NewCommitSpec(revision)
+	if err != nil {
+		return err
+	}
+
+	_, err = dSess.SwitchToCommit(sqlCtx, dbName, cs)
+	return err
+}
+
+// ComQuery implements mysql.Handler. Read-only databases are brought up to date with their repo state before the
+// query is run, so that each new transaction sees the latest root of a read replica. Outside of an explicit
+// transaction every query starts a new transaction from the latest working set of each database. Queries are
+// cancelled if they exceed the server's limits. SHOW PROCESSLIST is answered from information_schema.processlist,
+// which shows every connection rather than only those running queries.
+func (h *doltHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	h.tracker.queryStarted(c.ConnectionID, c.User, query)
+	defer h.recordSessionState(c)
+
+	err := h.syncReadOnlyDatabases(c)
+	if err != nil {
+		return err
+	}
+
+	handled, err := h.handleTransactionStatement(c, query)
+	if handled {
+		if err != nil {
+			return toTransactionError(c, err)
+		}
+
+		return callback(&sqltypes.Result{})
+	}
+
+	err = h.startTransaction(c)
+	if err != nil {
+		return err
+	}
+
+	query, err = h.expandCall(c, query)
+	if err != nil {
+		return err
+	}
+
+	handled, err = h.handleExtendedDDL(c, query)
+	if handled {
+		if err != nil {
+			return err
+		}
+
+		return callback(&sqltypes.Result{})
+	}
+
+	if showProcessListRegex.MatchString(query) {
+		query = showProcessListQuery
+	}
+
+	err = h.runWithLimits(c, query, callback, func(callback func(*sqltypes.Result) error) error {
+		return h.Handler.ComQuery(c, query, callback)
+	})
+
+	return toTransactionError(c, err)
+}
+
+// expandCall returns the statement to run in place of the query given if it's a CALL statement, which the engine
+// doesn't support, and the query itself otherwise.
+func (h *doltHandler) expandCall(c *mysql.Conn, query string) (string, error) {
+	if !dsqle.IsCall(query) {
+		return query, nil
+	}
+
+	sqlCtx, err := h.sm.NewContext(c)
+	if err != nil {
+		return "", err
+	}
+
+	return dsqle.ExpandCall(sqlCtx, h.sqlEngine, query)
+}
+
+// handleExtendedDDL runs the query given if it uses check constraints or spatial types, which the engine doesn't
+// support, and commits the change if the session is in autocommit mode.
+func (h *doltHandler) handleExtendedDDL(c *mysql.Conn, query string) (bool, error) {
+	sqlCtx, err := h.sm.NewContext(c)
+	if err != nil {
+		return false, err
+	}
+
+	handled, err := dsqle.ExecuteExtendedDDL(sqlCtx, h.sqlEngine, query)
+	if !handled || err != nil {
+		return handled, err
+	}
+
+	_, autocommit := sqlCtx.Get(sql.AutoCommitSessionVar)
+	if autocommit != nil {
+		if ac, _ := sql.ConvertToBool(autocommit); ac {
+			return true, sqlCtx.Session.CommitTransaction(sqlCtx)
+		}
+	}
+	return true, nil
+}
+
+// ComPrepare implements mysql.Handler. Statements which are run by the handler rather than the engine, see
+// expandCall and handleExtendedDDL, are prepared without being analyzed by the engine, which doesn't support them.
+// Statements the protocol layer can't parse, such as CALL statements and most of those using check constraints, are
+// rejected before they get here.
+func (h *doltHandler) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Field, error) {
+	if runByHandler(query) {
+		return nil, nil
+	}
+
+	return h.Handler.ComPrepare(c, query)
+}
+
+// ComStmtExecute implements mysql.Handler. Statements which are run by the handler rather than the engine are run as
+// they are by ComQuery, and can't have parameters.
+func (h *doltHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	if runByHandler(prepare.PrepareStmt) {
+		if prepare.ParamsCount > 0 {
+			return errPreparedStatementParams
+		}
+
+		return h.ComQuery(c, prepare.PrepareStmt, callback)
+	}
+
+	h.tracker.queryStarted(c.ConnectionID, c.User, prepare.PrepareStmt)
+	defer h.recordSessionState(c)
+
+	err := h.syncReadOnlyDatabases(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.startTransaction(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.runWithLimits(c, prepare.PrepareStmt, callback, func(callback func(*sqltypes.Result) error) error {
+		return h.Handler.ComStmtExecute(c, prepare, callback)
+	})
+
+	return toTransactionError(c, err)
+}
+
+// runByHandler returns whether the query given may be one of the statements the handler runs in place of the engine.
+func runByHandler(query string) bool {
+	return dsqle.IsCall(query) || dsqle.MayBeExtendedDDL(query)
+}
+
+// recordSessionState records the state of the connection's session in the session tracker. Connections which have
+// been closed, e.g. by KILL, are skipped so that their sessions are not recreated.
+func (h *doltHandler) recordSessionState(c *mysql.Conn) {
+	if !h.tracker.isOpen(c.ConnectionID) {
+		return
+	}
+
+	sqlCtx, err := h.sm.NewContext(c)
+	if err != nil {
+		logrus.Debugf("connection %d: could not record session state: %v", c.ConnectionID, err)
+		return
+	}
+
+	h.tracker.queryFinished(c.ConnectionID, c.User, sqlCtx)
+}
+
+// syncReadOnlyDatabases loads the current working root of every read-only database in the connection's session.
+func (h *doltHandler) syncReadOnlyDatabases(c *mysql.Conn) error {
+	var sqlCtx *sql.Context
+	for _, db := range dbsAsDSQLDBs(h.sqlEngine.Catalog.AllDatabases()) {
+		if !db.IsReadOnly() {
+			continue
+		}
+
+		if sqlCtx == nil {
+			var err error
+			sqlCtx, err = h.sm.NewContext(c)
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, ok := dsqle.DSessFromSess(sqlCtx.Session).GetDoltDB(db.Name()); !ok {
+			continue
+		}
+
+		err := db.SyncRootWithRepoState(sqlCtx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadSessionDatabase loads the working root of the database given into the session of the context given, and
+// registers the database's schema fragments.
+func loadSessionDatabase(sqlCtx *sql.Context, db dsqle.Database) error {
+	err := db.LoadRootFromRepoState(sqlCtx)
+	if err != nil {
+		return err
+	}
+
+	root, err := db.GetRoot(sqlCtx)
+	if err != nil {
+		return err
+	}
+
+	return dsqle.RegisterSchemaFragments(sqlCtx, db, root)
+}
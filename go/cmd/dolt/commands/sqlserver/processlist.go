@@ -0,0 +1,256 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+const processListTableName = "processlist"
+
+var showProcessListRegex = regexp.MustCompile(`(?i)^\s*show\s+(full\s+)?processlist\s*;?\s*$`)
+
+// showProcessListQuery is run in place of SHOW PROCESSLIST, which go-mysql-server only answers with running queries.
+// PROCESSLIST is a keyword to the parser, so the table name must be quoted.
+const showProcessListQuery = "SELECT * FROM information_schema.`" + processListTableName + "`"
+
+// connectionState is the state of a connection to the server, as shown by SHOW PROCESSLIST.
+type connectionState struct {
+	id         uint32
+	user       string
+	host       string
+	db         string
+	query      string
+	running    bool
+	stateSince time.Time
+	branch     string
+	workingSet string
+	dirty      bool
+}
+
+// sessionTracker keeps track of every connection to the server, the query each is running, and the branch and working
+// set of the database each is using. Session state is recorded by the connection's own goroutine after each query,
+// since sessions are not safe for concurrent use.
+type sessionTracker struct {
+	mu    *sync.Mutex
+	conns map[uint32]*connectionState
+}
+
+func newSessionTracker() *sessionTracker {
+	return &sessionTracker{mu: &sync.Mutex{}, conns: make(map[uint32]*connectionState)}
+}
+
+func (st *sessionTracker) connectionOpened(id uint32, host string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.conns[id] = &connectionState{id: id, host: host, stateSince: time.Now()}
+}
+
+func (st *sessionTracker) connectionClosed(id uint32) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	delete(st.conns, id)
+}
+
+func (st *sessionTracker) isOpen(id uint32) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	_, ok := st.conns[id]
+	return ok
+}
+
+func (st *sessionTracker) queryStarted(id uint32, user, query string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if conn, ok := st.conns[id]; ok {
+		conn.user = user
+		conn.query = query
+		conn.running = true
+		conn.stateSince = time.Now()
+	}
+}
+
+// queryFinished records that the connection given is idle, along with the state of the session's current database.
+func (st *sessionTracker) queryFinished(id uint32, user string, sqlCtx *sql.Context) {
+	state := connectionState{db: sqlCtx.GetCurrentDatabase()}
+	if state.db != "" {
+		dSess := dsqle.DSessFromSess(sqlCtx.Session)
+		if head, ok := dSess.GetHeadRef(state.db); ok {
+			state.branch = head.GetPath()
+		}
+
+		if root, ok := dSess.GetRoot(state.db); ok {
+			if h, err := root.HashOf(); err == nil {
+				state.workingSet = h.String()
+			}
+		}
+
+		dirty, err := dSess.HasUncommittedChanges(sqlCtx, state.db)
+		if err != nil {
+			logrus.Debugf("connection %d: could not determine the status of database %s: %v", id, state.db, err)
+		}
+		state.dirty = dirty
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if conn, ok := st.conns[id]; ok {
+		conn.user = user
+		conn.db = state.db
+		conn.branch = state.branch
+		conn.workingSet = state.workingSet
+		conn.dirty = state.dirty
+		conn.running = false
+		conn.stateSince = time.Now()
+	}
+}
+
+func (st *sessionTracker) connections() []connectionState {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	conns := make([]connectionState, 0, len(st.conns))
+	for _, conn := range st.conns {
+		conns = append(conns, *conn)
+	}
+
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].id < conns[j].id
+	})
+
+	return conns
+}
+
+// informationSchemaDatabase adds the processlist table to go-mysql-server's information_schema database.
+type informationSchemaDatabase struct {
+	sql.Database
+	processList *processListTable
+}
+
+var _ sql.Database = informationSchemaDatabase{}
+
+func newInformationSchemaDatabase(db sql.Database, tracker *sessionTracker) informationSchemaDatabase {
+	return informationSchemaDatabase{db, &processListTable{tracker}}
+}
+
+// GetTableInsensitive implements sql.Database
+func (db informationSchemaDatabase) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Table, bool, error) {
+	if strings.ToLower(tblName) == processListTableName {
+		return db.processList, true, nil
+	}
+
+	return db.Database.GetTableInsensitive(ctx, tblName)
+}
+
+// GetTableNames implements sql.Database
+func (db informationSchemaDatabase) GetTableNames(ctx *sql.Context) ([]string, error) {
+	names, err := db.Database.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(names, processListTableName), nil
+}
+
+// processListTable is the information_schema.processlist table. In addition to MySQL's columns it shows the branch
+// and working set of each connection's current database, and whether the working set has uncommitted changes.
+type processListTable struct {
+	tracker *sessionTracker
+}
+
+var _ sql.Table = (*processListTable)(nil)
+
+// Name implements sql.Table
+func (pt *processListTable) Name() string {
+	return processListTableName
+}
+
+// String implements sql.Table
+func (pt *processListTable) String() string {
+	return processListTableName
+}
+
+// Schema implements sql.Table
+func (pt *processListTable) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "Id", Type: sql.Int64, Source: processListTableName, PrimaryKey: true},
+		{Name: "User", Type: sql.LongText, Source: processListTableName},
+		{Name: "Host", Type: sql.LongText, Source: processListTableName},
+		{Name: "db", Type: sql.LongText, Source: processListTableName, Nullable: true},
+		{Name: "Command", Type: sql.LongText, Source: processListTableName},
+		{Name: "Time", Type: sql.Int64, Source: processListTableName},
+		{Name: "State", Type: sql.LongText, Source: processListTableName, Nullable: true},
+		{Name: "Info", Type: sql.LongText, Source: processListTableName, Nullable: true},
+		{Name: "branch", Type: sql.LongText, Source: processListTableName, Nullable: true},
+		{Name: "working_set", Type: sql.LongText, Source: processListTableName, Nullable: true},
+		{Name: "dirty", Type: sql.Boolean, Source: processListTableName},
+	}
+}
+
+// Partitions implements sql.Table
+func (pt *processListTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows implements sql.Table
+func (pt *processListTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	conns := pt.tracker.connections()
+	rows := make([]sql.Row, len(conns))
+	for i, conn := range conns {
+		command, state, info := "Sleep", interface{}(nil), interface{}(nil)
+		if conn.running {
+			command, state, info = "Query", "executing", conn.query
+		}
+
+		rows[i] = sql.NewRow(
+			int64(conn.id),
+			conn.user,
+			conn.host,
+			nullIfEmpty(conn.db),
+			command,
+			int64(time.Since(conn.stateSince)/time.Second),
+			state,
+			info,
+			nullIfEmpty(conn.branch),
+			nullIfEmpty(conn.workingSet),
+			conn.dirty,
+		)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
@@ -0,0 +1,274 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+// pushReplicator is a commit hook which pushes the branches of a database created or moved through SQL to one or more
+// remotes. Pushes happen asynchronously, one goroutine per remote, and failed pushes are retried with exponential
+// backoff up to maxRetries times, after which the branch is reported as failed until it's moved again. Pushes which
+// can't be fast forwarded are not retried. Only the latest commit of each branch is pushed, which carries its
+// ancestors with it.
+type pushReplicator struct {
+	dEnv       *env.DoltEnv
+	remotes    []*pushRemote
+	branches   map[string]bool
+	maxBackoff time.Duration
+	maxRetries uint64
+}
+
+var _ dsqle.CommitHook = (*pushReplicator)(nil)
+var _ dsqle.ReplicationStatusReporter = (*pushReplicator)(nil)
+
+// pushRemote tracks the commits waiting to be pushed to a single remote, and the status of pushes to it.
+type pushRemote struct {
+	remote   env.Remote
+	remoteDB *doltdb.DoltDB
+	notify   chan struct{}
+
+	mu       *sync.Mutex
+	pending  map[string]*doltdb.Commit
+	attempts map[string]uint64
+	status   map[string]*dtables.ReplicationStatus
+}
+
+// newPushReplicator creates a pushReplicator for the environment given which pushes the branches given to the remotes
+// named. Every branch is pushed if no branches are given.
+func newPushReplicator(dEnv *env.DoltEnv, remoteNames, branches []string, maxBackoff time.Duration, maxRetries uint64) (*pushReplicator, error) {
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &pushReplicator{
+		dEnv:       dEnv,
+		branches:   make(map[string]bool),
+		maxBackoff: maxBackoff,
+		maxRetries: maxRetries,
+	}
+
+	for _, branch := range branches {
+		pr.branches[branch] = true
+	}
+
+	for _, name := range remoteNames {
+		remote, ok := remotes[name]
+		if !ok {
+			return nil, fmt.Errorf("no remote named '%s'", name)
+		}
+
+		status := make(map[string]*dtables.ReplicationStatus)
+		for _, branch := range branches {
+			status[branch] = &dtables.ReplicationStatus{Remote: name, Branch: branch, State: dtables.ReplicationUpToDate}
+		}
+
+		pr.remotes = append(pr.remotes, &pushRemote{
+			remote:   remote,
+			notify:   make(chan struct{}, 1),
+			mu:       &sync.Mutex{},
+			pending:  make(map[string]*doltdb.Commit),
+			attempts: make(map[string]uint64),
+			status:   status,
+		})
+	}
+
+	return pr, nil
+}
+
+// Execute implements sqle.CommitHook. The commit the branch given now points to is queued to be pushed to every
+// remote if the branch is replicated.
+func (pr *pushReplicator) Execute(_ context.Context, _ string, branch ref.BranchRef, cm *doltdb.Commit) error {
+	if len(pr.branches) > 0 && !pr.branches[branch.GetPath()] {
+		return nil
+	}
+
+	for _, r := range pr.remotes {
+		r.enqueue(branch.GetPath(), cm)
+	}
+
+	return nil
+}
+
+// ReplicationStatus implements sqle.ReplicationStatusReporter.
+func (pr *pushReplicator) ReplicationStatus(_ string) []dtables.ReplicationStatus {
+	var statuses []dtables.ReplicationStatus
+	for _, r := range pr.remotes {
+		statuses = append(statuses, r.statuses()...)
+	}
+
+	return statuses
+}
+
+// run pushes queued commits until the context given is cancelled.
+func (pr *pushReplicator) run(ctx context.Context) {
+	wg := &sync.WaitGroup{}
+	for _, r := range pr.remotes {
+		wg.Add(1)
+		go func(r *pushRemote) {
+			defer wg.Done()
+			r.run(ctx, pr.dEnv, pr.maxBackoff, pr.maxRetries)
+		}(r)
+	}
+
+	wg.Wait()
+}
+
+func (r *pushRemote) enqueue(branch string, cm *doltdb.Commit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending[branch] = cm
+	r.attempts[branch] = 0
+	status, ok := r.status[branch]
+	if !ok {
+		status = &dtables.ReplicationStatus{Remote: r.remote.Name, Branch: branch}
+		r.status[branch] = status
+	}
+	status.Lag++
+	status.State = dtables.ReplicationPending
+
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (r *pushRemote) statuses() []dtables.ReplicationStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]dtables.ReplicationStatus, 0, len(r.status))
+	for _, status := range r.status {
+		statuses = append(statuses, *status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Branch < statuses[j].Branch
+	})
+
+	return statuses
+}
+
+func (r *pushRemote) run(ctx context.Context, dEnv *env.DoltEnv, maxBackoff time.Duration, maxRetries uint64) {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxInterval = maxBackoff
+	bo.MaxElapsedTime = 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.notify:
+		}
+
+		bo.Reset()
+		for !r.pushPending(ctx, dEnv, maxRetries) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(bo.NextBackOff()):
+			}
+		}
+	}
+}
+
+// pushPending pushes the latest commit queued for each branch. A branch whose push fails is given up on once it has
+// been retried maxRetries times, or if the remote branch can't be fast forwarded to the commit. Returns false if any
+// push failed and should be retried.
+func (r *pushRemote) pushPending(ctx context.Context, dEnv *env.DoltEnv, maxRetries uint64) bool {
+	r.mu.Lock()
+	pending := make(map[string]*doltdb.Commit, len(r.pending))
+	lags := make(map[string]uint64, len(r.pending))
+	for branch, cm := range r.pending {
+		pending[branch] = cm
+		lags[branch] = r.status[branch].Lag
+	}
+	r.mu.Unlock()
+
+	ok := true
+	for branch, cm := range pending {
+		h, err := cm.HashOf()
+		if err == nil {
+			err = r.push(ctx, dEnv, branch, cm)
+		}
+
+		r.mu.Lock()
+		status := r.status[branch]
+		latest := r.pending[branch] == cm
+		if err != nil {
+			status.LastError = err.Error()
+			if latest {
+				r.attempts[branch]++
+			}
+
+			// a branch which has moved since is retried with its new commit
+			if latest && (r.attempts[branch] > maxRetries || errors.Is(err, actions.ErrCantFF)) {
+				status.State = dtables.ReplicationFailed
+				delete(r.pending, branch)
+				logrus.Errorf("failed to push %s to remote %s, giving up: %v", branch, r.remote.Name, err)
+			} else {
+				ok = false
+				logrus.Warnf("failed to push %s to remote %s: %v", branch, r.remote.Name, err)
+			}
+		} else {
+			status.LastPushedCommit = h.String()
+			status.LastPushedAt = time.Now()
+			status.LastError = ""
+			status.Lag -= lags[branch]
+
+			if latest {
+				status.State = dtables.ReplicationUpToDate
+				delete(r.pending, branch)
+			}
+		}
+		r.mu.Unlock()
+	}
+
+	return ok
+}
+
+func (r *pushRemote) push(ctx context.Context, dEnv *env.DoltEnv, branch string, cm *doltdb.Commit) error {
+	if r.remoteDB == nil {
+		remoteDB, err := r.remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+		if err != nil {
+			return err
+		}
+
+		r.remoteDB = remoteDB
+	} else if err := r.remoteDB.Rebase(ctx); err != nil {
+		return err
+	}
+
+	destRef := ref.NewBranchRef(branch)
+	remoteRef := ref.NewRemoteRef(r.remote.Name, branch)
+	return actions.PushToRemoteBranch(ctx, dEnv, ref.FastForwardOnly, destRef, remoteRef, r.remoteDB, cm)
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+// commitToBranch commits the working root of the environment given with the message given, and moves the branch
+// given to the new commit, as is done by writing the hash returned by COMMIT() to dolt_branches.
+func commitToBranch(t *testing.T, ctx context.Context, dEnv *env.DoltEnv, branch ref.BranchRef, msg string) *doltdb.Commit {
+	parent, err := dEnv.DoltDB.ResolveRef(ctx, branch)
+	require.NoError(t, err)
+	working, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	workingHash, err := dEnv.DoltDB.WriteRootValue(ctx, working)
+	require.NoError(t, err)
+	meta, err := doltdb.NewCommitMeta("Bill Billerson", "bill@example.com", msg)
+	require.NoError(t, err)
+	cm, err := dEnv.DoltDB.WriteDanglingCommit(ctx, workingHash, []*doltdb.Commit{parent}, meta)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, branch, cm))
+	return cm
+}
+
+func TestPushReplicator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dEnv := createEnvWithSeedData(t)
+	remote := env.NewRemote("origin", "file://"+t.TempDir(), nil)
+	dEnv.RepoState.AddRemote(remote)
+
+	pr, err := newPushReplicator(dEnv, []string{"origin"}, []string{"master"}, time.Second, 3)
+	require.NoError(t, err)
+
+	statuses := pr.ReplicationStatus("dolt")
+	require.Len(t, statuses, 1)
+	assert.Equal(t, dtables.ReplicationStatus{Remote: "origin", Branch: "master", State: dtables.ReplicationUpToDate}, statuses[0])
+
+	master := ref.NewBranchRef("master")
+	first := commitToBranch(t, ctx, dEnv, master, "first")
+	cm := commitToBranch(t, ctx, dEnv, master, "second")
+	cmHash, err := cm.HashOf()
+	require.NoError(t, err)
+
+	require.NoError(t, pr.Execute(ctx, "dolt", master, first))
+	require.NoError(t, pr.Execute(ctx, "dolt", master, cm))
+	require.NoError(t, pr.Execute(ctx, "dolt", ref.NewBranchRef("other"), cm))
+	statuses = pr.ReplicationStatus("dolt")
+	require.Len(t, statuses, 1)
+	assert.Equal(t, uint64(2), statuses[0].Lag)
+	assert.Equal(t, dtables.ReplicationPending, statuses[0].State)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pr.run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	require.Eventually(t, func() bool {
+		statuses := pr.ReplicationStatus("dolt")
+		return len(statuses) == 1 && statuses[0].State == dtables.ReplicationUpToDate
+	}, 10*time.Second, 10*time.Millisecond)
+
+	statuses = pr.ReplicationStatus("dolt")
+	assert.Equal(t, cmHash.String(), statuses[0].LastPushedCommit)
+	assert.Equal(t, uint64(0), statuses[0].Lag)
+	assert.Equal(t, "", statuses[0].LastError)
+
+	remoteDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+	require.NoError(t, err)
+	remoteCm, err := remoteDB.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	remoteHash, err := remoteCm.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, cmHash, remoteHash)
+
+	_, err = newPushReplicator(dEnv, []string{"missing"}, nil, time.Second, 3)
+	assert.Error(t, err)
+}
+
+func TestPushReplicatorGivesUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dEnv := createEnvWithSeedData(t)
+	dEnv.RepoState.AddRemote(env.NewRemote("unreachable", "invalid://nowhere", nil))
+	diverged := env.NewRemote("diverged", "file://"+t.TempDir(), nil)
+	dEnv.RepoState.AddRemote(diverged)
+
+	// the diverged remote's master has a commit which the local master doesn't
+	master := ref.NewBranchRef("master")
+	head, err := dEnv.DoltDB.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("other"), head))
+	other := commitToBranch(t, ctx, dEnv, ref.NewBranchRef("other"), "other")
+	divergedDB, err := diverged.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+	require.NoError(t, err)
+	require.NoError(t, actions.PushToRemoteBranch(ctx, dEnv, ref.ForceUpdate, master, ref.NewRemoteRef("diverged", "master"), divergedDB, other))
+
+	pr, err := newPushReplicator(dEnv, []string{"unreachable", "diverged"}, nil, time.Millisecond, 2)
+	require.NoError(t, err)
+
+	cm := commitToBranch(t, ctx, dEnv, master, "local")
+	require.NoError(t, pr.Execute(ctx, "dolt", master, cm))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pr.run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	require.Eventually(t, func() bool {
+		for _, status := range pr.ReplicationStatus("dolt") {
+			if status.State != dtables.ReplicationFailed {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	statuses := pr.ReplicationStatus("dolt")
+	require.Len(t, statuses, 2)
+	for _, status := range statuses {
+		assert.Equal(t, "master", status.Branch)
+		assert.Equal(t, uint64(1), status.Lag)
+		assert.Equal(t, "", status.LastPushedCommit)
+		assert.NotEqual(t, "", status.LastError)
+	}
+
+	// the unreachable remote is retried, but a push which can't be fast forwarded is given up on at once
+	for _, r := range pr.remotes {
+		r.mu.Lock()
+		assert.Empty(t, r.pending)
+		if r.remote.Name == "unreachable" {
+			assert.Equal(t, uint64(3), r.attempts["master"])
+		} else {
+			assert.Equal(t, uint64(1), r.attempts["master"])
+			assert.Equal(t, actions.ErrCantFF.Error(), r.status["master"].LastError)
+		}
+		r.mu.Unlock()
+	}
+
+	// moving the branch again queues it to be retried
+	cm = commitToBranch(t, ctx, dEnv, master, "again")
+	require.NoError(t, pr.Execute(ctx, "dolt", master, cm))
+	for _, status := range pr.ReplicationStatus("dolt") {
+		assert.Equal(t, uint64(2), status.Lag)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxExecutionTimeSessionVar is the session variable holding the number of milliseconds a query may run for. As in
+	// MySQL, 0 means no limit.
+	maxExecutionTimeSessionVar = "max_execution_time"
+
+	// erQueryTimeout is the MySQL error code ER_QUERY_TIMEOUT, which isn't defined by vitess
+	erQueryTimeout = 3024
+	// ssQueryInterrupted is the SQLSTATE of ER_QUERY_INTERRUPTED
+	ssQueryInterrupted = "70100"
+	// ssMemoryAllocationError is the SQLSTATE of ER_OUT_OF_SORTMEMORY
+	ssMemoryAllocationError = "HY001"
+)
+
+var errTooManyRows = errors.New("too many rows returned")
+
+// queryLimits are the resource limits enforced on every query run by the server.
+type queryLimits struct {
+	maxReturnedRows uint64
+}
+
+// memoryLimitReporter is a sql.Reporter which reports the memory used by the process, and a fixed memory limit.
+type memoryLimitReporter struct {
+	maxMemory uint64
+}
+
+var _ sql.Reporter = memoryLimitReporter{}
+
+// newMemoryLimitReporter returns a sql.Reporter for a limit of the number of megabytes given.
+func newMemoryLimitReporter(maxMemoryMB uint64) memoryLimitReporter {
+	return memoryLimitReporter{maxMemoryMB * 1024 * 1024}
+}
+
+// MaxMemory implements sql.Reporter
+func (r memoryLimitReporter) MaxMemory() uint64 {
+	return r.maxMemory
+}
+
+// UsedMemory implements sql.Reporter
+func (r memoryLimitReporter) UsedMemory() uint64 {
+	return sql.ProcessMemory.UsedMemory()
+}
+
+// runWithLimits runs a query on the connection given, cancelling it if it runs for longer than the session's
+// max_execution_time or returns more rows than the server allows. Errors caused by exceeding a limit are logged and
+// returned to the client as MySQL errors.
+func (h *doltHandler) runWithLimits(c *mysql.Conn, query string, callback func(*sqltypes.Result) error, run func(func(*sqltypes.Result) error) error) error {
+	timeout, err := h.maxExecutionTime(c)
+	if err != nil {
+		return err
+	}
+
+	var timedOut int32
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			atomic.StoreInt32(&timedOut, 1)
+			h.sqlEngine.Catalog.KillOnlyQueries(c.ConnectionID)
+		})
+		defer timer.Stop()
+	}
+
+	limitedCallback := callback
+	if h.limits.maxReturnedRows > 0 {
+		var rowCount uint64
+		limitedCallback = func(r *sqltypes.Result) error {
+			rowCount += uint64(len(r.Rows))
+			if rowCount > h.limits.maxReturnedRows {
+				h.sqlEngine.Catalog.KillOnlyQueries(c.ConnectionID)
+				return errTooManyRows
+			}
+
+			return callback(r)
+		}
+	}
+
+	err = run(limitedCallback)
+
+	switch {
+	case err == nil:
+		return nil
+	case atomic.LoadInt32(&timedOut) == 1:
+		logrus.Warnf("connection %d: query exceeded max execution time of %v and was cancelled: %s", c.ConnectionID, timeout, query)
+		return mysql.NewSQLError(erQueryTimeout, mysql.SSUnknownSQLState, "Query execution was interrupted, maximum statement execution time exceeded")
+	case err == errTooManyRows:
+		logrus.Warnf("connection %d: query returned more than %d rows and was cancelled: %s", c.ConnectionID, h.limits.maxReturnedRows, query)
+		return mysql.NewSQLError(mysql.ERQueryInterrupted, ssQueryInterrupted, "Query execution was interrupted, number of rows returned exceeded the maximum of %d", h.limits.maxReturnedRows)
+	case errors.Is(err, context.Canceled):
+		return mysql.NewSQLError(mysql.ERQueryInterrupted, ssQueryInterrupted, "Query execution was interrupted")
+	case sql.ErrNoMemoryAvailable.Is(err):
+		logrus.Warnf("connection %d: query ran out of memory: %s", c.ConnectionID, query)
+		return mysql.NewSQLError(mysql.EROutOfSortMemory, ssMemoryAllocationError, "Out of memory; the server's memory limit was reached")
+	}
+
+	return err
+}
+
+// maxExecutionTime returns the value of the max_execution_time session variable of the connection given.
+func (h *doltHandler) maxExecutionTime(c *mysql.Conn) (time.Duration, error) {
+	sqlCtx, err := h.sm.NewContext(c)
+	if err != nil {
+		return 0, err
+	}
+
+	_, val := sqlCtx.Session.Get(maxExecutionTimeSessionVar)
+	if val == nil {
+		return 0, nil
+	}
+
+	millis, err := sql.Int64.Convert(val)
+	if err != nil {
+		return 0, err
+	}
+
+	if millis.(int64) <= 0 {
+		return 0, nil
+	}
+
+	return time.Duration(millis.(int64)) * time.Millisecond, nil
+}
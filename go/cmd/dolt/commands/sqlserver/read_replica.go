@@ -0,0 +1,181 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// readReplica serves a read-only database which follows a branch of a remote. It periodically fetches the branch,
+// fast-forwards the local branch to it, and points the working set served to new transactions at the new head.
+type readReplica struct {
+	dEnv         *env.DoltEnv
+	db           dsqle.Database
+	rs           *env.MemoryRepoState
+	remote       env.Remote
+	remoteDB     *doltdb.DoltDB
+	branch       ref.BranchRef
+	pollInterval time.Duration
+}
+
+// newReadReplicas creates a read replica for each database named in the config given, following the remote and
+// branch configured for that database. The replicas are keyed by database name.
+func newReadReplicas(ctx context.Context, mrEnv env.MultiRepoEnv, replicaCfgs map[string]ReadReplicaConfig) (map[string]*readReplica, error) {
+	replicas := make(map[string]*readReplica, len(replicaCfgs))
+	for name, replicaCfg := range replicaCfgs {
+		dEnv, ok := mrEnv[name]
+		if !ok {
+			return nil, fmt.Errorf("read replica %s is not a database served by the server", name)
+		}
+
+		pollInterval := time.Duration(replicaCfg.PollIntervalMs) * time.Millisecond
+		replica, err := newReadReplica(ctx, name, dEnv, replicaCfg.Remote, replicaCfg.Branch, pollInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		replicas[name] = replica
+	}
+
+	return replicas, nil
+}
+
+// newReadReplica creates a read replica named |name| for the environment given. The replica is brought up to date
+// with the remote before it is returned if the remote can be reached, otherwise it starts from the local branch.
+func newReadReplica(ctx context.Context, name string, dEnv *env.DoltEnv, remoteName, branch string, pollInterval time.Duration) (*readReplica, error) {
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	remote, ok := remotes[remoteName]
+	if !ok {
+		return nil, fmt.Errorf("database '%s' has no remote named '%s'", name, remoteName)
+	}
+
+	rr := &readReplica{
+		dEnv:         dEnv,
+		remote:       remote,
+		branch:       ref.NewBranchRef(branch),
+		pollInterval: pollInterval,
+	}
+
+	if _, err = rr.fetch(ctx); err != nil {
+		logrus.Warnf("read replica %s failed to fetch %s from %s: %v", name, branch, remoteName, err)
+	}
+
+	cm, err := dEnv.DoltDB.ResolveRef(ctx, rr.branch)
+	if err != nil {
+		return nil, fmt.Errorf("read replica %s could not resolve branch %s: %w", name, branch, err)
+	}
+
+	rootHash, err := rootHashOfCommit(cm)
+	if err != nil {
+		return nil, err
+	}
+
+	headSpec, err := doltdb.NewCommitSpec("HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	rr.rs = env.NewMemoryRepoState(rr.branch, headSpec, rootHash)
+	rr.db = dsqle.NewReadOnlyDatabase(name, dEnv.DoltDB, rr.rs, rr.rs)
+
+	return rr, nil
+}
+
+// run polls the remote until the context given is cancelled.
+func (rr *readReplica) run(ctx context.Context) {
+	ticker := time.NewTicker(rr.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := rr.sync(ctx); err != nil {
+				logrus.Errorf("read replica %s failed to sync %s from %s: %v", rr.db.Name(), rr.branch.GetPath(), rr.remote.Name, err)
+			}
+		}
+	}
+}
+
+// sync fetches the branch from the remote and updates the working set served by the replica to its head.
+func (rr *readReplica) sync(ctx context.Context) error {
+	cm, err := rr.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootHash, err := rootHashOfCommit(cm)
+	if err != nil {
+		return err
+	}
+
+	if rootHash != rr.rs.WorkingHash() {
+		logrus.Debugf("read replica %s updated to root %s", rr.db.Name(), rootHash.String())
+	}
+
+	return rr.rs.SetWorkingHash(ctx, rootHash)
+}
+
+// fetch fetches the branch from the remote and fast-forwards the local branch to it.
+func (rr *readReplica) fetch(ctx context.Context) (*doltdb.Commit, error) {
+	if rr.remoteDB == nil {
+		remoteDB, err := rr.remote.GetRemoteDB(ctx, rr.dEnv.DoltDB.ValueReadWriter().Format())
+		if err != nil {
+			return nil, err
+		}
+
+		rr.remoteDB = remoteDB
+	} else if err := rr.remoteDB.Rebase(ctx); err != nil {
+		return nil, err
+	}
+
+	remoteRef := ref.NewRemoteRef(rr.remote.Name, rr.branch.GetPath())
+	cm, err := actions.FetchRemoteBranch(ctx, rr.dEnv, rr.remoteDB, rr.branch, remoteRef)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rr.dEnv.DoltDB.FastForward(ctx, rr.branch, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	return cm, nil
+}
+
+func rootHashOfCommit(cm *doltdb.Commit) (hash.Hash, error) {
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	return root.HashOf()
+}
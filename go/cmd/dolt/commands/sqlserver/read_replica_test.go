@@ -0,0 +1,126 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+func TestReadReplicaSync(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createEnvWithSeedData(t)
+
+	remote := env.NewRemote("origin", "file://"+t.TempDir(), nil)
+	dEnv.RepoState.AddRemote(remote)
+	remoteDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+	require.NoError(t, err)
+
+	master := ref.NewBranchRef("master")
+	initialCm, err := dEnv.DoltDB.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	pushCommit(t, dEnv, remoteDB, initialCm)
+
+	replica, err := newReadReplica(ctx, "replica", dEnv, "origin", "master", time.Second)
+	require.NoError(t, err)
+
+	initialRootHash, err := rootHashOfCommit(initialCm)
+	require.NoError(t, err)
+	assert.Equal(t, initialRootHash, replica.rs.WorkingHash())
+	assert.True(t, replica.db.IsReadOnly())
+
+	// commit the seeded table and push it to the remote as if from another client
+	working, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	workingHash, err := dEnv.DoltDB.WriteRootValue(ctx, working)
+	require.NoError(t, err)
+	meta, err := doltdb.NewCommitMeta("Bill Billerson", "bill@example.com", "add people")
+	require.NoError(t, err)
+	newCm, err := dEnv.DoltDB.WriteDanglingCommit(ctx, workingHash, []*doltdb.Commit{initialCm}, meta)
+	require.NoError(t, err)
+	pushCommit(t, dEnv, remoteDB, newCm)
+
+	require.NoError(t, replica.sync(ctx))
+	assert.Equal(t, workingHash, replica.rs.WorkingHash())
+
+	localCm, err := dEnv.DoltDB.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	localHash, err := localCm.HashOf()
+	require.NoError(t, err)
+	newHash, err := newCm.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, newHash, localHash)
+
+	_, err = newReadReplica(ctx, "replica", dEnv, "missing", "master", time.Second)
+	assert.Error(t, err)
+}
+
+func pushCommit(t *testing.T, dEnv *env.DoltEnv, remoteDB *doltdb.DoltDB, cm *doltdb.Commit) {
+	progChan := make(chan datas.PullProgress)
+	pullerEventCh := make(chan datas.PullerEvent)
+	go func() {
+		for range progChan {
+		}
+	}()
+	go func() {
+		for range pullerEventCh {
+		}
+	}()
+	defer close(progChan)
+	defer close(pullerEventCh)
+
+	master := ref.NewBranchRef("master")
+	remoteRef := ref.NewRemoteRef("origin", "master")
+	err := actions.Push(context.Background(), dEnv, ref.ForceUpdate, master, remoteRef, dEnv.DoltDB, remoteDB, cm, progChan, pullerEventCh)
+	require.NoError(t, err)
+}
+
+func TestNewReadReplicas(t *testing.T) {
+	ctx := context.Background()
+	replicaEnv := createEnvWithSeedData(t)
+	replicaEnv.RepoState.AddRemote(env.NewRemote("origin", "file://"+t.TempDir(), nil))
+	mrEnv := env.MultiRepoEnv{
+		"replica": replicaEnv,
+		"primary": createEnvWithSeedData(t),
+	}
+
+	replicas, err := newReadReplicas(ctx, mrEnv, map[string]ReadReplicaConfig{
+		"replica": {Remote: "origin", Branch: "master", PollIntervalMs: 250},
+	})
+	require.NoError(t, err)
+	require.Len(t, replicas, 1)
+	assert.Equal(t, "replica", replicas["replica"].db.Name())
+	assert.Equal(t, 250*time.Millisecond, replicas["replica"].pollInterval)
+
+	_, err = newReadReplicas(ctx, mrEnv, map[string]ReadReplicaConfig{
+		"missing": {Remote: "origin", Branch: "master", PollIntervalMs: 250},
+	})
+	assert.Error(t, err)
+
+	_, err = newReadReplicas(ctx, mrEnv, map[string]ReadReplicaConfig{
+		"primary": {Remote: "origin", Branch: "master", PollIntervalMs: 250},
+	})
+	assert.Error(t, err)
+}
@@ -16,6 +16,7 @@ package sqlserver
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"strconv"
 	"time"
@@ -24,7 +25,6 @@ import (
 	"github.com/dolthub/go-mysql-server/auth"
 	"github.com/dolthub/go-mysql-server/server"
 	"github.com/dolthub/go-mysql-server/sql"
-	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/information_schema"
 	"github.com/dolthub/vitess/go/mysql"
 	"github.com/sirupsen/logrus"
@@ -49,11 +49,15 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		serverController = CreateServerController()
 	}
 
-	var mySQLServer *server.Server
+	var listener *mysql.Listener
+	closeListener := func() error {
+		listener.Close()
+		return nil
+	}
 	// This guarantees unblocking on any routines with a waiting `ServerController`
 	defer func() {
-		if mySQLServer != nil {
-			serverController.registerCloseFunction(startError, mySQLServer.Close)
+		if listener != nil {
+			serverController.registerCloseFunction(startError, closeListener)
 		} else {
 			serverController.registerCloseFunction(startError, func() error { return nil })
 		}
@@ -83,7 +87,11 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 	userAuth := auth.NewAudit(auth.NewNativeSingle(serverConfig.User(), serverConfig.Password(), permissions), auth.NewAuditLog(logrus.StandardLogger()))
 
 	c := sql.NewCatalog()
-	a := analyzer.NewBuilder(c).WithParallelism(serverConfig.QueryParallelism()).Build()
+	if serverConfig.MaxMemory() > 0 {
+		c.MemoryManager = sql.NewMemoryManager(newMemoryLimitReporter(serverConfig.MaxMemory()))
+	}
+
+	a := dsqle.NewAnalyzerBuilder(c).WithParallelism(serverConfig.QueryParallelism()).Build()
 	sqlEngine := sqle.New(c, a, nil)
 
 	err := sqlEngine.Catalog.Register(dfunctions.DoltFunctions...)
@@ -115,47 +123,90 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		}
 	}
 
+	var replicas map[string]*readReplica
+	replicas, startError = newReadReplicas(ctx, mrEnv, serverConfig.ReadReplicas())
+	if startError != nil {
+		cli.PrintErr(startError)
+		return
+	}
+
 	dbs := commands.CollectDBs(mrEnv, newDatabase)
+	for i, db := range dbs {
+		if replica, ok := replicas[db.Name()]; ok {
+			dbs[i] = replica.db
+		}
+	}
+
+	var pushReplicators []*pushReplicator
+	if len(serverConfig.PushRemotes()) > 0 {
+		maxBackoff := time.Duration(serverConfig.PushMaxBackoff()) * time.Millisecond
+		for i, db := range dbs {
+			if _, ok := replicas[db.Name()]; ok {
+				continue
+			}
+
+			var pr *pushReplicator
+			pr, startError = newPushReplicator(mrEnv[db.Name()], serverConfig.PushRemotes(), serverConfig.PushBranches(), maxBackoff, serverConfig.PushMaxRetries())
+			if startError != nil {
+				startError = fmt.Errorf("database '%s': %w", db.Name(), startError)
+				cli.PrintErr(startError)
+				return
+			}
+
+			pushReplicators = append(pushReplicators, pr)
+			dbs[i] = db.WithCommitHooks(pr)
+		}
+	}
 
 	for _, db := range dbs {
 		sqlEngine.AddDatabase(db)
 	}
 
-	sqlEngine.AddDatabase(information_schema.NewInformationSchemaDatabase(sqlEngine.Catalog))
+	tracker := newSessionTracker()
+	sqlEngine.AddDatabase(newInformationSchemaDatabase(information_schema.NewInformationSchemaDatabase(sqlEngine.Catalog), tracker))
 
 	hostPort := net.JoinHostPort(serverConfig.Host(), strconv.Itoa(serverConfig.Port()))
 	readTimeout := time.Duration(serverConfig.ReadTimeout()) * time.Millisecond
 	writeTimeout := time.Duration(serverConfig.WriteTimeout()) * time.Millisecond
-	mySQLServer, startError = server.NewServer(
-		server.Config{
-			Protocol:         "tcp",
-			Address:          hostPort,
-			Auth:             userAuth,
-			ConnReadTimeout:  readTimeout,
-			ConnWriteTimeout: writeTimeout,
-			MaxConnections:   serverConfig.MaxConnections(),
-			// Do not set the value of Version.  Let it default to what go-mysql-server uses.  This should be equivalent
-			// to the value of mysql that we support.
-		},
+	serverCfg := server.Config{
+		Protocol:         "tcp",
+		Address:          hostPort,
+		Auth:             userAuth,
+		ConnReadTimeout:  readTimeout,
+		ConnWriteTimeout: writeTimeout,
+		MaxConnections:   serverConfig.MaxConnections(),
+		// Do not set the value of Version.  Let it default to what go-mysql-server uses.  This should be equivalent
+		// to the value of mysql that we support.
+	}
+	handler := newDoltHandler(
+		serverCfg,
 		sqlEngine,
-		newSessionBuilder(sqlEngine, username, email, serverConfig.AutoCommit()),
+		newSessionBuilder(sqlEngine, username, email, serverConfig.AutoCommit(), serverConfig.MaxExecutionTime()),
+		queryLimits{maxReturnedRows: serverConfig.MaxReturnedRows()},
+		tracker,
 	)
+	listener, startError = newListener(serverCfg, handler)
 
 	if startError != nil {
 		cli.PrintErr(startError)
 		return
 	}
 
-	serverController.registerCloseFunction(startError, mySQLServer.Close)
-	closeError = mySQLServer.Start()
-	if closeError != nil {
-		cli.PrintErr(closeError)
-		return
+	replicaCtx, stopReplicas := context.WithCancel(ctx)
+	defer stopReplicas()
+	for _, replica := range replicas {
+		go replica.run(replicaCtx)
 	}
+	for _, pr := range pushReplicators {
+		go pr.run(replicaCtx)
+	}
+
+	serverController.registerCloseFunction(startError, closeListener)
+	listener.Accept()
 	return
 }
 
-func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommit bool) server.SessionBuilder {
+func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommit bool, maxExecutionTime uint64) server.SessionBuilder {
 	return func(ctx context.Context, conn *mysql.Conn, host string) (sql.Session, *sql.IndexRegistry, *sql.ViewRegistry, error) {
 		mysqlSess := sql.NewSession(host, conn.RemoteAddr().String(), conn.User, conn.ConnectionID)
 		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, username, email, dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())...)
@@ -170,6 +221,12 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 			return nil, nil, nil, err
 		}
 
+		err = doltSess.Set(ctx, maxExecutionTimeSessionVar, sql.Int64, int64(maxExecutionTime))
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
 		ir := sql.NewIndexRegistry()
 		vr := sql.NewViewRegistry()
 		sqlCtx := sql.NewContext(
@@ -180,18 +237,7 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 
 		dbs := dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())
 		for _, db := range dbs {
-			err := db.LoadRootFromRepoState(sqlCtx)
-			if err != nil {
-				return nil, nil, nil, err
-			}
-
-			root, err := db.GetRoot(sqlCtx)
-			if err != err {
-				cli.PrintErrln(err)
-				return nil, nil, nil, err
-			}
-
-			err = dsqle.RegisterSchemaFragments(sqlCtx, db, root)
+			err := loadSessionDatabase(sqlCtx, db)
 			if err != nil {
 				cli.PrintErr(err)
 				return nil, nil, nil, err
@@ -203,7 +249,7 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 }
 
 func newDatabase(name string, dEnv *env.DoltEnv) dsqle.Database {
-	return dsqle.NewDatabase(name, dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter())
+	return dsqle.NewDatabase(name, dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter()).WithRemotes(dEnv)
 }
 
 func dbsAsDSQLDBs(dbs []sql.Database) []dsqle.Database {
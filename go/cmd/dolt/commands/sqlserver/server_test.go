@@ -15,19 +15,27 @@
 package sqlserver
 
 import (
+	gosql "database/sql"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/gocraft/dbr/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 type testPerson struct {
@@ -220,6 +228,615 @@ func TestServerSelect(t *testing.T) {
 	}
 }
 
+func TestServerRevisionDatabases(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	err := actions.CreateBranch(context.Background(), env, "feature", "master", false)
+	require.NoError(t, err)
+
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15301).withMaxConnections(10)
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err = sc.WaitForStart()
+	require.NoError(t, err)
+
+	openDB := func(dbName string) *gosql.DB {
+		cfg := mysql.NewConfig()
+		cfg.User = serverConfig.User()
+		cfg.Passwd = serverConfig.Password()
+		cfg.Net = "tcp"
+		cfg.Addr = "localhost:15301"
+		cfg.DBName = dbName
+		connector, err := mysql.NewConnector(cfg)
+		require.NoError(t, err)
+		return gosql.OpenDB(connector)
+	}
+
+	tableNames := func(db *gosql.DB) []string {
+		rows, err := db.Query("SHOW TABLES")
+		require.NoError(t, err)
+		defer rows.Close()
+		var names []string
+		for rows.Next() {
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			names = append(names, name)
+		}
+		require.NoError(t, rows.Err())
+		return names
+	}
+
+	feature := openDB("dolt/feature")
+	defer feature.Close()
+	assert.Empty(t, tableNames(feature))
+	_, err = feature.Exec("CREATE TABLE test (pk int PRIMARY KEY)")
+	require.NoError(t, err)
+	_, err = feature.Exec("INSERT INTO test VALUES (1), (2)")
+	require.NoError(t, err)
+
+	otherFeature := openDB("dolt/feature")
+	defer otherFeature.Close()
+	assert.Equal(t, []string{"test"}, tableNames(otherFeature))
+
+	master := openDB("dolt")
+	defer master.Close()
+	assert.Equal(t, []string{"people"}, tableNames(master))
+
+	atCommit := openDB("dolt@feature")
+	defer atCommit.Close()
+	assert.Empty(t, tableNames(atCommit))
+	_, err = atCommit.Exec("CREATE TABLE test2 (pk int PRIMARY KEY)")
+	assert.Error(t, err)
+
+	missing := openDB("dolt/missing")
+	defer missing.Close()
+	assert.Error(t, missing.Ping())
+
+	// revisions are selected by the sessions of the connections naming them, and aren't added to the catalog
+	var dbName string
+	require.NoError(t, feature.QueryRow("SELECT DATABASE()").Scan(&dbName))
+	assert.Equal(t, "dolt", dbName)
+	rows, err := master.Query("SHOW DATABASES")
+	require.NoError(t, err)
+	defer rows.Close()
+	var dbNames []string
+	for rows.Next() {
+		require.NoError(t, rows.Scan(&dbName))
+		dbNames = append(dbNames, dbName)
+	}
+	require.NoError(t, rows.Err())
+	assert.ElementsMatch(t, []string{"dolt", "information_schema"}, dbNames)
+}
+
+func TestServerQueryLimits(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15302).withResourceLimits(200, 2, 0)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := gosql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assertMySQLError := func(err error, number uint16) {
+		require.Error(t, err)
+		mysqlErr, ok := err.(*mysql.MySQLError)
+		require.True(t, ok, "unexpected error: %v", err)
+		assert.Equal(t, number, mysqlErr.Number)
+	}
+
+	start := time.Now()
+	_, err = conn.ExecContext(context.Background(), "SELECT SLEEP(10)")
+	assertMySQLError(err, erQueryTimeout)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	var name string
+	err = conn.QueryRowContext(context.Background(), "SELECT name FROM people WHERE age = 32").Scan(&name)
+	require.NoError(t, err)
+	assert.Equal(t, bill.Name, name)
+
+	rows, err := conn.QueryContext(context.Background(), "SELECT name FROM people")
+	if err == nil {
+		for rows.Next() {
+		}
+		err = rows.Err()
+		rows.Close()
+	}
+	assertMySQLError(err, 1317)
+
+	_, err = conn.ExecContext(context.Background(), "SET max_execution_time = 0")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(context.Background(), "SELECT SLEEP(0.3)")
+	require.NoError(t, err)
+}
+
+func TestServerProcessList(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15303).withMaxConnections(10)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := gosql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sleeperDB, err := gosql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer sleeperDB.Close()
+	sleeper, err := sleeperDB.Conn(ctx)
+	require.NoError(t, err)
+
+	var connID, sleeperID int64
+	require.NoError(t, conn.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&connID))
+	require.NoError(t, sleeper.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&sleeperID))
+
+	sleepErr := make(chan error)
+	go func() {
+		_, err := sleeper.ExecContext(ctx, "SELECT SLEEP(10)")
+		sleepErr <- err
+	}()
+
+	type process struct {
+		command    string
+		info       gosql.NullString
+		branch     gosql.NullString
+		workingSet gosql.NullString
+		dirty      bool
+	}
+
+	processes := func(query string) map[int64]process {
+		rows, err := conn.QueryContext(ctx, query)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		procs := make(map[int64]process)
+		for rows.Next() {
+			var id, time int64
+			var user, host, command string
+			var db, state, info, branch, workingSet gosql.NullString
+			var dirty bool
+			require.NoError(t, rows.Scan(&id, &user, &host, &db, &command, &time, &state, &info, &branch, &workingSet, &dirty))
+			procs[id] = process{command, info, branch, workingSet, dirty}
+		}
+		require.NoError(t, rows.Err())
+		return procs
+	}
+
+	require.Eventually(t, func() bool {
+		return processes("SHOW PROCESSLIST")[sleeperID].command == "Query"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	procs := processes("SELECT * FROM information_schema.`processlist`")
+	assert.Equal(t, "SELECT SLEEP(10)", procs[sleeperID].info.String)
+	assert.Equal(t, "Query", procs[connID].command)
+	assert.Equal(t, "master", procs[connID].branch.String)
+	assert.NotEmpty(t, procs[connID].workingSet.String)
+	assert.True(t, procs[connID].dirty)
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("KILL QUERY %d", sleeperID))
+	require.NoError(t, err)
+
+	select {
+	case err := <-sleepErr:
+		mysqlErr, ok := err.(*mysql.MySQLError)
+		require.True(t, ok, "unexpected error: %v", err)
+		assert.Equal(t, uint16(1317), mysqlErr.Number)
+	case <-time.After(5 * time.Second):
+		t.Fatal("query was not killed")
+	}
+
+	require.NoError(t, sleeper.Close())
+	require.NoError(t, sleeperDB.Close())
+
+	require.Eventually(t, func() bool {
+		_, ok := processes("SHOW PROCESSLIST")[sleeperID]
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestServerTransactions(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15304).withMaxConnections(10)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := gosql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	conn1, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn1.Close()
+	conn2, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	exec := func(conn *gosql.Conn, query string) {
+		_, err := conn.ExecContext(ctx, query)
+		require.NoError(t, err, query)
+	}
+
+	age := func(conn *gosql.Conn, name string) int {
+		var age int
+		require.NoError(t, conn.QueryRowContext(ctx, "SELECT age FROM people WHERE name = ?", name).Scan(&age))
+		return age
+	}
+
+	// changes to different rows are merged
+	exec(conn1, "START TRANSACTION")
+	exec(conn1, "UPDATE people SET age = 33 WHERE name = 'Bill Billerson'")
+	assert.Equal(t, 32, age(conn2, "Bill Billerson"))
+	exec(conn2, "UPDATE people SET age = 26 WHERE name = 'John Johnson'")
+	assert.Equal(t, 25, age(conn1, "John Johnson"))
+	exec(conn1, "COMMIT")
+	assert.Equal(t, 33, age(conn2, "Bill Billerson"))
+	assert.Equal(t, 26, age(conn2, "John Johnson"))
+	assert.Equal(t, 26, age(conn1, "John Johnson"))
+
+	// conflicting changes to the same row fail the transaction committed last
+	exec(conn1, "BEGIN")
+	exec(conn1, "UPDATE people SET age = 22 WHERE name = 'Rob Robertson'")
+	exec(conn2, "UPDATE people SET age = 23 WHERE name = 'Rob Robertson'")
+	_, err = conn1.ExecContext(ctx, "COMMIT")
+	mysqlErr, ok := err.(*mysql.MySQLError)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, uint16(1213), mysqlErr.Number)
+	assert.Equal(t, 23, age(conn1, "Rob Robertson"))
+	assert.Equal(t, 23, age(conn2, "Rob Robertson"))
+
+	// rolled back changes are discarded
+	exec(conn1, "START TRANSACTION")
+	exec(conn1, "UPDATE people SET age = 40 WHERE name = 'Rob Robertson'")
+	assert.Equal(t, 40, age(conn1, "Rob Robertson"))
+	exec(conn1, "ROLLBACK")
+	assert.Equal(t, 23, age(conn1, "Rob Robertson"))
+	assert.Equal(t, 23, age(conn2, "Rob Robertson"))
+
+	// savepoints roll back only the changes made since they were created
+	exec(conn1, "START TRANSACTION")
+	exec(conn1, "UPDATE people SET age = 50 WHERE name = 'Rob Robertson'")
+	exec(conn1, "SAVEPOINT sp1")
+	exec(conn1, "UPDATE people SET age = 51 WHERE name = 'Rob Robertson'")
+	exec(conn1, "SAVEPOINT sp2")
+	exec(conn1, "UPDATE people SET age = 52 WHERE name = 'Rob Robertson'")
+	exec(conn1, "ROLLBACK TO SAVEPOINT sp2")
+	assert.Equal(t, 51, age(conn1, "Rob Robertson"))
+	exec(conn1, "ROLLBACK TO sp1")
+	assert.Equal(t, 50, age(conn1, "Rob Robertson"))
+	_, err = conn1.ExecContext(ctx, "RELEASE SAVEPOINT sp2")
+	mysqlErr, ok = err.(*mysql.MySQLError)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, uint16(1305), mysqlErr.Number)
+	exec(conn1, "RELEASE SAVEPOINT sp1")
+	exec(conn1, "COMMIT")
+	assert.Equal(t, 50, age(conn2, "Rob Robertson"))
+}
+
+func TestServerPreparedStatements(t *testing.T) {
+	env := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15307).withMaxConnections(10)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := gosql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	conn1, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn1.Close()
+	conn2, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	execPrepared := func(conn *gosql.Conn, query string, args ...interface{}) {
+		stmt, err := conn.PrepareContext(ctx, query)
+		require.NoError(t, err, query)
+		defer stmt.Close()
+		_, err = stmt.ExecContext(ctx, args...)
+		require.NoError(t, err, query)
+	}
+
+	// statements the engine doesn't support are run as they are outside of prepared statements
+	execPrepared(conn1, "CREATE TABLE places (pk int, center POINT)")
+	execPrepared(conn1, "INSERT INTO places VALUES (?, ST_GeomFromText(?))", 1, "POINT(1 2)")
+	execPrepared(conn1, "ALTER TABLE places ADD PRIMARY KEY (pk)")
+
+	var createStmt string
+	require.NoError(t, conn2.QueryRowContext(ctx, "SHOW CREATE TABLE places").Scan(new(string), &createStmt))
+	assert.Contains(t, createStmt, "`center` point")
+	assert.Contains(t, createStmt, "PRIMARY KEY (`pk`)")
+
+	var count int
+	require.NoError(t, conn2.QueryRowContext(ctx, "SELECT COUNT(*) FROM places WHERE pk = ?", 1).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestServerBranchFunctions(t *testing.T) {
+	dEnv := createEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15305).withMaxConnections(10)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, dEnv)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := gosql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	conn1, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn1.Close()
+	conn2, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	exec := func(conn *gosql.Conn, query string) {
+		_, err := conn.ExecContext(ctx, query)
+		require.NoError(t, err, query)
+	}
+
+	age := func(conn *gosql.Conn, name string) int {
+		var age int
+		require.NoError(t, conn.QueryRowContext(ctx, "SELECT age FROM people WHERE name = ?", name).Scan(&age))
+		return age
+	}
+
+	commitMessage := func(conn *gosql.Conn, branch string) string {
+		var msg string
+		require.NoError(t, conn.QueryRowContext(ctx, "SELECT latest_commit_message FROM dolt_branches WHERE name = ?", branch).Scan(&msg))
+		return msg
+	}
+
+	exec(conn1, "SELECT DOLT_ADD('people')")
+	exec(conn1, "SET @@dolt_head = COMMIT('add people')")
+	exec(conn1, "REPLACE INTO dolt_branches (name, hash) VALUES ('master', @@dolt_head)")
+	assert.Equal(t, "add people", commitMessage(conn2, "master"))
+
+	// each connection works on its own branch
+	exec(conn1, "SELECT DOLT_BRANCH('feature')")
+	exec(conn1, "SELECT DOLT_CHECKOUT('feature')")
+	exec(conn1, "UPDATE people SET age = 60 WHERE name = 'Bill Billerson'")
+	assert.Equal(t, 60, age(conn1, "Bill Billerson"))
+	assert.Equal(t, 32, age(conn2, "Bill Billerson"))
+
+	_, err = conn1.ExecContext(ctx, "SELECT DOLT_CHECKOUT('master')")
+	assert.Error(t, err)
+
+	exec(conn1, "SELECT DOLT_ADD('.')")
+	exec(conn1, "SET @@dolt_head = COMMIT('update bill')")
+	exec(conn1, "REPLACE INTO dolt_branches (name, hash) VALUES ('feature', @@dolt_head)")
+	assert.Equal(t, "update bill", commitMessage(conn2, "feature"))
+	assert.Equal(t, "add people", commitMessage(conn2, "master"))
+
+	// checking out a table discards its changes
+	exec(conn1, "UPDATE people SET age = 70 WHERE name = 'Bill Billerson'")
+	exec(conn1, "SELECT DOLT_CHECKOUT('people')")
+	assert.Equal(t, 60, age(conn1, "Bill Billerson"))
+
+	exec(conn1, "SELECT DOLT_CHECKOUT('master')")
+	assert.Equal(t, 32, age(conn1, "Bill Billerson"))
+
+	// the working set of a branch is shared by the sessions working on it, and isn't saved in the repo state
+	conn3, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn3.Close()
+
+	exec(conn1, "SELECT DOLT_CHECKOUT('feature')")
+	exec(conn1, "UPDATE people SET age = 80 WHERE name = 'Bill Billerson'")
+	exec(conn3, "SELECT DOLT_CHECKOUT('feature')")
+	assert.Equal(t, 80, age(conn3, "Bill Billerson"))
+	rs, err := env.LoadRepoState(dEnv.FS)
+	require.NoError(t, err)
+	assert.Equal(t, dEnv.RepoState.Working, rs.Working)
+	assert.Equal(t, "master", rs.CWBHeadRef().GetPath())
+
+	// changes which haven't been committed to the working set are committed before switching branches
+	exec(conn3, "SELECT DOLT_CHECKOUT('people')")
+	exec(conn3, "SELECT DOLT_CHECKOUT('master')")
+	assert.Equal(t, 60, age(conn1, "Bill Billerson"))
+	exec(conn1, "SELECT DOLT_CHECKOUT('master')")
+
+	// changes made in an explicit transaction must be committed first
+	exec(conn1, "START TRANSACTION")
+	exec(conn1, "SELECT DOLT_CHECKOUT('people')")
+	exec(conn1, "UPDATE people SET age = 90 WHERE name = 'Bill Billerson'")
+	exec(conn1, "SELECT DOLT_ADD('people')")
+	exec(conn1, "SET @@dolt_head = COMMIT('update bill on master')")
+	_, err = conn1.ExecContext(ctx, "SELECT DOLT_CHECKOUT('feature')")
+	assert.Error(t, err)
+	exec(conn1, "ROLLBACK")
+
+	exec(conn2, "SELECT DOLT_CHECKOUT('-b', 'other')")
+	exec(conn2, "SELECT DOLT_BRANCH('-D', 'feature')")
+	var count int
+	require.NoError(t, conn2.QueryRowContext(ctx, "SELECT COUNT(*) FROM dolt_branches").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	// the working set of a deleted branch is discarded along with it
+	exec(conn2, "SELECT DOLT_BRANCH('feature', 'master')")
+	exec(conn2, "SELECT DOLT_CHECKOUT('feature')")
+	assert.Equal(t, 32, age(conn2, "Bill Billerson"))
+}
+
+func TestServerRemoteFunctions(t *testing.T) {
+	ctx := context.Background()
+	dEnv := createEnvWithSeedData(t)
+	remote := env.NewRemote("origin", "file://"+t.TempDir(), nil)
+	dEnv.RepoState.AddRemote(remote)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15306)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, dEnv)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	db, err := gosql.Open("mysql", ConnectionString(serverConfig)+"dolt")
+	require.NoError(t, err)
+	defer db.Close()
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exec := func(query string) {
+		_, err := conn.ExecContext(ctx, query)
+		require.NoError(t, err, query)
+	}
+
+	query := func(query string) string {
+		var res string
+		require.NoError(t, conn.QueryRowContext(ctx, query).Scan(&res), query)
+		return res
+	}
+
+	// the refs updated by the last fetch, pull or push, as ref, old hash, new hash and status
+	remoteUpdates := func() [][]string {
+		rows, err := conn.QueryContext(ctx, "SELECT ref, COALESCE(old_hash, ''), new_hash, status FROM dolt_remote_updates")
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var updates [][]string
+		for rows.Next() {
+			update := make([]string, 4)
+			require.NoError(t, rows.Scan(&update[0], &update[1], &update[2], &update[3]))
+			updates = append(updates, update)
+		}
+
+		require.NoError(t, rows.Err())
+		return updates
+	}
+
+	master := ref.NewBranchRef("master")
+	head := query("SELECT @@dolt_head")
+	assert.Equal(t, "1", query("SELECT DOLT_PUSH()"))
+	assert.Equal(t, [][]string{{"origin/master", "", head, "created"}}, remoteUpdates())
+	assert.Equal(t, "0", query("SELECT DOLT_PUSH('origin', 'master')"))
+	assert.Equal(t, [][]string{{"origin/master", head, head, "up to date"}}, remoteUpdates())
+
+	exec("SELECT DOLT_ADD('people')")
+	exec("SET @@dolt_head = COMMIT('add people')")
+	newHead := query("SELECT @@dolt_head")
+	assert.Equal(t, "1", query("SELECT DOLT_PUSH()"))
+	assert.Equal(t, [][]string{{"origin/master", head, newHead, "fast-forward"}}, remoteUpdates())
+
+	remoteDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+	require.NoError(t, err)
+	assert.Equal(t, newHead, commitHash(t, remoteDB, master))
+
+	// commits made to the remote are fetched, and fast forwarded to by a pull
+	remoteHead := commitTableToRemote(t, remoteDB, "people2", 1000)
+	assert.Equal(t, "1", query("SELECT DOLT_FETCH()"))
+	assert.Equal(t, [][]string{{"origin/master", newHead, remoteHead, "fast-forward"}}, remoteUpdates())
+	assert.Equal(t, "0", query("SELECT DOLT_FETCH('origin', 'master')"))
+	assert.Empty(t, remoteUpdates())
+	assert.Equal(t, "1", query("SELECT DOLT_PULL()"))
+	assert.Equal(t, [][]string{{"master", newHead, remoteHead, "fast-forward"}}, remoteUpdates())
+	assert.Equal(t, remoteHead, query("SELECT @@dolt_head"))
+
+	// diverged commits are merged into the working set, and committed with both parents
+	exec("UPDATE people SET age = 60 WHERE name = 'Bill Billerson'")
+	exec("SET @@dolt_head = COMMIT('update bill')")
+	localHead := query("SELECT @@dolt_head")
+	oldRemoteHead := remoteHead
+	remoteHead = commitTableToRemote(t, remoteDB, "people3", 1001)
+	_, err = conn.ExecContext(ctx, "SELECT DOLT_PUSH()")
+	assert.Error(t, err)
+	assert.Equal(t, "2", query("SELECT DOLT_PULL('origin')"))
+	assert.Equal(t, [][]string{
+		{"origin/master", oldRemoteHead, remoteHead, "fast-forward"},
+		{"master", localHead, remoteHead, "merged"},
+	}, remoteUpdates())
+	exec("SET @@dolt_head = COMMIT('merge origin')")
+	mergeHead := query("SELECT @@dolt_head")
+	assert.Equal(t, "1", query("SELECT DOLT_PUSH()"))
+	assert.Equal(t, [][]string{{"origin/master", remoteHead, mergeHead, "fast-forward"}}, remoteUpdates())
+
+	require.NoError(t, remoteDB.Rebase(ctx))
+	assert.Equal(t, mergeHead, commitHash(t, remoteDB, master))
+	assert.Equal(t, "0", query("SELECT COUNT(*) FROM people3"))
+	assert.Equal(t, "60", query("SELECT age FROM people WHERE name = 'Bill Billerson'"))
+}
+
+// commitTableToRemote commits an empty table with the name and column tag given to the master branch of the remote
+// database given, and returns the hash of the commit.
+func commitTableToRemote(t *testing.T, remoteDB *doltdb.DoltDB, tblName string, tag uint64) string {
+	ctx := context.Background()
+	require.NoError(t, remoteDB.Rebase(ctx))
+	master := ref.NewBranchRef("master")
+	parent, err := remoteDB.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	root, err := parent.GetRootValue()
+	require.NoError(t, err)
+	cols, err := schema.NewColCollection(schema.NewColumn("pk", tag, types.IntKind, true))
+	require.NoError(t, err)
+	sch, err := schema.SchemaFromCols(cols)
+	require.NoError(t, err)
+	root, err = root.CreateEmptyTable(ctx, tblName, sch)
+	require.NoError(t, err)
+	h, err := remoteDB.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := doltdb.NewCommitMeta("Bill Billerson", "bill@example.com", "add "+tblName)
+	require.NoError(t, err)
+	cm, err := remoteDB.WriteDanglingCommit(ctx, h, []*doltdb.Commit{parent}, meta)
+	require.NoError(t, err)
+	require.NoError(t, remoteDB.FastForward(ctx, master, cm))
+	return commitHash(t, remoteDB, master)
+}
+
+func commitHash(t *testing.T, ddb *doltdb.DoltDB, branch ref.DoltRef) string {
+	cm, err := ddb.ResolveRef(context.Background(), branch)
+	require.NoError(t, err)
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+	return h.String()
+}
+
 func createEnvWithSeedData(t *testing.T) *env.DoltEnv {
 	dEnv := dtestutils.CreateTestEnv()
 	imt, sch := dtestutils.CreateTestDataTable(true)
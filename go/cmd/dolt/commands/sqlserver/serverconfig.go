@@ -44,6 +44,13 @@ const (
 	defaultAutoCommit       = true
 	defaultMaxConnections   = 1
 	defaultQueryParallelism = 2
+	defaultReplicaBranch    = "master"
+	defaultReplicaPollMs    = 5000
+	defaultPushMaxBackoffMs = 60 * 1000
+	defaultPushMaxRetries   = 10
+	defaultMaxExecutionTime = 0
+	defaultMaxReturnedRows  = 0
+	defaultMaxMemoryMB      = 0
 )
 
 // String returns the string representation of the log level.
@@ -66,6 +73,17 @@ func (level LogLevel) String() string {
 	}
 }
 
+// ReadReplicaConfig contains the configuration of a database which is served as a read replica of a branch of one
+// of its remotes.
+type ReadReplicaConfig struct {
+	// Remote is the name of the remote that the database follows.
+	Remote string
+	// Branch is the branch that the database fetches from the remote and serves.
+	Branch string
+	// PollIntervalMs is the number of milliseconds the database waits between fetches from the remote.
+	PollIntervalMs uint64
+}
+
 // ServerConfig contains all of the configurable options for the MySQL-compatible server.
 type ServerConfig interface {
 	// Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -94,6 +112,26 @@ type ServerConfig interface {
 	MaxConnections() uint64
 	// QueryParallelism returns the parallelism that should be used by the go-mysql-server analyzer
 	QueryParallelism() int
+	// MaxExecutionTime returns the default number of milliseconds a query may run before it is cancelled. Sessions may
+	// override it with the max_execution_time session variable. 0 means queries are not timed out.
+	MaxExecutionTime() uint64
+	// MaxReturnedRows returns the number of rows a query may return before it is cancelled. 0 means no limit.
+	MaxReturnedRows() uint64
+	// MaxMemory returns the number of megabytes of memory the server may use before queries that cache rows in memory,
+	// such as sorts and joins, fail. 0 means no limit.
+	MaxMemory() uint64
+	// ReadReplicas returns the configuration of the databases which are served as read replicas, keyed by database
+	// name. Databases which are not in the map are served normally.
+	ReadReplicas() map[string]ReadReplicaConfig
+	// PushRemotes returns the names of the remotes that commits made through SQL are pushed to. An empty list means
+	// commits are not pushed.
+	PushRemotes() []string
+	// PushBranches returns the branches whose commits are pushed to PushRemotes. An empty list means every branch.
+	PushBranches() []string
+	// PushMaxBackoff returns the maximum number of milliseconds to wait before retrying a failed push.
+	PushMaxBackoff() uint64
+	// PushMaxRetries returns the number of times a failed push is retried before it's reported as failed.
+	PushMaxRetries() uint64
 }
 
 type commandLineServerConfig struct {
@@ -108,6 +146,14 @@ type commandLineServerConfig struct {
 	autoCommit       bool
 	maxConnections   uint64
 	queryParallelism int
+	maxExecutionTime uint64
+	maxReturnedRows  uint64
+	maxMemoryMB      uint64
+	readReplicas     map[string]ReadReplicaConfig
+	pushRemotes      []string
+	pushBranches     []string
+	pushMaxBackoffMs uint64
+	pushMaxRetries   uint64
 }
 
 // Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -165,6 +211,22 @@ func (cfg *commandLineServerConfig) QueryParallelism() int {
 	return cfg.queryParallelism
 }
 
+// MaxExecutionTime returns the default number of milliseconds a query may run before it is cancelled.
+func (cfg *commandLineServerConfig) MaxExecutionTime() uint64 {
+	return cfg.maxExecutionTime
+}
+
+// MaxReturnedRows returns the number of rows a query may return before it is cancelled. 0 means no limit.
+func (cfg *commandLineServerConfig) MaxReturnedRows() uint64 {
+	return cfg.maxReturnedRows
+}
+
+// MaxMemory returns the number of megabytes of memory the server may use before queries that cache rows in memory
+// fail. 0 means no limit.
+func (cfg *commandLineServerConfig) MaxMemory() uint64 {
+	return cfg.maxMemoryMB
+}
+
 // DatabaseNamesAndPaths returns an array of env.EnvNameAndPathObjects corresponding to the databases to be loaded in
 // a multiple db configuration. If nil is returned the server will look for a database in the current directory and
 // give it a name automatically.
@@ -172,6 +234,31 @@ func (cfg *commandLineServerConfig) DatabaseNamesAndPaths() []env.EnvNameAndPath
 	return cfg.dbNamesAndPaths
 }
 
+// ReadReplicas returns the configuration of the databases which are served as read replicas, keyed by database name.
+func (cfg *commandLineServerConfig) ReadReplicas() map[string]ReadReplicaConfig {
+	return cfg.readReplicas
+}
+
+// PushRemotes returns the names of the remotes that commits made through SQL are pushed to.
+func (cfg *commandLineServerConfig) PushRemotes() []string {
+	return cfg.pushRemotes
+}
+
+// PushBranches returns the branches whose commits are pushed to PushRemotes. An empty list means every branch.
+func (cfg *commandLineServerConfig) PushBranches() []string {
+	return cfg.pushBranches
+}
+
+// PushMaxBackoff returns the maximum number of milliseconds to wait before retrying a failed push.
+func (cfg *commandLineServerConfig) PushMaxBackoff() uint64 {
+	return cfg.pushMaxBackoffMs
+}
+
+// PushMaxRetries returns the number of times a failed push is retried before it's reported as failed.
+func (cfg *commandLineServerConfig) PushMaxRetries() uint64 {
+	return cfg.pushMaxRetries
+}
+
 // withHost updates the host and returns the called `*commandLineServerConfig`, which is useful for chaining calls.
 func (cfg *commandLineServerConfig) withHost(host string) *commandLineServerConfig {
 	cfg.host = host
@@ -227,11 +314,39 @@ func (cfg *commandLineServerConfig) withQueryParallelism(queryParallelism int) *
 	return cfg
 }
 
+// withResourceLimits updates the query execution time, returned row and memory limits and returns the called
+// `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withResourceLimits(maxExecutionTime, maxReturnedRows, maxMemoryMB uint64) *commandLineServerConfig {
+	cfg.maxExecutionTime = maxExecutionTime
+	cfg.maxReturnedRows = maxReturnedRows
+	cfg.maxMemoryMB = maxMemoryMB
+	return cfg
+}
+
 func (cfg *commandLineServerConfig) withDBNamesAndPaths(dbNamesAndPaths []env.EnvNameAndPath) *commandLineServerConfig {
 	cfg.dbNamesAndPaths = dbNamesAndPaths
 	return cfg
 }
 
+// withReadReplica configures the database named to be served as a read replica of the remote and branch in the
+// config given, and returns the called `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withReadReplica(dbName string, replicaCfg ReadReplicaConfig) *commandLineServerConfig {
+	if cfg.readReplicas == nil {
+		cfg.readReplicas = make(map[string]ReadReplicaConfig)
+	}
+
+	cfg.readReplicas[dbName] = replicaCfg
+	return cfg
+}
+
+// withPushReplication configures the server to push commits made to the branches given to the remotes given, and
+// returns the called `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withPushReplication(remotes, branches []string) *commandLineServerConfig {
+	cfg.pushRemotes = remotes
+	cfg.pushBranches = branches
+	return cfg
+}
+
 // DefaultServerConfig creates a `*ServerConfig` that has all of the options set to their default values.
 func DefaultServerConfig() *commandLineServerConfig {
 	return &commandLineServerConfig{
@@ -245,6 +360,11 @@ func DefaultServerConfig() *commandLineServerConfig {
 		autoCommit:       defaultAutoCommit,
 		maxConnections:   defaultMaxConnections,
 		queryParallelism: defaultQueryParallelism,
+		maxExecutionTime: defaultMaxExecutionTime,
+		maxReturnedRows:  defaultMaxReturnedRows,
+		maxMemoryMB:      defaultMaxMemoryMB,
+		pushMaxBackoffMs: defaultPushMaxBackoffMs,
+		pushMaxRetries:   defaultPushMaxRetries,
 	}
 }
 
@@ -265,6 +385,14 @@ func ValidateConfig(config ServerConfig) error {
 	if config.LogLevel().String() == "unknown" {
 		return fmt.Errorf("loglevel is invalid: %v\n", string(config.LogLevel()))
 	}
+	for name, replicaCfg := range config.ReadReplicas() {
+		if replicaCfg.Remote == "" {
+			return fmt.Errorf("read replica %s must have a remote", name)
+		}
+		if replicaCfg.PollIntervalMs == 0 {
+			return fmt.Errorf("read replica %s poll interval must be greater than 0", name)
+		}
+	}
 	return nil
 }
 
@@ -62,7 +62,7 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}behavior.read_only{{.EmphasisRight}} - If true database modification is disabled
 
-		{{.EmphasisLeft}}behavior.autocommit{{.EmphasisRight}} - If true write queries will automatically alter the working set. When working with autocommit enabled it is highly recommended that listener.max_connections be set to 1 as concurrency issues will arise otherwise
+		{{.EmphasisLeft}}behavior.autocommit{{.EmphasisRight}} - If true write queries will automatically alter the working set. Otherwise changes are written to the working set by {{.EmphasisLeft}}COMMIT{{.EmphasisRight}}. Changes committed by concurrent connections are merged, and a transaction fails with a serialization error if another connection committed conflicting changes to the same rows since it started
 
 		{{.EmphasisLeft}}user.name{{.EmphasisRight}} - The username that connections should use for authentication
 
@@ -80,6 +80,28 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}performance.query_parallelism{{.EmphasisRight}} - Amount of go routines spawned to process each query
 
+		{{.EmphasisLeft}}performance.max_execution_time_millis{{.EmphasisRight}} - The number of milliseconds a query may run before it is cancelled. Connections may override it by setting the {{.EmphasisLeft}}max_execution_time{{.EmphasisRight}} session variable. 0 means no limit
+
+		{{.EmphasisLeft}}performance.max_returned_rows{{.EmphasisRight}} - The number of rows a query may return before it is cancelled. 0 means no limit
+
+		{{.EmphasisLeft}}performance.max_memory_mb{{.EmphasisRight}} - The number of megabytes of memory the server may use before queries which sort, group or join rows in memory fail. 0 means no limit
+
+		{{.EmphasisLeft}}read_replicas{{.EmphasisRight}} - A map from the names of databases to serve as read replicas to their replica configuration. A read replica is served read-only and is periodically updated to the head of a branch on one of its remotes. Databases which are not in the map are served normally
+
+		{{.EmphasisLeft}}read_replicas.<db>.remote{{.EmphasisRight}} - The name of the remote that the database follows
+
+		{{.EmphasisLeft}}read_replicas.<db>.branch{{.EmphasisRight}} - The branch of the remote that the database follows
+
+		{{.EmphasisLeft}}read_replicas.<db>.poll_interval_millis{{.EmphasisRight}} - The number of milliseconds between fetches of the remote by the database
+
+		{{.EmphasisLeft}}replication.push_remotes{{.EmphasisRight}} - A list of remotes that branches are pushed to asynchronously whenever they are created or moved through SQL, e.g. by writing the hash returned by {{.EmphasisLeft}}COMMIT(){{.EmphasisRight}} to {{.EmphasisLeft}}dolt_branches{{.EmphasisRight}}. The progress of pushes is shown in the {{.EmphasisLeft}}dolt_replication_status{{.EmphasisRight}} system table
+
+		{{.EmphasisLeft}}replication.branches{{.EmphasisRight}} - A list of branches whose commits are pushed. If missing or empty then commits to every branch are pushed
+
+		{{.EmphasisLeft}}replication.max_backoff_millis{{.EmphasisRight}} - The maximum number of milliseconds to wait before retrying a failed push
+
+		{{.EmphasisLeft}}replication.max_retries{{.EmphasisRight}} - The number of times a failed push is retried before the branch is shown as failed in {{.EmphasisLeft}}dolt_replication_status{{.EmphasisRight}}. Pushes which can't be fast forwarded are not retried
+
 		{{.EmphasisLeft}}databases{{.EmphasisRight}} - a list of dolt data repositories to make available as SQL databases. If databases is missing or empty then the working directory must be a valid dolt data repository which will be made available as a SQL database
 		
 		{{.EmphasisLeft}}databases[i].path{{.EmphasisRight}} - A path to a dolt data repository
@@ -0,0 +1,115 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"regexp"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/sirupsen/logrus"
+
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+// go-mysql-server doesn't support START TRANSACTION or savepoints, and treats COMMIT and ROLLBACK as no-ops outside of
+// autocommit, so transaction statements are handled by the server itself.
+var (
+	beginRegex            = regexp.MustCompile(`(?i)^\s*(start\s+transaction|begin(\s+work)?)\s*;?\s*$`)
+	commitRegex           = regexp.MustCompile(`(?i)^\s*commit(\s+work)?\s*;?\s*$`)
+	rollbackRegex         = regexp.MustCompile(`(?i)^\s*rollback(\s+work)?\s*;?\s*$`)
+	savepointRegex        = regexp.MustCompile("(?i)^\\s*savepoint\\s+`?([^`\\s;]+)`?\\s*;?\\s*$")
+	rollbackToRegex       = regexp.MustCompile("(?i)^\\s*rollback\\s+(work\\s+)?to\\s+(savepoint\\s+)?`?([^`\\s;]+)`?\\s*;?\\s*$")
+	releaseSavepointRegex = regexp.MustCompile("(?i)^\\s*release\\s+savepoint\\s+`?([^`\\s;]+)`?\\s*;?\\s*$")
+)
+
+const (
+	// erSPDoesNotExist is the MySQL error code ER_SP_DOES_NOT_EXIST, which isn't defined by vitess
+	erSPDoesNotExist = 1305
+	// ssSyntaxErrorOrAccessViolation is the SQLSTATE of ER_SP_DOES_NOT_EXIST
+	ssSyntaxErrorOrAccessViolation = "42000"
+)
+
+// handleTransactionStatement runs the query given if it is a START TRANSACTION, BEGIN, COMMIT, ROLLBACK, SAVEPOINT,
+// ROLLBACK TO SAVEPOINT or RELEASE SAVEPOINT statement. Returns false if the query is some other statement.
+func (h *doltHandler) handleTransactionStatement(c *mysql.Conn, query string) (bool, error) {
+	var run func(sqlCtx *sql.Context, dSess *dsqle.DoltSession) error
+	if beginRegex.MatchString(query) {
+		run = func(sqlCtx *sql.Context, dSess *dsqle.DoltSession) error {
+			return dSess.BeginTransaction(sqlCtx)
+		}
+	} else if commitRegex.MatchString(query) {
+		run = func(sqlCtx *sql.Context, dSess *dsqle.DoltSession) error {
+			return dSess.EndTransaction(sqlCtx)
+		}
+	} else if rollbackRegex.MatchString(query) {
+		run = func(sqlCtx *sql.Context, dSess *dsqle.DoltSession) error {
+			return dSess.RollbackTransaction(sqlCtx)
+		}
+	} else if m := savepointRegex.FindStringSubmatch(query); m != nil {
+		run = func(sqlCtx *sql.Context, dSess *dsqle.DoltSession) error {
+			return dSess.CreateSavepoint(sqlCtx, m[1])
+		}
+	} else if m := rollbackToRegex.FindStringSubmatch(query); m != nil {
+		run = func(sqlCtx *sql.Context, dSess *dsqle.DoltSession) error {
+			return dSess.RollbackToSavepoint(sqlCtx, m[3])
+		}
+	} else if m := releaseSavepointRegex.FindStringSubmatch(query); m != nil {
+		run = func(sqlCtx *sql.Context, dSess *dsqle.DoltSession) error {
+			return dSess.ReleaseSavepoint(m[1])
+		}
+	} else {
+		return false, nil
+	}
+
+	sqlCtx, err := h.sm.NewContext(c)
+	if err != nil {
+		return true, err
+	}
+
+	return true, run(sqlCtx, dsqle.DSessFromSess(sqlCtx.Session))
+}
+
+// startTransaction starts a new transaction for the connection's next statement, unless the connection is in an
+// explicit transaction.
+func (h *doltHandler) startTransaction(c *mysql.Conn) error {
+	sqlCtx, err := h.sm.NewContext(c)
+	if err != nil {
+		return err
+	}
+
+	dSess := dsqle.DSessFromSess(sqlCtx.Session)
+	if dSess.InTransaction() {
+		return nil
+	}
+
+	return dSess.StartTransaction(sqlCtx)
+}
+
+// toTransactionError converts a serialization failure to the MySQL error clients expect when a transaction must be
+// retried, and a missing savepoint to MySQL's error for it. Other errors are returned unchanged.
+func toTransactionError(c *mysql.Conn, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case dsqle.ErrSerializationFailure.Is(err):
+		logrus.Infof("connection %d: %v", c.ConnectionID, err)
+		return mysql.NewSQLError(mysql.ERLockDeadlock, mysql.SSLockDeadlock, "%s", err.Error())
+	case dsqle.ErrSavepointDoesNotExist.Is(err):
+		return mysql.NewSQLError(erSPDoesNotExist, ssSyntaxErrorOrAccessViolation, "%s", err.Error())
+	}
+
+	return err
+}
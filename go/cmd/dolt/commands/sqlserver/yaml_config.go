@@ -67,19 +67,40 @@ type ListenerYAMLConfig struct {
 	WriteTimeoutMillis *uint64 `yaml:"write_timeout_millis"`
 }
 
-// PerformanceYAMLConfig contains configuration parameters for performance tweaking
+// PerformanceYAMLConfig contains configuration parameters for performance tweaking. The server authenticates a
+// single user, so its limits apply to every connection; sessions may lower or raise their own max_execution_time.
 type PerformanceYAMLConfig struct {
-	QueryParallelism *int `yaml:"query_parallelism"`
+	QueryParallelism       *int    `yaml:"query_parallelism"`
+	MaxExecutionTimeMillis *uint64 `yaml:"max_execution_time_millis"`
+	MaxReturnedRows        *uint64 `yaml:"max_returned_rows"`
+	MaxMemoryMB            *uint64 `yaml:"max_memory_mb"`
+}
+
+// ReadReplicaYAMLConfig contains configuration for serving a database as a read replica which follows a remote
+type ReadReplicaYAMLConfig struct {
+	Remote             *string `yaml:"remote"`
+	Branch             *string `yaml:"branch"`
+	PollIntervalMillis *uint64 `yaml:"poll_interval_millis"`
+}
+
+// ReplicationYAMLConfig contains configuration for pushing commits made through SQL to remotes
+type ReplicationYAMLConfig struct {
+	PushRemotes      []string `yaml:"push_remotes"`
+	Branches         []string `yaml:"branches"`
+	MaxBackoffMillis *uint64  `yaml:"max_backoff_millis"`
+	MaxRetries       *uint64  `yaml:"max_retries"`
 }
 
 // YAMLConfig is a ServerConfig implementation which is read from a yaml file
 type YAMLConfig struct {
-	LogLevelStr       *string               `yaml:"log_level"`
-	BehaviorConfig    BehaviorYAMLConfig    `yaml:"behavior"`
-	UserConfig        UserYAMLConfig        `yaml:"user"`
-	ListenerConfig    ListenerYAMLConfig    `yaml:"listener"`
-	DatabaseConfig    []DatabaseYAMLConfig  `yaml:"databases"`
-	PerformanceConfig PerformanceYAMLConfig `yaml:"performance"`
+	LogLevelStr       *string                          `yaml:"log_level"`
+	BehaviorConfig    BehaviorYAMLConfig               `yaml:"behavior"`
+	UserConfig        UserYAMLConfig                   `yaml:"user"`
+	ListenerConfig    ListenerYAMLConfig               `yaml:"listener"`
+	DatabaseConfig    []DatabaseYAMLConfig             `yaml:"databases"`
+	PerformanceConfig PerformanceYAMLConfig            `yaml:"performance"`
+	ReadReplicaConfig map[string]ReadReplicaYAMLConfig `yaml:"read_replicas"`
+	ReplicationConfig ReplicationYAMLConfig            `yaml:"replication"`
 }
 
 func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
@@ -95,9 +116,39 @@ func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 			uint64Ptr(cfg.WriteTimeout()),
 		},
 		DatabaseConfig: nil,
+		PerformanceConfig: PerformanceYAMLConfig{
+			intPtr(cfg.QueryParallelism()),
+			uint64Ptr(cfg.MaxExecutionTime()),
+			uint64Ptr(cfg.MaxReturnedRows()),
+			uint64Ptr(cfg.MaxMemory()),
+		},
+		ReadReplicaConfig: readReplicasAsYAMLConfig(cfg.ReadReplicas()),
+		ReplicationConfig: ReplicationYAMLConfig{
+			cfg.PushRemotes(),
+			cfg.PushBranches(),
+			uint64Ptr(cfg.PushMaxBackoff()),
+			uint64Ptr(cfg.PushMaxRetries()),
+		},
 	}
 }
 
+func readReplicasAsYAMLConfig(replicas map[string]ReadReplicaConfig) map[string]ReadReplicaYAMLConfig {
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	replicaCfgs := make(map[string]ReadReplicaYAMLConfig, len(replicas))
+	for name, replica := range replicas {
+		replicaCfgs[name] = ReadReplicaYAMLConfig{
+			strPtr(replica.Remote),
+			strPtr(replica.Branch),
+			uint64Ptr(replica.PollIntervalMs),
+		}
+	}
+
+	return replicaCfgs
+}
+
 // String returns the YAML representation of the config
 func (cfg YAMLConfig) String() string {
 	data, err := yaml.Marshal(cfg)
@@ -240,3 +291,88 @@ func (cfg YAMLConfig) QueryParallelism() int {
 
 	return *cfg.PerformanceConfig.QueryParallelism
 }
+
+// MaxExecutionTime returns the default number of milliseconds a query may run before it is cancelled.
+func (cfg YAMLConfig) MaxExecutionTime() uint64 {
+	if cfg.PerformanceConfig.MaxExecutionTimeMillis == nil {
+		return defaultMaxExecutionTime
+	}
+
+	return *cfg.PerformanceConfig.MaxExecutionTimeMillis
+}
+
+// MaxReturnedRows returns the number of rows a query may return before it is cancelled. 0 means no limit.
+func (cfg YAMLConfig) MaxReturnedRows() uint64 {
+	if cfg.PerformanceConfig.MaxReturnedRows == nil {
+		return defaultMaxReturnedRows
+	}
+
+	return *cfg.PerformanceConfig.MaxReturnedRows
+}
+
+// MaxMemory returns the number of megabytes of memory the server may use before queries that cache rows in memory
+// fail. 0 means no limit.
+func (cfg YAMLConfig) MaxMemory() uint64 {
+	if cfg.PerformanceConfig.MaxMemoryMB == nil {
+		return defaultMaxMemoryMB
+	}
+
+	return *cfg.PerformanceConfig.MaxMemoryMB
+}
+
+// ReadReplicas returns the configuration of the databases which are served as read replicas, keyed by database name.
+func (cfg YAMLConfig) ReadReplicas() map[string]ReadReplicaConfig {
+	if len(cfg.ReadReplicaConfig) == 0 {
+		return nil
+	}
+
+	replicas := make(map[string]ReadReplicaConfig, len(cfg.ReadReplicaConfig))
+	for name, replicaCfg := range cfg.ReadReplicaConfig {
+		replica := ReadReplicaConfig{
+			Branch:         defaultReplicaBranch,
+			PollIntervalMs: defaultReplicaPollMs,
+		}
+
+		if replicaCfg.Remote != nil {
+			replica.Remote = *replicaCfg.Remote
+		}
+		if replicaCfg.Branch != nil {
+			replica.Branch = *replicaCfg.Branch
+		}
+		if replicaCfg.PollIntervalMillis != nil {
+			replica.PollIntervalMs = *replicaCfg.PollIntervalMillis
+		}
+
+		replicas[name] = replica
+	}
+
+	return replicas
+}
+
+// PushRemotes returns the names of the remotes that commits made through SQL are pushed to.
+func (cfg YAMLConfig) PushRemotes() []string {
+	return cfg.ReplicationConfig.PushRemotes
+}
+
+// PushBranches returns the branches whose commits are pushed to PushRemotes. An empty list means every branch.
+func (cfg YAMLConfig) PushBranches() []string {
+	return cfg.ReplicationConfig.Branches
+}
+
+// PushMaxBackoff returns the maximum number of milliseconds to wait before retrying a failed push.
+func (cfg YAMLConfig) PushMaxBackoff() uint64 {
+	if cfg.ReplicationConfig.MaxBackoffMillis == nil {
+		return defaultPushMaxBackoffMs
+	}
+
+	return *cfg.ReplicationConfig.MaxBackoffMillis
+}
+
+// PushMaxRetries returns the number of times a failed push is retried before it's reported as failed.
+func (cfg YAMLConfig) PushMaxRetries() uint64 {
+	if cfg.ReplicationConfig.MaxRetries == nil {
+		return defaultPushMaxRetries
+	}
+
+	return *cfg.ReplicationConfig.MaxRetries
+}
@@ -41,6 +41,16 @@ listener:
     max_connections: 1
     read_timeout_millis: 28800000
     write_timeout_millis: 28800000
+
+performance:
+    query_parallelism: 2
+    max_execution_time_millis: 0
+    max_returned_rows: 0
+    max_memory_mb: 0
+
+replication:
+    max_backoff_millis: 60000
+    max_retries: 10
     
 databases:
     - name: irs_soi
@@ -67,6 +77,33 @@ databases:
 	assert.Equal(t, expected, config)
 }
 
+func TestServerConfigAsYAMLConfig(t *testing.T) {
+	serverCfg := DefaultServerConfig().
+		withPort(15300).
+		withMaxConnections(20).
+		withQueryParallelism(4).
+		withResourceLimits(1000, 500, 256).
+		withReadReplica("sales", ReadReplicaConfig{Remote: "origin", Branch: "release", PollIntervalMs: 250}).
+		withPushReplication([]string{"origin", "backup"}, []string{"master"})
+
+	var cfg YAMLConfig
+	err := yaml.Unmarshal([]byte(serverConfigAsYAMLConfig(serverCfg).String()), &cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, serverCfg.Host(), cfg.Host())
+	assert.Equal(t, serverCfg.Port(), cfg.Port())
+	assert.Equal(t, serverCfg.MaxConnections(), cfg.MaxConnections())
+	assert.Equal(t, serverCfg.QueryParallelism(), cfg.QueryParallelism())
+	assert.Equal(t, serverCfg.MaxExecutionTime(), cfg.MaxExecutionTime())
+	assert.Equal(t, serverCfg.MaxReturnedRows(), cfg.MaxReturnedRows())
+	assert.Equal(t, serverCfg.MaxMemory(), cfg.MaxMemory())
+	assert.Equal(t, serverCfg.ReadReplicas(), cfg.ReadReplicas())
+	assert.Equal(t, serverCfg.PushRemotes(), cfg.PushRemotes())
+	assert.Equal(t, serverCfg.PushBranches(), cfg.PushBranches())
+	assert.Equal(t, serverCfg.PushMaxBackoff(), cfg.PushMaxBackoff())
+	assert.Equal(t, serverCfg.PushMaxRetries(), cfg.PushMaxRetries())
+}
+
 func TestYAMLConfigDefaults(t *testing.T) {
 	var cfg YAMLConfig
 	err := yaml.Unmarshal([]byte{}, &cfg)
@@ -82,4 +119,30 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Equal(t, defaultLogLevel, cfg.LogLevel())
 	assert.Equal(t, defaultAutoCommit, cfg.AutoCommit())
 	assert.Equal(t, uint64(defaultMaxConnections), cfg.MaxConnections())
+	assert.Equal(t, uint64(defaultMaxExecutionTime), cfg.MaxExecutionTime())
+	assert.Equal(t, uint64(defaultMaxReturnedRows), cfg.MaxReturnedRows())
+	assert.Equal(t, uint64(defaultMaxMemoryMB), cfg.MaxMemory())
+	assert.Empty(t, cfg.ReadReplicas())
+}
+
+func TestYAMLReadReplica(t *testing.T) {
+	testStr := `
+read_replicas:
+    sales:
+        remote: origin
+        branch: release
+        poll_interval_millis: 250
+    inventory:
+        remote: upstream
+`
+
+	var cfg YAMLConfig
+	err := yaml.Unmarshal([]byte(testStr), &cfg)
+	require.NoError(t, err)
+
+	expected := map[string]ReadReplicaConfig{
+		"sales":     {Remote: "origin", Branch: "release", PollIntervalMs: 250},
+		"inventory": {Remote: "upstream", Branch: defaultReplicaBranch, PollIntervalMs: defaultReplicaPollMs},
+	}
+	assert.Equal(t, expected, cfg.ReadReplicas())
 }
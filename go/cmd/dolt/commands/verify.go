@@ -0,0 +1,95 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var verifyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Checks the working set's data against the constraints of its tables.",
+	LongDesc: `Checks every table of the working set for rows that violate NOT NULL constraints, the bounds of their column types or check constraints, for indexes that don't match the rows of their tables or contain duplicate keys of unique indexes, and for foreign keys whose rows are missing from the referenced tables.
+
+Writes through SQL are checked as they happen, but merges made with {{.EmphasisLeft}}--force{{.EmphasisRight}} skip foreign key checks, and other edits can leave data that doesn't satisfy its schema. The violations found are listed, and the command fails if there are any. They can also be queried through the {{.EmphasisLeft}}dolt_constraint_violations{{.EmphasisRight}} system table.`,
+	Synopsis: []string{""},
+}
+
+type VerifyCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd VerifyCmd) Name() string {
+	return "verify"
+}
+
+// Description returns a description of the command
+func (cmd VerifyCmd) Description() string {
+	return verifyDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd VerifyCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, verifyDocs, ap))
+}
+
+func (cmd VerifyCmd) createArgParser() *argparser.ArgParser {
+	return argparser.NewArgParser()
+}
+
+// Exec executes the command
+func (cmd VerifyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, verifyDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+	if apr.NArg() != 0 {
+		usage()
+		return 1
+	}
+
+	working, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("Unable to get working.").AddCause(err).Build(), nil)
+	}
+
+	violations, err := merge.VerifyRoot(ctx, working)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to verify tables").AddCause(err).Build(), nil)
+	}
+
+	if len(violations) == 0 {
+		cli.Println("No constraint violations found.")
+		return 0
+	}
+
+	for _, v := range violations {
+		location := v.Table
+		if v.Key != "" {
+			location = fmt.Sprintf("%s (%s)", v.Table, v.Key)
+		}
+		cli.Println(color.RedString("%s: %s `%s`: %s", location, v.Type, v.Name, v.Message))
+	}
+	cli.PrintErrln(fmt.Sprintf("Found %d constraint violation(s).", len(violations)))
+	return 1
+}
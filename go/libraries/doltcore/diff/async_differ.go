@@ -21,6 +21,7 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/dolthub/dolt/go/libraries/utils/async"
 	"github.com/dolthub/dolt/go/store/diff"
 	"github.com/dolthub/dolt/go/store/types"
@@ -58,6 +59,163 @@ func (ad *AsyncDiffer) Start(ctx context.Context, from, to types.Map) {
 	})
 }
 
+// StartInRanges starts diffing the rows of the maps given whose keys are within one of the ranges given. Rather than
+// diffing the maps in full, the keys within each range are read from both maps using range iteration and compared.
+// Ranges are diffed in the order given, and should not overlap.
+func (ad *AsyncDiffer) StartInRanges(ctx context.Context, from, to types.Map, ranges []*noms.ReadRange) {
+	ad.eg, ad.egCtx = errgroup.WithContext(ctx)
+	ad.egCancel = async.GoWithCancel(ad.egCtx, ad.eg, func(ctx context.Context) error {
+		defer close(ad.diffChan)
+		for _, r := range ranges {
+			err := diffRange(ctx, from, to, r, ad.diffChan)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// diffRange sends a diff.Difference to the channel given for each key within the range given whose value differs
+// between the two maps.
+func diffRange(ctx context.Context, from, to types.Map, r *noms.ReadRange, diffChan chan<- diff.Difference) error {
+	fromItr, err := newRangeIterator(ctx, from, r)
+	if err != nil {
+		return err
+	}
+
+	toItr, err := newRangeIterator(ctx, to, r)
+	if err != nil {
+		return err
+	}
+
+	fromKey, fromVal, err := fromItr.next(ctx)
+	if err != nil {
+		return err
+	}
+
+	toKey, toVal, err := toItr.next(ctx)
+	if err != nil {
+		return err
+	}
+
+	for fromKey != nil || toKey != nil {
+		var d diff.Difference
+		var advanceFrom, advanceTo bool
+
+		if fromKey != nil && toKey != nil && fromKey.Equals(toKey) {
+			advanceFrom, advanceTo = true, true
+			if !fromVal.Equals(toVal) {
+				d = diff.Difference{ChangeType: types.DiffChangeModified, KeyValue: toKey, OldValue: fromVal, NewValue: toVal}
+			}
+		} else {
+			fromFirst := toKey == nil
+			if fromKey != nil && toKey != nil {
+				// keys are read in descending order for reverse ranges
+				fromFirst, err = fromKey.Less(from.Format(), toKey)
+				if err != nil {
+					return err
+				}
+
+				fromFirst = fromFirst != r.Reverse
+			}
+
+			if fromFirst {
+				advanceFrom = true
+				d = diff.Difference{ChangeType: types.DiffChangeRemoved, KeyValue: fromKey, OldValue: fromVal}
+			} else {
+				advanceTo = true
+				d = diff.Difference{ChangeType: types.DiffChangeAdded, KeyValue: toKey, NewValue: toVal}
+			}
+		}
+
+		if !d.IsEmpty() {
+			select {
+			case diffChan <- d:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if advanceFrom {
+			fromKey, fromVal, err = fromItr.next(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if advanceTo {
+			toKey, toVal, err = toItr.next(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rangeIterator iterates over the entries of a map whose keys are within a noms.ReadRange
+type rangeIterator struct {
+	itr   types.MapIterator
+	r     *noms.ReadRange
+	first bool
+}
+
+func newRangeIterator(ctx context.Context, m types.Map, r *noms.ReadRange) (*rangeIterator, error) {
+	var itr types.MapIterator
+	var err error
+	if r.Reverse {
+		itr, err = m.IteratorBackFrom(ctx, r.Start)
+	} else {
+		itr, err = m.IteratorFrom(ctx, r.Start)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rangeIterator{itr, r, true}, nil
+}
+
+// next returns the next key and value within the range, or a nil key once the range is exhausted.
+func (ri *rangeIterator) next(ctx context.Context) (types.Value, types.Value, error) {
+	if ri.itr == nil {
+		return nil, nil, nil
+	}
+
+	k, v, err := ri.itr.Next(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ri.first {
+		ri.first = false
+		if k != nil && !ri.r.Inclusive && ri.r.Start.Equals(k) {
+			k, v, err = ri.itr.Next(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if k != nil {
+		inRange, err := ri.r.Check(k.(types.Tuple))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if inRange {
+			return k, v, nil
+		}
+	}
+
+	ri.itr = nil
+	return nil, nil, nil
+}
+
 func (ad *AsyncDiffer) Close() error {
 	ad.egCancel()
 	return ad.eg.Wait()
@@ -36,14 +36,17 @@ type RowDiffSource struct {
 	joiner     *rowconv.Joiner
 	oldRowConv *rowconv.RowConverter
 	newRowConv *rowconv.RowConverter
+
+	// pending holds the rows left to return for a diff of a keyless row whose cardinality changed by more than 1
+	pending []row.Row
 }
 
 func NewRowDiffSource(ad *AsyncDiffer, joiner *rowconv.Joiner) *RowDiffSource {
 	return &RowDiffSource{
-		ad,
-		joiner,
-		rowconv.IdentityConverter,
-		rowconv.IdentityConverter,
+		ad:         ad,
+		joiner:     joiner,
+		oldRowConv: rowconv.IdentityConverter,
+		newRowConv: rowconv.IdentityConverter,
 	}
 }
 
@@ -59,7 +62,16 @@ func (rdRd *RowDiffSource) GetSchema() schema.Schema {
 
 // NextDiff reads a row from a table.  If there is a bad row the returned error will be non nil, and callin IsBadRow(err)
 // will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row, or fail.
+//
+// Rows of keyless tables are identified by their contents, so a change in their cardinality is returned as one added
+// or removed row for every row added or removed, and they are never returned as modified.
 func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, error) {
+	if len(rdRd.pending) > 0 {
+		r := rdRd.pending[0]
+		rdRd.pending = rdRd.pending[1:]
+		return r, pipeline.ImmutableProperties{}, nil
+	}
+
 	diffs, hasMore, err := rdRd.ad.GetDiffs(1, time.Second)
 	if err != nil {
 		return nil, pipeline.ImmutableProperties{}, err
@@ -78,6 +90,8 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 
 	d := diffs[0]
 	rows := make(map[string]row.Row)
+	var oldCard, newCard uint64
+	keyless := false
 	if d.OldValue != nil {
 		sch := rdRd.joiner.SchemaForName(From)
 		if !rdRd.oldRowConv.IdentityConverter {
@@ -90,6 +104,9 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 			return nil, pipeline.ImmutableProperties{}, err
 		}
 
+		oldCard = row.GetCardinality(oldRow)
+		keyless = keyless || schema.IsKeyless(sch)
+
 		rows[From], err = rdRd.oldRowConv.Convert(oldRow)
 
 		if err != nil {
@@ -109,6 +126,9 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 			return nil, pipeline.ImmutableProperties{}, err
 		}
 
+		newCard = row.GetCardinality(newRow)
+		keyless = keyless || schema.IsKeyless(sch)
+
 		rows[To], err = rdRd.newRowConv.Convert(newRow)
 
 		if err != nil {
@@ -116,12 +136,34 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 		}
 	}
 
+	if !keyless {
+		joinedRow, err := rdRd.joiner.Join(rows)
+
+		if err != nil {
+			return nil, pipeline.ImmutableProperties{}, err
+		}
+
+		return joinedRow, pipeline.ImmutableProperties{}, nil
+	}
+
+	count := newCard - oldCard
+	if oldCard > newCard {
+		count = oldCard - newCard
+		delete(rows, To)
+	} else {
+		delete(rows, From)
+	}
+
 	joinedRow, err := rdRd.joiner.Join(rows)
 
 	if err != nil {
 		return nil, pipeline.ImmutableProperties{}, err
 	}
 
+	for i := uint64(1); i < count; i++ {
+		rdRd.pending = append(rdRd.pending, joinedRow)
+	}
+
 	return joinedRow, pipeline.ImmutableProperties{}, nil
 }
 
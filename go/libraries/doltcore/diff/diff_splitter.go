@@ -15,11 +15,15 @@
 package diff
 
 import (
+	"context"
+
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/pipeline"
 	"github.com/dolthub/dolt/go/libraries/utils/valutil"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 const (
@@ -70,14 +74,15 @@ func (dr *DiffRow) DiffType() DiffChType {
 // version, and a column for every field in the new version and split it into two rows with properties which annotate
 // what each row is.  This is used to show diffs as 2 lines, instead of 1.
 type DiffSplitter struct {
+	ctx     context.Context
 	joiner  *rowconv.Joiner
 	oldConv *rowconv.RowConverter
 	newConv *rowconv.RowConverter
 }
 
 // NewDiffSplitter creates a DiffSplitter
-func NewDiffSplitter(joiner *rowconv.Joiner, oldConv, newConv *rowconv.RowConverter) *DiffSplitter {
-	return &DiffSplitter{joiner, oldConv, newConv}
+func NewDiffSplitter(ctx context.Context, joiner *rowconv.Joiner, oldConv, newConv *rowconv.RowConverter) *DiffSplitter {
+	return &DiffSplitter{ctx, joiner, oldConv, newConv}
 }
 
 func convertNamedRow(rows map[string]row.Row, name string, rc *rowconv.RowConverter) (row.Row, error) {
@@ -128,6 +133,13 @@ func (ds *DiffSplitter) SplitDiffIntoOldAndNew(inRow row.Row, props pipeline.Rea
 				if !valutil.NilSafeEqCheck(oldVal, newVal) {
 					newColDiffs[col.Name] = DiffModifiedNew
 					oldColDiffs[col.Name] = DiffModifiedOld
+
+					if col.Kind == types.StringKind {
+						mappedOld, mappedNew, err = ds.showJSONPathDiffs(rows, tag, mappedOld, mappedNew)
+						if err != nil {
+							return true, err
+						}
+					}
 				}
 			} else if inOld {
 				oldColDiffs[col.Name] = DiffRemoved
@@ -157,3 +169,35 @@ func (ds *DiffSplitter) SplitDiffIntoOldAndNew(inRow row.Row, props pipeline.Rea
 
 	return results, ""
 }
+
+// showJSONPathDiffs replaces the values of a modified JSON column in the rows given, which have been converted to
+// strings for display, with the values at each path which changed between the two documents.
+func (ds *DiffSplitter) showJSONPathDiffs(rows map[string]row.Row, tag uint64, mappedOld, mappedNew row.Row) (row.Row, row.Row, error) {
+	fromCol, fromOk := ds.joiner.SchemaForName(From).GetAllCols().GetByTag(tag)
+	toCol, toOk := ds.joiner.SchemaForName(To).GetAllCols().GetByTag(tag)
+	if !fromOk || !toOk || !typeinfo.IsJSONType(fromCol.TypeInfo) || !typeinfo.IsJSONType(toCol.TypeInfo) {
+		return mappedOld, mappedNew, nil
+	}
+
+	fromVal, _ := rows[From].GetColVal(tag)
+	toVal, _ := rows[To].GetColVal(tag)
+	if types.IsNull(fromVal) || types.IsNull(toVal) {
+		return mappedOld, mappedNew, nil
+	}
+
+	diffs, err := DiffJSONValues(ds.ctx, fromCol.TypeInfo, fromVal, toVal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outSch := ds.newConv.DestSch
+	mappedOld, err = mappedOld.SetColVal(tag, types.String(formatJSONPathDiffs(diffs, true)), outSch)
+	if err != nil {
+		return nil, nil, err
+	}
+	mappedNew, err = mappedNew.SetColVal(tag, types.String(formatJSONPathDiffs(diffs, false)), outSch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mappedOld, mappedNew, nil
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 
 	"github.com/dolthub/dolt/go/store/diff"
 	"github.com/dolthub/dolt/go/store/types"
@@ -39,7 +40,16 @@ func Summary(ctx context.Context, ch chan DiffSummaryProgress, from, to types.Ma
 		}
 	}()
 
-	ch <- DiffSummaryProgress{OldSize: from.Len(), NewSize: to.Len()}
+	oldSize, err := rowCount(ctx, from)
+	if err != nil {
+		return err
+	}
+	newSize, err := rowCount(ctx, to)
+	if err != nil {
+		return err
+	}
+
+	ch <- DiffSummaryProgress{OldSize: oldSize, NewSize: newSize}
 
 	hasMore := true
 	var diffs []*diff.Difference
@@ -61,23 +71,53 @@ func Summary(ctx context.Context, ch chan DiffSummaryProgress, from, to types.Ma
 	return nil
 }
 
+// SummaryTotals returns the total of the changes between two values reported by Summary.
+func SummaryTotals(ctx context.Context, from, to types.Map) (DiffSummaryProgress, error) {
+	ch := make(chan DiffSummaryProgress)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		errCh <- Summary(ctx, ch, from, to)
+	}()
+
+	acc := DiffSummaryProgress{}
+	for p := range ch {
+		acc.Adds += p.Adds
+		acc.Removes += p.Removes
+		acc.Changes += p.Changes
+		acc.CellChanges += p.CellChanges
+		acc.NewSize += p.NewSize
+		acc.OldSize += p.OldSize
+	}
+
+	return acc, <-errCh
+}
+
 func reportChanges(ctx context.Context, change *diff.Difference, ch chan<- DiffSummaryProgress) error {
 	var summary DiffSummaryProgress
-	switch change.ChangeType {
-	case types.DiffChangeAdded:
-		summary = DiffSummaryProgress{Adds: 1}
-	case types.DiffChangeRemoved:
-		summary = DiffSummaryProgress{Removes: 1}
-	case types.DiffChangeModified:
-		oldTuple := change.OldValue.(types.Tuple)
-		newTuple := change.NewValue.(types.Tuple)
-		cellChanges, err := row.CountCellDiffs(oldTuple, newTuple)
+	if isKeylessChange(change) {
+		var err error
+		summary, err = keylessChangeSummary(change)
 		if err != nil {
 			return err
 		}
-		summary = DiffSummaryProgress{Changes: 1, CellChanges: cellChanges}
-	default:
-		return errors.New("unknown change type")
+	} else {
+		switch change.ChangeType {
+		case types.DiffChangeAdded:
+			summary = DiffSummaryProgress{Adds: 1}
+		case types.DiffChangeRemoved:
+			summary = DiffSummaryProgress{Removes: 1}
+		case types.DiffChangeModified:
+			oldTuple := change.OldValue.(types.Tuple)
+			newTuple := change.NewValue.(types.Tuple)
+			cellChanges, err := row.CountCellDiffs(oldTuple, newTuple)
+			if err != nil {
+				return err
+			}
+			summary = DiffSummaryProgress{Changes: 1, CellChanges: cellChanges}
+		default:
+			return errors.New("unknown change type")
+		}
 	}
 	select {
 	case ch <- summary:
@@ -86,3 +126,66 @@ func reportChanges(ctx context.Context, change *diff.Difference, ch chan<- DiffS
 		return ctx.Err()
 	}
 }
+
+// rowCount returns the number of rows in the row data given, which for keyless tables is the sum of the cardinalities
+// of its rows.
+func rowCount(ctx context.Context, rowData types.Map) (uint64, error) {
+	if rowData.Len() == 0 {
+		return 0, nil
+	}
+
+	key, _, err := rowData.First(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !isKeylessKey(key) {
+		return rowData.Len(), nil
+	}
+
+	var count uint64
+	err = rowData.IterAll(ctx, func(_, value types.Value) error {
+		card, err := row.ParseCardinality(value.(types.Tuple))
+		count += card
+		return err
+	})
+	return count, err
+}
+
+// isKeylessChange returns whether the change given is to a row of a keyless table.
+func isKeylessChange(change *diff.Difference) bool {
+	return isKeylessKey(change.KeyValue)
+}
+
+// isKeylessKey returns whether the key given belongs to a row of a keyless table, whose keys consist of a row id.
+func isKeylessKey(key types.Value) bool {
+	tpl, ok := key.(types.Tuple)
+	if !ok || tpl.Len() == 0 {
+		return false
+	}
+	tag, err := tpl.Get(0)
+	return err == nil && tag.Equals(types.Uint(schema.KeylessRowIdTag))
+}
+
+// keylessChangeSummary returns the summary of a change to a row of a keyless table. As the rows of keyless tables are
+// identified by their contents, every change to their cardinality is an addition or removal of identical rows.
+func keylessChangeSummary(change *diff.Difference) (DiffSummaryProgress, error) {
+	var oldCard, newCard uint64
+	var err error
+	if change.OldValue != nil {
+		oldCard, err = row.ParseCardinality(change.OldValue.(types.Tuple))
+		if err != nil {
+			return DiffSummaryProgress{}, err
+		}
+	}
+	if change.NewValue != nil {
+		newCard, err = row.ParseCardinality(change.NewValue.(types.Tuple))
+		if err != nil {
+			return DiffSummaryProgress{}, err
+		}
+	}
+
+	if oldCard > newCard {
+		return DiffSummaryProgress{Removes: oldCard - newCard}, nil
+	}
+	return DiffSummaryProgress{Adds: newCard - oldCard}, nil
+}
@@ -184,7 +184,10 @@ type TableDelta struct {
 }
 
 // GetTableDeltas returns a slice of TableDelta objects for each table that changed between fromRoot and toRoot.
-// It matches tables across roots using the tag of the first primary key column in the table's schema.
+// It matches tables across roots using the tag of the first primary key column in the table's schema, or of the first
+// column for keyless tables. Tables that
+// can't be matched this way, but have the same name in both roots, had their primary key changed and are matched by
+// name.
 func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (deltas []TableDelta, err error) {
 	fromTables := make(map[uint64]*doltdb.Table)
 	fromTableNames := make(map[uint64]string)
@@ -202,7 +205,7 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (de
 			return true, err
 		}
 
-		pkTag := sch.GetPKCols().GetColumns()[0].Tag
+		pkTag := tableMatchTag(sch)
 		fromTables[pkTag] = table
 		fromTableNames[pkTag] = name
 		fromTableHashes[pkTag] = th
@@ -218,6 +221,8 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (de
 		return nil, err
 	}
 
+	addedDeltaIdx := make(map[string]int)
+
 	err = toRoot.IterTables(ctx, func(name string, table *doltdb.Table, sch schema.Schema) (stop bool, err error) {
 		th, err := table.HashOf()
 		if err != nil {
@@ -241,10 +246,11 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (de
 			toFksParentSch[toFk.ReferencedTableName] = toRefSch
 		}
 
-		pkTag := sch.GetPKCols().GetColumns()[0].Tag
+		pkTag := tableMatchTag(sch)
 		oldName, ok := fromTableNames[pkTag]
 
 		if !ok {
+			addedDeltaIdx[name] = len(deltas)
 			deltas = append(deltas, TableDelta{
 				ToName:         name,
 				ToTable:        table,
@@ -276,8 +282,15 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (de
 		return nil, err
 	}
 
-	// all unmatched tables in fromRoot must have been dropped
+	// unmatched tables in fromRoot either had their primary key changed or must have been dropped
 	for pkTag, oldName := range fromTableNames {
+		if idx, ok := addedDeltaIdx[oldName]; ok {
+			deltas[idx].FromName = oldName
+			deltas[idx].FromTable = fromTables[pkTag]
+			deltas[idx].FromFks = fromTableFKs[pkTag]
+			continue
+		}
+
 		deltas = append(deltas, TableDelta{
 			FromName:  oldName,
 			FromTable: fromTables[pkTag],
@@ -288,6 +301,14 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (de
 	return deltas, nil
 }
 
+// tableMatchTag returns the tag used to match the table with the schema given across roots.
+func tableMatchTag(sch schema.Schema) uint64 {
+	if schema.IsKeyless(sch) {
+		return sch.GetAllCols().GetByIndex(0).Tag
+	}
+	return sch.GetPKCols().GetByIndex(0).Tag
+}
+
 func GetStagedUnstagedTableDeltas(ctx context.Context, dEnv *env.DoltEnv) (staged, unstaged []TableDelta, err error) {
 	headRoot, err := dEnv.HeadRoot(ctx)
 	if err != nil {
@@ -0,0 +1,209 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// JSONPathDiff is a change to the value at a path within a JSON document. From and To are the encoded values at the
+// path before and after the change, and are nil if the path didn't exist.
+type JSONPathDiff struct {
+	Path string
+	From *string
+	To   *string
+}
+
+// DiffJSONValues returns the path-level differences between two values of a JSON column, ordered by path.
+func DiffJSONValues(ctx context.Context, ti typeinfo.TypeInfo, from, to types.Value) ([]JSONPathDiff, error) {
+	fromDoc, err := unmarshalJSONValue(ctx, ti, from)
+	if err != nil {
+		return nil, err
+	}
+	toDoc, err := unmarshalJSONValue(ctx, ti, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []JSONPathDiff
+	err = diffJSON("$", fromDoc, toDoc, &diffs)
+	if err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+func unmarshalJSONValue(ctx context.Context, ti typeinfo.TypeInfo, v types.Value) (interface{}, error) {
+	str, err := ti.FormatValue(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are compared by their canonical text rather than as floats
+	dec := json.NewDecoder(strings.NewReader(*str))
+	dec.UseNumber()
+
+	var doc interface{}
+	err = dec.Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func diffJSON(path string, from, to interface{}, diffs *[]JSONPathDiff) error {
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+
+	switch fromVal := from.(type) {
+	case map[string]interface{}:
+		if toVal, ok := to.(map[string]interface{}); ok {
+			return diffJSONObjects(path, fromVal, toVal, diffs)
+		}
+	case []interface{}:
+		if toVal, ok := to.([]interface{}); ok {
+			return diffJSONArrays(path, fromVal, toVal, diffs)
+		}
+	}
+
+	return appendJSONDiff(path, from, to, true, true, diffs)
+}
+
+func diffJSONObjects(path string, from, to map[string]interface{}, diffs *[]JSONPathDiff) error {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fromVal, inFrom := from[k]
+		toVal, inTo := to[k]
+
+		var err error
+		if inFrom && inTo {
+			err = diffJSON(path+jsonMemberPath(k), fromVal, toVal, diffs)
+		} else {
+			err = appendJSONDiff(path+jsonMemberPath(k), fromVal, toVal, inFrom, inTo, diffs)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func diffJSONArrays(path string, from, to []interface{}, diffs *[]JSONPathDiff) error {
+	for i := 0; i < len(from) || i < len(to); i++ {
+		elemPath := path + "[" + strconv.Itoa(i) + "]"
+
+		var err error
+		if i < len(from) && i < len(to) {
+			err = diffJSON(elemPath, from[i], to[i], diffs)
+		} else if i < len(from) {
+			err = appendJSONDiff(elemPath, from[i], nil, true, false, diffs)
+		} else {
+			err = appendJSONDiff(elemPath, nil, to[i], false, true, diffs)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func appendJSONDiff(path string, from, to interface{}, inFrom, inTo bool, diffs *[]JSONPathDiff) error {
+	d := JSONPathDiff{Path: path}
+
+	if inFrom {
+		str, err := marshalJSONValue(from)
+		if err != nil {
+			return err
+		}
+		d.From = &str
+	}
+
+	if inTo {
+		str, err := marshalJSONValue(to)
+		if err != nil {
+			return err
+		}
+		d.To = &str
+	}
+
+	*diffs = append(*diffs, d)
+	return nil
+}
+
+func marshalJSONValue(v interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+var jsonIdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// jsonMemberPath returns the path leg selecting the member of an object with the key given, quoting the key if it is
+// not a valid identifier.
+func jsonMemberPath(key string) string {
+	if jsonIdentifierRegex.MatchString(key) {
+		return "." + key
+	}
+
+	quoted, _ := json.Marshal(key)
+	return "." + string(quoted)
+}
+
+// formatJSONPathDiffs returns the changed values at each path on one side of a JSON diff, as shown in tabular diffs.
+// Paths which don't exist on that side are omitted.
+func formatJSONPathDiffs(diffs []JSONPathDiff, from bool) string {
+	var parts []string
+	for _, d := range diffs {
+		val := d.To
+		if from {
+			val = d.From
+		}
+
+		if val != nil {
+			parts = append(parts, d.Path+": "+*val)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+func TestDiffJSONValues(t *testing.T) {
+	str := func(s string) *string {
+		return &s
+	}
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected []JSONPathDiff
+	}{
+		{
+			name:     "reordered members",
+			from:     `{"a": 1, "b": [1, 2]}`,
+			to:       `{"b": [1, 2], "a": 1.0}`,
+			expected: nil,
+		},
+		{
+			name: "nested changes",
+			from: `{"a": {"b": 1, "c": "<x>"}, "d": [1, 2, 3]}`,
+			to:   `{"a": {"b": 2, "c": "<x>", "e f": null}, "d": [1, 3]}`,
+			expected: []JSONPathDiff{
+				{Path: `$.a.b`, From: str(`1`), To: str(`2`)},
+				{Path: `$.a."e f"`, To: str(`null`)},
+				{Path: `$.d[1]`, From: str(`2`), To: str(`3`)},
+				{Path: `$.d[2]`, From: str(`3`)},
+			},
+		},
+		{
+			name: "changed container type",
+			from: `{"a": [1]}`,
+			to:   `{"a": {"0": 1}}`,
+			expected: []JSONPathDiff{
+				{Path: `$.a`, From: str(`[1]`), To: str(`{"0":1}`)},
+			},
+		},
+		{
+			name: "scalar documents",
+			from: `"abc"`,
+			to:   `null`,
+			expected: []JSONPathDiff{
+				{Path: `$`, From: str(`"abc"`), To: str(`null`)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, err := typeinfo.JSONType.ConvertValueToNomsValue(context.Background(), nil, test.from)
+			require.NoError(t, err)
+			to, err := typeinfo.JSONType.ConvertValueToNomsValue(context.Background(), nil, test.to)
+			require.NoError(t, err)
+
+			diffs, err := DiffJSONValues(context.Background(), typeinfo.JSONType, from, to)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, diffs)
+		})
+	}
+}
+
+func TestFormatJSONPathDiffs(t *testing.T) {
+	one, two := "1", "2"
+	diffs := []JSONPathDiff{
+		{Path: "$.a", From: &one, To: &two},
+		{Path: "$.b", From: &one},
+		{Path: "$.c", To: &two},
+	}
+
+	assert.Equal(t, "$.a: 1, $.b: 1", formatJSONPathDiffs(diffs, true))
+	assert.Equal(t, "$.a: 2, $.c: 2", formatJSONPathDiffs(diffs, false))
+}
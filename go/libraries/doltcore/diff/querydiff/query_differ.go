@@ -257,7 +257,7 @@ func makeSqlEngine(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValu
 		sql.WithViewRegistry(sql.NewViewRegistry()))
 	sqlCtx.SetCurrentDatabase("db")
 
-	engine := sqle.NewDefault()
+	engine := dsqle.NewDefaultEngine()
 	engine.AddDatabase(information_schema.NewInformationSchemaDatabase(engine.Catalog))
 
 	dsess := dsqle.DSessFromSess(sqlCtx.Session)
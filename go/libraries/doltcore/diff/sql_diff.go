@@ -15,6 +15,7 @@
 package diff
 
 import (
+	"context"
 	"errors"
 	"io"
 
@@ -27,14 +28,15 @@ import (
 )
 
 type SQLDiffSink struct {
+	ctx       context.Context
 	wr        io.WriteCloser
 	sch       schema.Schema
 	tableName string
 }
 
 // NewSQLDiffSink creates a SQLDiffSink for a diff pipeline.
-func NewSQLDiffSink(wr io.WriteCloser, sch schema.Schema, tableName string) (*SQLDiffSink, error) {
-	return &SQLDiffSink{wr, sch, tableName}, nil
+func NewSQLDiffSink(ctx context.Context, wr io.WriteCloser, sch schema.Schema, tableName string) (*SQLDiffSink, error) {
+	return &SQLDiffSink{ctx, wr, sch, tableName}, nil
 }
 
 // GetSchema gets the schema that the SQLDiffSink was created with.
@@ -77,7 +79,7 @@ func (sds *SQLDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap)
 		if dt, convertedOK := prop.(DiffChType); convertedOK {
 			switch dt {
 			case DiffAdded:
-				stmt, err := sqlfmt.RowAsInsertStmt(r, sds.tableName, sds.sch)
+				stmt, err := sqlfmt.RowAsInsertStmt(sds.ctx, r, sds.tableName, sds.sch)
 
 				if err != nil {
 					return err
@@ -85,7 +87,7 @@ func (sds *SQLDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap)
 
 				return iohelp.WriteLine(sds.wr, stmt)
 			case DiffRemoved:
-				stmt, err := sqlfmt.RowAsDeleteStmt(r, sds.tableName, sds.sch)
+				stmt, err := sqlfmt.RowAsDeleteStmt(sds.ctx, r, sds.tableName, sds.sch)
 
 				if err != nil {
 					return err
@@ -96,7 +98,7 @@ func (sds *SQLDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap)
 				return nil
 			case DiffModifiedNew:
 				// TODO: minimize update statement to modified rows
-				stmt, err := sqlfmt.RowAsUpdateStmt(r, sds.tableName, sds.sch)
+				stmt, err := sqlfmt.RowAsUpdateStmt(sds.ctx, r, sds.tableName, sds.sch)
 
 				if err != nil {
 					return err
@@ -114,7 +116,7 @@ func (sds *SQLDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap)
 
 // ProcRowWithProps satisfies pipeline.SinkFunc; it writes rows as SQL statements.
 func (sds *SQLDiffSink) ProcRowForExport(r row.Row, _ pipeline.ReadableMap) error {
-	stmt, err := sqlfmt.RowAsInsertStmt(r, sds.tableName, sds.sch)
+	stmt, err := sqlfmt.RowAsInsertStmt(sds.ctx, r, sds.tableName, sds.sch)
 
 	if err != nil {
 		return err
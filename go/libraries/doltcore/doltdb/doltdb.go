@@ -356,6 +356,17 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec, cwb ref.DoltRef)
 	return NewCommit(ddb.db, commitSt), nil
 }
 
+// ResolveFromHead resolves the CommitSpec given like Resolve, except that HEAD refers to the commit given rather than to
+// the head of a branch. It's used to resolve specs like HEAD~3 relative to a head that isn't stored in a ref, such as
+// the head of a sql session.
+func (ddb *DoltDB) ResolveFromHead(ctx context.Context, cs *CommitSpec, head *Commit) (*Commit, error) {
+	if cs.csType != headCommitSpec {
+		return ddb.Resolve(ctx, cs, nil)
+	}
+
+	return head.GetAncestor(ctx, cs.aSpec)
+}
+
 // ResolveRef takes a DoltRef and returns a Commit, or an error if the commit cannot be found.
 func (ddb *DoltDB) ResolveRef(ctx context.Context, ref ref.DoltRef) (*Commit, error) {
 	commitSt, err := getCommitStForRefStr(ctx, ddb.db, ref.String())
@@ -452,6 +463,11 @@ func (ddb *DoltDB) Commit(ctx context.Context, valHash hash.Hash, dref ref.DoltR
 	return ddb.CommitWithParentSpecs(ctx, valHash, dref, nil, cm)
 }
 
+// Rebase brings this DoltDB's view of the underlying database up to date with changes made by other clients.
+func (ddb *DoltDB) Rebase(ctx context.Context) error {
+	return ddb.db.Rebase(ctx)
+}
+
 // FastForward fast-forwards the branch given to the commit given.
 func (ddb *DoltDB) FastForward(ctx context.Context, branch ref.DoltRef, commit *Commit) error {
 	ds, err := ddb.db.GetDataset(ctx, branch.String())
@@ -112,16 +112,23 @@ func (fk ForeignKey) HashOf() hash.Hash {
 }
 
 // ConstraintIsSatisfied ensures that the foreign key is valid by comparing the index data from the given table against the index
-// data from the referenced table.
-func (fk ForeignKey) ConstraintIsSatisfied(ctx context.Context, childIdx, parentIdx types.Map, childDef, parentDef schema.Index) error {
+// data from the referenced table. The row data of the given table is read when its index represents a column by
+// collation keys while the referenced index doesn't.
+func (fk ForeignKey) ConstraintIsSatisfied(ctx context.Context, vrw types.ValueReadWriter, childRowData, childIdx, parentIdx types.Map, childDef, parentDef schema.Index) error {
 	if fk.ReferencedTableIndex != parentDef.Name() {
 		return fmt.Errorf("cannot validate data as wrong referenced index was given: expected `%s` but received `%s`",
 			fk.ReferencedTableIndex, parentDef.Name())
 	}
 
 	tagMap := make(map[uint64]uint64, len(fk.TableColumns))
+	readChildRows := false
 	for i, childTag := range fk.TableColumns {
 		tagMap[childTag] = fk.ReferencedTableColumns[i]
+		childCol, _ := childDef.GetColumn(childTag)
+		parentCol, _ := parentDef.GetColumn(fk.ReferencedTableColumns[i])
+		if schema.UsesCollationKeys(childCol) != schema.UsesCollationKeys(parentCol) {
+			readChildRows = true
+		}
 	}
 
 	// FieldMappings ignore columns not in the tagMap
@@ -130,7 +137,7 @@ func (fk ForeignKey) ConstraintIsSatisfied(ctx context.Context, childIdx, parent
 		return err
 	}
 
-	rc, err := rowconv.NewRowConverter(fm)
+	rc, err := rowconv.NewRowConverter(ctx, vrw, fm)
 	if err != nil {
 		return err
 	}
@@ -149,17 +156,29 @@ func (fk ForeignKey) ConstraintIsSatisfied(ctx context.Context, childIdx, parent
 			return err
 		}
 
-		parentIdxRow, err := rc.Convert(childIdxRow)
-		if err != nil {
-			return err
-		}
-		if row.IsEmpty(parentIdxRow) {
-			continue
-		}
+		var partial types.Tuple
+		if readChildRows {
+			var hasNulls bool
+			partial, hasNulls, err = fk.parentKeyFromChildRow(ctx, vrw.Format(), childRowData, childIdxRow, childDef, parentDef)
+			if err != nil {
+				return err
+			}
+			if hasNulls {
+				continue
+			}
+		} else {
+			parentIdxRow, err := rc.Convert(childIdxRow)
+			if err != nil {
+				return err
+			}
+			if row.IsEmpty(parentIdxRow) {
+				continue
+			}
 
-		partial, err := parentIdxRow.ReduceToIndexPartialKey(parentDef)
-		if err != nil {
-			return err
+			partial, err = parentIdxRow.ReduceToIndexPartialKey(parentDef)
+			if err != nil {
+				return err
+			}
 		}
 
 		indexIter := noms.NewNomsRangeReader(parentDef.Schema(), parentIdx,
@@ -182,6 +201,56 @@ func (fk ForeignKey) ConstraintIsSatisfied(ctx context.Context, childIdx, parent
 	return nil
 }
 
+// parentKeyFromChildRow returns the partial key of the referenced index that is referenced by the given row of the
+// table's index. The values are read from the table's row, as the index row holds collation keys in place of some of
+// them. If the key contains any nulls, then we return true to indicate that the row doesn't reference anything.
+func (fk ForeignKey) parentKeyFromChildRow(ctx context.Context, nbf *types.NomsBinFormat, childRowData types.Map, childIdxRow row.Row, childDef, parentDef schema.Index) (types.Tuple, bool, error) {
+	idxVals, err := row.GetTaggedVals(childIdxRow)
+	if err != nil {
+		return types.EmptyTuple(nbf), false, err
+	}
+
+	var pkVals []types.Value
+	for _, tag := range childDef.PrimaryKeyTags() {
+		pkVals = append(pkVals, types.Uint(tag), idxVals[tag])
+	}
+	pk, err := types.NewTuple(nbf, pkVals...)
+	if err != nil {
+		return types.EmptyTuple(nbf), false, err
+	}
+	rowVal, ok, err := childRowData.MaybeGet(ctx, pk)
+	if err != nil {
+		return types.EmptyTuple(nbf), false, err
+	}
+	if !ok {
+		return types.EmptyTuple(nbf), false, fmt.Errorf("index `%s` on `%s` references a missing row", childDef.Name(), fk.TableName)
+	}
+	rowVals, err := row.ParseTaggedValues(rowVal.(types.Tuple))
+	if err != nil {
+		return types.EmptyTuple(nbf), false, err
+	}
+
+	keyVals := make([]types.Value, len(fk.TableColumns)*2)
+	for i, childTag := range fk.TableColumns {
+		val, ok := idxVals[childTag]
+		if childCol, _ := childDef.GetColumn(childTag); schema.UsesCollationKeys(childCol) {
+			val, ok = rowVals[childTag]
+		}
+		if !ok || types.IsNull(val) {
+			return types.EmptyTuple(nbf), true, nil
+		}
+		parentTag := fk.ReferencedTableColumns[i]
+		parentCol, _ := parentDef.GetColumn(parentTag)
+		keyVals[2*i] = types.Uint(parentTag)
+		keyVals[2*i+1] = schema.IndexKeyValue(parentCol, val)
+	}
+	tpl, err := types.NewTuple(nbf, keyVals...)
+	if err != nil {
+		return types.EmptyTuple(nbf), false, err
+	}
+	return tpl, false, nil
+}
+
 // ValidateReferencedTableSchema verifies that the given schema matches the expectation of the referenced table.
 func (fk ForeignKey) ValidateReferencedTableSchema(sch schema.Schema) error {
 	allSchCols := sch.GetAllCols()
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -134,7 +135,7 @@ func (ste *SessionedTableEditor) handleReferencingRowsOnDelete(ctx context.Conte
 		if !ok {
 			return fmt.Errorf("unable to get table editor as `%s` is missing", foreignKey.TableName)
 		}
-		indexKey, hasNulls, err := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.ReferencedTableColumns, foreignKey.TableColumns, dRow)
+		indexKey, hasNulls, err := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.ReferencedTableColumns, foreignKey.TableColumns, referencingSte.tableEditor.tSch, dRow)
 		if err != nil {
 			return err
 		}
@@ -172,7 +173,8 @@ func (ste *SessionedTableEditor) handleReferencingRowsOnDelete(ctx context.Conte
 				}
 			}
 		case ForeignKeyReferenceOption_DefaultAction, ForeignKeyReferenceOption_NoAction, ForeignKeyReferenceOption_Restrict:
-			indexKeyStr, _ := types.EncodedValue(ctx, indexKey)
+			rawKey, _, _ := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.ReferencedTableColumns, foreignKey.TableColumns, nil, dRow)
+			indexKeyStr, _ := types.EncodedValue(ctx, rawKey)
 			return fmt.Errorf("foreign key constraint violation on `%s`.`%s`: cannot delete rows with value `%s`",
 				foreignKey.TableName, foreignKey.Name, indexKeyStr)
 		default:
@@ -193,7 +195,7 @@ func (ste *SessionedTableEditor) handleReferencingRowsOnUpdate(ctx context.Conte
 		if !ok {
 			return fmt.Errorf("unable to get table editor as `%s` is missing", foreignKey.TableName)
 		}
-		indexKey, hasNulls, err := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.ReferencedTableColumns, foreignKey.TableColumns, dOldRow)
+		indexKey, hasNulls, err := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.ReferencedTableColumns, foreignKey.TableColumns, referencingSte.tableEditor.tSch, dOldRow)
 		if err != nil {
 			return err
 		}
@@ -266,7 +268,8 @@ func (ste *SessionedTableEditor) handleReferencingRowsOnUpdate(ctx context.Conte
 				}
 			}
 		case ForeignKeyReferenceOption_DefaultAction, ForeignKeyReferenceOption_NoAction, ForeignKeyReferenceOption_Restrict:
-			indexKeyStr, _ := types.EncodedValue(ctx, indexKey)
+			rawKey, _, _ := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.ReferencedTableColumns, foreignKey.TableColumns, nil, dOldRow)
+			indexKeyStr, _ := types.EncodedValue(ctx, rawKey)
 			return fmt.Errorf("foreign key constraint violation on `%s`.`%s`: cannot update rows with value `%s`",
 				foreignKey.TableName, foreignKey.Name, indexKeyStr)
 		default:
@@ -279,9 +282,10 @@ func (ste *SessionedTableEditor) handleReferencingRowsOnUpdate(ctx context.Conte
 
 // reduceRowAndConvert takes in a row and returns a Tuple containing only the values from the tags given. The returned
 // items have tags from newTags, while the tags from dRow are expected to match originalTags. Both parameter slices are
-// assumed to have equivalent ordering and length. If the key contains any nulls, then we return true to indicate that
-// we do not propagate an ON DELETE/UPDATE.
-func (ste *SessionedTableEditor) reduceRowAndConvert(nbf *types.NomsBinFormat, originalTags []uint64, newTags []uint64, dRow row.Row) (types.Tuple, bool, error) {
+// assumed to have equivalent ordering and length. The values are encoded as they are in the index keys of newSch, the
+// schema of the table that newTags belong to, unless newSch is nil. If the key contains any nulls, then we return true
+// to indicate that we do not propagate an ON DELETE/UPDATE.
+func (ste *SessionedTableEditor) reduceRowAndConvert(nbf *types.NomsBinFormat, originalTags []uint64, newTags []uint64, newSch schema.Schema, dRow row.Row) (types.Tuple, bool, error) {
 	keyVals := make([]types.Value, len(originalTags)*2)
 	for i, colTag := range originalTags {
 		val, ok := dRow.GetColVal(colTag)
@@ -290,6 +294,11 @@ func (ste *SessionedTableEditor) reduceRowAndConvert(nbf *types.NomsBinFormat, o
 		}
 		newTag := newTags[i]
 		keyVals[2*i] = types.Uint(newTag)
+		if newSch != nil {
+			if col, ok := newSch.GetAllCols().GetByTag(newTag); ok {
+				val = schema.IndexKeyValue(col, val)
+			}
+		}
 		keyVals[2*i+1] = val
 	}
 	tpl, err := types.NewTuple(nbf, keyVals...)
@@ -321,23 +330,24 @@ func (ste *SessionedTableEditor) validateForInsert(ctx context.Context, dRow row
 		return nil
 	}
 	for _, foreignKey := range ste.referencedTables {
-		indexKey, hasNulls, err := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.TableColumns, foreignKey.ReferencedTableColumns, dRow)
+		referencingSte, ok := ste.tableEditSession.tables[foreignKey.ReferencedTableName]
+		if !ok {
+			return fmt.Errorf("unable to get table editor as `%s` is missing", foreignKey.ReferencedTableName)
+		}
+		indexKey, hasNulls, err := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.TableColumns, foreignKey.ReferencedTableColumns, referencingSte.tableEditor.tSch, dRow)
 		if err != nil {
 			return err
 		}
 		if hasNulls {
 			continue
 		}
-		referencingSte, ok := ste.tableEditSession.tables[foreignKey.ReferencedTableName]
-		if !ok {
-			return fmt.Errorf("unable to get table editor as `%s` is missing", foreignKey.ReferencedTableName)
-		}
 		exists, err := referencingSte.tableEditor.ContainsIndexedKey(ctx, indexKey, foreignKey.ReferencedTableIndex)
 		if err != nil {
 			return err
 		}
 		if !exists {
-			indexKeyStr, _ := types.EncodedValue(ctx, indexKey)
+			rawKey, _, _ := ste.reduceRowAndConvert(ste.tableEditor.nbf, foreignKey.TableColumns, foreignKey.ReferencedTableColumns, nil, dRow)
+			indexKeyStr, _ := types.EncodedValue(ctx, rawKey)
 			return fmt.Errorf("foreign key violation on `%s`.`%s`: `%s`", foreignKey.TableName, foreignKey.Name, indexKeyStr)
 		}
 	}
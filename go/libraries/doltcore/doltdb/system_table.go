@@ -138,6 +138,13 @@ var generatedSystemTables = []string{
 	TableOfTablesInConflictName,
 	CommitsTableName,
 	CommitAncestorsTableName,
+	ReplicationStatusTableName,
+	StatusTableName,
+	DiffSummaryTableName,
+	SchemaHistoryTableName,
+	SchemaDiffTableName,
+	ConstraintViolationsTableName,
+	RemoteUpdatesTableName,
 }
 
 var generatedSystemTablePrefixes = []string{
@@ -273,4 +280,26 @@ const (
 
 	// CommitAncestorsTableName is the commit_ancestors system table name
 	CommitAncestorsTableName = "dolt_commit_ancestors"
+
+	// ReplicationStatusTableName is the replication status system table name
+	ReplicationStatusTableName = "dolt_replication_status"
+
+	// StatusTableName is the status system table name
+	StatusTableName = "dolt_status"
+
+	// DiffSummaryTableName is the diff summary system table name. It shares the prefix of the dolt_diff_ tables, and
+	// takes precedence over the diff table of a table named summary.
+	DiffSummaryTableName = "dolt_diff_summary"
+
+	// SchemaHistoryTableName is the schema history system table name
+	SchemaHistoryTableName = "dolt_schema_history"
+
+	// SchemaDiffTableName is the schema diff system table name
+	SchemaDiffTableName = "dolt_schema_diff"
+
+	// ConstraintViolationsTableName is the constraint violations system table name
+	ConstraintViolationsTableName = "dolt_constraint_violations"
+
+	// RemoteUpdatesTableName is the remote updates system table name
+	RemoteUpdatesTableName = "dolt_remote_updates"
 )
@@ -37,10 +37,14 @@ var ErrDuplicatePrimaryKeyFmt = "duplicate primary key given: %v"
 // TableEditor supports making multiple row edits (inserts, updates, deletes) to a table. It does error checking for key
 // collision etc. in the Close() method, as well as during Insert / Update.
 //
+// Rows of keyless tables are never checked for collisions. Instead, inserting a row increments the cardinality of any
+// identical row by the cardinality of the row inserted, and deleting a row decrements it.
+//
 // This type is thread-safe, and may be used in a multi-threaded environment.
 type TableEditor struct {
 	t        *Table
 	tSch     schema.Schema
+	keyless  bool
 	tea      *tableEditAccumulator
 	aq       *async.ActionExecutor
 	nbf      *types.NomsBinFormat
@@ -65,6 +69,16 @@ type tableEditAccumulator struct {
 	addedKeys    map[hash.Hash]types.Value
 	removedKeys  map[hash.Hash]types.Value
 	affectedKeys map[hash.Hash]types.Value
+	// keylessDeltas holds the pending changes to the cardinality of the rows of a keyless table
+	keylessDeltas map[hash.Hash]*keylessDelta
+}
+
+// keylessDelta is a pending change to the cardinality of a row of a keyless table.
+type keylessDelta struct {
+	key types.Tuple
+	// r is the most recently written row with the key, or nil if the row has only been deleted
+	r     row.Row
+	delta int64
 }
 
 const tableEditorMaxOps = 16384
@@ -73,6 +87,7 @@ func NewTableEditor(ctx context.Context, t *Table, tableSch schema.Schema) (*Tab
 	te := &TableEditor{
 		t:          t,
 		tSch:       tableSch,
+		keyless:    schema.IsKeyless(tableSch),
 		tea:        newTableEditAcc(t.Format()),
 		nbf:        t.Format(),
 		indexEds:   make([]*IndexEditor, tableSch.Indexes().Count()),
@@ -120,7 +135,23 @@ func newTableEditAcc(nbf *types.NomsBinFormat) *tableEditAccumulator {
 		addedKeys:    make(map[hash.Hash]types.Value),
 		removedKeys:  make(map[hash.Hash]types.Value),
 		affectedKeys: make(map[hash.Hash]types.Value),
+
+		keylessDeltas: make(map[hash.Hash]*keylessDelta),
+	}
+}
+
+// addKeylessDelta records a change to the cardinality of the keyless row with the key given.
+func (tea *tableEditAccumulator) addKeylessDelta(keyHash hash.Hash, key types.Tuple, r row.Row, delta int64) {
+	kd, ok := tea.keylessDeltas[keyHash]
+	if !ok {
+		kd = &keylessDelta{key: key}
+		tea.keylessDeltas[keyHash] = kd
+	}
+	if r != nil {
+		kd.r = r
 	}
+	kd.delta += delta
+	tea.opCount++
 }
 
 // Close ensures that all goroutines that may be open are properly disposed of. Attempting to call any other function
@@ -281,6 +312,11 @@ func (te *TableEditor) InsertRow(ctx context.Context, dRow row.Row) error {
 	te.writeMutex.Lock()
 	defer te.writeMutex.Unlock()
 
+	if te.keyless {
+		te.tea.addKeylessDelta(keyHash, key.(types.Tuple), dRow, int64(row.GetCardinality(dRow)))
+		return nil
+	}
+
 	// If we've already inserted this key as part of this insert operation, that's an error. Inserting a row that
 	// already exists in the table will be handled in Close().
 	if _, ok := te.tea.addedKeys[keyHash]; ok {
@@ -314,7 +350,8 @@ func (te *TableEditor) InsertRow(ctx context.Context, dRow row.Row) error {
 	return nil
 }
 
-// DeleteKey removes the given key from the table.
+// DeleteKey removes the given key from the table. For keyless tables, only one of the identical rows with the key is
+// removed.
 func (te *TableEditor) DeleteKey(ctx context.Context, key types.Tuple) error {
 	defer te.autoFlush()
 	te.flushMutex.RLock()
@@ -335,6 +372,19 @@ func (te *TableEditor) DeleteRow(ctx context.Context, dRow row.Row) error {
 		return errhand.BuildDError("failed to get row key").AddCause(err).Build()
 	}
 
+	if te.keyless {
+		keyHash, err := key.Hash(te.nbf)
+		if err != nil {
+			return err
+		}
+
+		te.writeMutex.Lock()
+		defer te.writeMutex.Unlock()
+
+		te.tea.addKeylessDelta(keyHash, key.(types.Tuple), nil, -int64(row.GetCardinality(dRow)))
+		return nil
+	}
+
 	return te.delete(key.(types.Tuple))
 }
 
@@ -365,6 +415,16 @@ func (te *TableEditor) UpdateRow(ctx context.Context, dOldRow row.Row, dNewRow r
 	te.writeMutex.Lock()
 	defer te.writeMutex.Unlock()
 
+	if te.keyless {
+		oldHash, err := dOldKeyVal.Hash(dOldRow.Format())
+		if err != nil {
+			return err
+		}
+		te.tea.addKeylessDelta(oldHash, dOldKeyVal.(types.Tuple), nil, -int64(row.GetCardinality(dOldRow)))
+		te.tea.addKeylessDelta(newHash, dNewKeyVal.(types.Tuple), dNewRow, int64(row.GetCardinality(dNewRow)))
+		return nil
+	}
+
 	// If the PK is changed then we need to delete the old value and insert the new one
 	if !oldKeyEqualsNewKey {
 		oldHash, err := dOldKeyVal.Hash(dOldRow.Format())
@@ -450,6 +510,11 @@ func (te *TableEditor) delete(key types.Tuple) error {
 	te.writeMutex.Lock()
 	defer te.writeMutex.Unlock()
 
+	if te.keyless {
+		te.tea.addKeylessDelta(keyHash, key, nil, -1)
+		return nil
+	}
+
 	delete(te.tea.addedKeys, keyHash)
 	te.tea.removedKeys[keyHash] = key
 	te.tea.affectedKeys[keyHash] = key
@@ -486,6 +551,10 @@ func (te *TableEditor) flushEditAccumulator(ctx context.Context, teaInterface in
 			tea.ed.AddEdit(removedKey, nil)
 		}
 	}
+	err := te.applyKeylessDeltas(ctx, tea)
+	if err != nil {
+		return err
+	}
 
 	accEdits, err := tea.ed.FinishedEditing()
 	if err != nil {
@@ -512,6 +581,48 @@ func (te *TableEditor) flushEditAccumulator(ctx context.Context, teaInterface in
 	tea.ed = nil
 	tea.insertedKeys = nil
 	tea.removedKeys = nil
+	tea.keylessDeltas = nil
+	return nil
+}
+
+// applyKeylessDeltas adds an edit for every row of a keyless table whose cardinality was changed, removing the rows
+// whose cardinality drops to zero.
+func (te *TableEditor) applyKeylessDeltas(ctx context.Context, tea *tableEditAccumulator) error {
+	for _, kd := range tea.keylessDeltas {
+		if kd.delta == 0 {
+			continue
+		}
+
+		var card int64
+		r := kd.r
+		existing, ok, err := te.rowData.MaybeGet(ctx, kd.key)
+		if err != nil {
+			return errhand.BuildDError("failed to read table").AddCause(err).Build()
+		}
+		if ok {
+			existingCard, err := row.ParseCardinality(existing.(types.Tuple))
+			if err != nil {
+				return err
+			}
+			card = int64(existingCard)
+			if r == nil {
+				r, err = row.FromNoms(te.tSch, kd.key, existing.(types.Tuple))
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		card += kd.delta
+		if card <= 0 {
+			if ok {
+				tea.ed.AddEdit(kd.key, nil)
+			}
+			continue
+		}
+
+		tea.ed.AddEdit(kd.key, row.WithCardinality(r, uint64(card)).NomsMapValue(te.tSch))
+	}
 	return nil
 }
 
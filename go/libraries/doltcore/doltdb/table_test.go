@@ -201,7 +201,8 @@ func TestIndexRebuildingWithOneIndex(t *testing.T) {
 		for _, tag := range index.AllTags() {
 			val, ok := r.GetColVal(tag)
 			require.True(t, ok)
-			indexKey[tag] = val
+			col, _ := index.GetColumn(tag)
+			indexKey[tag] = schema.IndexKeyValue(col, val)
 		}
 		indexExpectedRows[i], err = row.New(types.Format_7_18, indexSch, indexKey)
 		require.NoError(t, err)
@@ -532,7 +533,8 @@ func rowsToIndexRows(t *testing.T, rows []row.Row, indexName schema.Index, index
 		for _, tag := range indexName.AllTags() {
 			val, ok := r.GetColVal(tag)
 			require.True(t, ok)
-			indexNameKey[tag] = val
+			col, _ := indexName.GetColumn(tag)
+			indexNameKey[tag] = schema.IndexKeyValue(col, val)
 		}
 		indexNameExpectedRows[i], err = row.New(types.Format_7_18, indexNameSch, indexNameKey)
 		require.NoError(t, err)
@@ -541,7 +543,8 @@ func rowsToIndexRows(t *testing.T, rows []row.Row, indexName schema.Index, index
 		for _, tag := range indexAge.AllTags() {
 			val, ok := r.GetColVal(tag)
 			require.True(t, ok)
-			indexAgeKey[tag] = val
+			col, _ := indexAge.GetColumn(tag)
+			indexAgeKey[tag] = schema.IndexKeyValue(col, val)
 		}
 		indexAgeExpectedRows[i], err = row.New(types.Format_7_18, indexAgeSch, indexAgeKey)
 		require.NoError(t, err)
@@ -17,15 +17,24 @@ package actions
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 var ErrCantFF = errors.New("can't fast forward merge")
 
+// RefUpdate describes a ref moved by a fetch or a push. OldHash is empty if the ref was created.
+type RefUpdate struct {
+	Ref     ref.DoltRef
+	OldHash hash.Hash
+	NewHash hash.Hash
+}
+
 // Push will update a destination branch, in a given destination database if it can be done as a fast forward merge.
 // This is accomplished first by verifying that the remote tracking reference for the source database can be updated to
 // the given commit via a fast forward merge.  If this is the case, an attempt will be made to update the branch in the
@@ -73,6 +82,16 @@ func Push(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, destRe
 	return err
 }
 
+// PushToRemoteBranch pushes the commit given, along with all underlying data, from the local database to the branch
+// given in the remote database, and updates the remote tracking ref given. Progress is not reported.
+func PushToRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, destRef ref.BranchRef, remoteRef ref.RemoteRef, destDB *doltdb.DoltDB, commit *doltdb.Commit) error {
+	progChan, pullerEventCh, stop := discardProgress()
+	err := Push(ctx, dEnv, mode, destRef, remoteRef, dEnv.DoltDB, destDB, commit, progChan, pullerEventCh)
+	stop()
+
+	return err
+}
+
 // PushTag pushes a commit tag and all underlying data from a local source database to a remote destination database.
 func PushTag(ctx context.Context, dEnv *env.DoltEnv, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	var err error
@@ -140,6 +159,161 @@ func FetchTag(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.Dolt
 	return destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, stRef, progChan, pullerEventCh)
 }
 
+// FetchRemoteBranch fetches the commit at the head of the branch given in the remote database, along with all
+// underlying data, into the local database and fast-forwards the remote tracking ref given to it. Progress is not
+// reported. Returns the fetched commit.
+func FetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, srcDB *doltdb.DoltDB, srcRef ref.BranchRef, remoteRef ref.RemoteRef) (*doltdb.Commit, error) {
+	srcDBCommit, err := srcDB.ResolveRef(ctx, srcRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	progChan, pullerEventCh, stop := discardProgress()
+	err = FetchCommit(ctx, dEnv, srcDB, dEnv.DoltDB, srcDBCommit, progChan, pullerEventCh)
+	stop()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dEnv.DoltDB.FastForward(ctx, remoteRef, srcDBCommit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return srcDBCommit, nil
+}
+
+// FetchRefSpecs fetches the head of every branch in the remote database matching the refspecs given, along with all
+// underlying data, into the local database and updates the remote tracking refs the refspecs map them to. Progress is
+// not reported. Returns the remote tracking refs which were updated.
+func FetchRefSpecs(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec) ([]RefUpdate, error) {
+	branchRefs, err := srcDB.GetBranches(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []RefUpdate
+	for _, rs := range refSpecs {
+		for _, branchRef := range branchRefs {
+			remoteTrackRef := rs.DestRef(branchRef)
+
+			if remoteTrackRef == nil {
+				continue
+			}
+
+			update, err := fetchToRef(ctx, dEnv, mode, srcDB, branchRef, remoteTrackRef)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if update.OldHash != update.NewHash {
+				updates = append(updates, update)
+			}
+		}
+	}
+
+	return updates, nil
+}
+
+func fetchToRef(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, srcDB *doltdb.DoltDB, srcRef, destRef ref.DoltRef) (RefUpdate, error) {
+	update := RefUpdate{Ref: destRef}
+
+	srcDBCommit, err := srcDB.ResolveRef(ctx, srcRef)
+
+	if err != nil {
+		return RefUpdate{}, err
+	}
+
+	update.NewHash, err = srcDBCommit.HashOf()
+
+	if err != nil {
+		return RefUpdate{}, err
+	}
+
+	hasRef, err := dEnv.DoltDB.HasRef(ctx, destRef)
+
+	if err != nil {
+		return RefUpdate{}, err
+	}
+
+	if hasRef {
+		oldCommit, err := dEnv.DoltDB.ResolveRef(ctx, destRef)
+
+		if err != nil {
+			return RefUpdate{}, err
+		}
+
+		update.OldHash, err = oldCommit.HashOf()
+
+		if err != nil {
+			return RefUpdate{}, err
+		}
+
+		if update.OldHash == update.NewHash {
+			return update, nil
+		}
+	}
+
+	progChan, pullerEventCh, stop := discardProgress()
+	err = FetchCommit(ctx, dEnv, srcDB, dEnv.DoltDB, srcDBCommit, progChan, pullerEventCh)
+	stop()
+
+	if err != nil {
+		return RefUpdate{}, err
+	}
+
+	switch mode {
+	case ref.ForceUpdate:
+		err = dEnv.DoltDB.SetHeadToCommit(ctx, destRef, srcDBCommit)
+	case ref.FastForwardOnly:
+		var canFF bool
+		canFF, err = dEnv.DoltDB.CanFastForward(ctx, destRef, srcDBCommit)
+
+		if err == nil && !canFF {
+			return RefUpdate{}, ErrCantFF
+		} else if err == nil {
+			err = dEnv.DoltDB.FastForward(ctx, destRef, srcDBCommit)
+		}
+	}
+
+	if err != nil {
+		return RefUpdate{}, err
+	}
+
+	return update, nil
+}
+
+// discardProgress returns progress channels for use with pulls and pushes whose progress is not reported, and a
+// function which must be called once the operation using them is complete.
+func discardProgress() (chan datas.PullProgress, chan datas.PullerEvent, func()) {
+	progChan := make(chan datas.PullProgress, 128)
+	pullerEventCh := make(chan datas.PullerEvent, 128)
+	wg := &sync.WaitGroup{}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range progChan {
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range pullerEventCh {
+		}
+	}()
+
+	return progChan, pullerEventCh, func() {
+		close(progChan)
+		close(pullerEventCh)
+		wg.Wait()
+	}
+}
+
 // Clone pulls all data from a remote source database to a local destination database.
 func Clone(ctx context.Context, srcDB, destDB *doltdb.DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return srcDB.Clone(ctx, destDB, eventCh)
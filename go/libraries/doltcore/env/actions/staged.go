@@ -94,17 +94,7 @@ func StageAllTables(ctx context.Context, dEnv *env.DoltEnv) error {
 }
 
 func stageTables(ctx context.Context, dEnv *env.DoltEnv, tbls []string, staged *doltdb.RootValue, working *doltdb.RootValue) error {
-	err := ValidateTables(ctx, tbls, staged, working)
-	if err != nil {
-		return err
-	}
-
-	working, err = checkTablesForConflicts(ctx, tbls, working)
-	if err != nil {
-		return err
-	}
-
-	staged, err = MoveTablesBetweenRoots(ctx, tbls, working, staged)
+	staged, working, err := StageTablesInRoots(ctx, tbls, staged, working)
 	if err != nil {
 		return err
 	}
@@ -125,6 +115,28 @@ func stageTables(ctx context.Context, dEnv *env.DoltEnv, tbls []string, staged *
 	return doltdb.ErrNomsIO
 }
 
+// StageTablesInRoots copies the tables given from the working root to the staged root, and returns the new staged and
+// working roots. Tables with unresolved conflicts can't be staged, and have their conflicts cleared from the working
+// root once they are resolved.
+func StageTablesInRoots(ctx context.Context, tbls []string, staged, working *doltdb.RootValue) (*doltdb.RootValue, *doltdb.RootValue, error) {
+	err := ValidateTables(ctx, tbls, staged, working)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	working, err = checkTablesForConflicts(ctx, tbls, working)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	staged, err = MoveTablesBetweenRoots(ctx, tbls, working, staged)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return staged, working, nil
+}
+
 func checkTablesForConflicts(ctx context.Context, tbls []string, working *doltdb.RootValue) (*doltdb.RootValue, error) {
 	var inConflict []string
 	for _, tblName := range tbls {
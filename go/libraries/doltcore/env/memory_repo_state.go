@@ -0,0 +1,86 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var _ RepoStateReader = &MemoryRepoState{}
+var _ RepoStateWriter = &MemoryRepoState{}
+
+// MemoryRepoState is a RepoStateReader and RepoStateWriter whose working and staged hashes are kept in memory rather
+// than persisted to the repo state file. It is used to serve a working set for a ref other than the one checked out
+// in the repository, such as a branch or commit requested by a sql-server client, or the head of a branch followed by
+// a read replica.
+type MemoryRepoState struct {
+	mu      *sync.RWMutex
+	head    ref.DoltRef
+	spec    *doltdb.CommitSpec
+	working hash.Hash
+	staged  hash.Hash
+}
+
+// NewMemoryRepoState returns a MemoryRepoState whose head is the ref and commit spec given, and whose working and
+// staged hashes are both initialized to the root hash given.
+func NewMemoryRepoState(head ref.DoltRef, spec *doltdb.CommitSpec, rootHash hash.Hash) *MemoryRepoState {
+	return &MemoryRepoState{
+		mu:      &sync.RWMutex{},
+		head:    head,
+		spec:    spec,
+		working: rootHash,
+		staged:  rootHash,
+	}
+}
+
+// CWBHeadRef implements RepoStateReader.
+func (m *MemoryRepoState) CWBHeadRef() ref.DoltRef {
+	return m.head
+}
+
+// CWBHeadSpec implements RepoStateReader.
+func (m *MemoryRepoState) CWBHeadSpec() *doltdb.CommitSpec {
+	return m.spec
+}
+
+// WorkingHash implements RepoStateReader.
+func (m *MemoryRepoState) WorkingHash() hash.Hash {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.working
+}
+
+// StagedHash implements RepoStateReader.
+func (m *MemoryRepoState) StagedHash() hash.Hash {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.staged
+}
+
+// SetWorkingHash implements RepoStateWriter.
+func (m *MemoryRepoState) SetWorkingHash(ctx context.Context, h hash.Hash) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.working = h
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const geoJSONCRSPrefix = "EPSG:"
+
+// ToGeoJSON returns the GeoJSON object of a geometry, in the form produced by encoding/json. A geometry with an SRID
+// other than 0 names its spatial reference system in a "crs" member.
+func ToGeoJSON(g Geometry) map[string]interface{} {
+	obj := make(map[string]interface{})
+	switch g := g.(type) {
+	case Point:
+		obj["type"] = "Point"
+		obj["coordinates"] = geoJSONPosition(g)
+	case LineString:
+		obj["type"] = "LineString"
+		obj["coordinates"] = geoJSONPositions(g.Points)
+	case Polygon:
+		obj["type"] = "Polygon"
+		rings := make([]interface{}, len(g.Rings))
+		for i, ring := range g.Rings {
+			rings[i] = geoJSONPositions(ring.Points)
+		}
+		obj["coordinates"] = rings
+	}
+	if g.GetSRID() != 0 {
+		obj["crs"] = map[string]interface{}{
+			"type":       "name",
+			"properties": map[string]interface{}{"name": geoJSONCRSPrefix + strconv.FormatUint(uint64(g.GetSRID()), 10)},
+		}
+	}
+	return obj
+}
+
+// FromGeoJSON returns the geometry of the GeoJSON object given, in the form produced by encoding/json.
+func FromGeoJSON(obj map[string]interface{}) (Geometry, error) {
+	srid, err := geoJSONSRID(obj["crs"])
+	if err != nil {
+		return nil, err
+	}
+
+	coords := obj["coordinates"]
+	var g Geometry
+	switch obj["type"] {
+	case "Point":
+		p, err := fromGeoJSONPosition(coords)
+		if err != nil {
+			return nil, err
+		}
+		p.SRID = srid
+		g = p
+	case "LineString":
+		points, err := fromGeoJSONPositions(coords)
+		if err != nil {
+			return nil, err
+		}
+		g = LineString{SRID: srid, Points: points}
+	case "Polygon":
+		arr, ok := coords.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: polygon coordinates must be an array of rings", ErrInvalidGeometry)
+		}
+		rings := make([]LineString, len(arr))
+		for i, ring := range arr {
+			if rings[i].Points, err = fromGeoJSONPositions(ring); err != nil {
+				return nil, err
+			}
+		}
+		g = Polygon{SRID: srid, Rings: rings}
+	default:
+		return nil, fmt.Errorf("%w: unsupported GeoJSON type %v", ErrInvalidGeometry, obj["type"])
+	}
+
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func geoJSONPosition(p Point) []interface{} {
+	return []interface{}{p.X, p.Y}
+}
+
+func geoJSONPositions(points []Point) []interface{} {
+	positions := make([]interface{}, len(points))
+	for i, p := range points {
+		positions[i] = geoJSONPosition(p)
+	}
+	return positions
+}
+
+func fromGeoJSONPosition(v interface{}) (Point, error) {
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) < 2 {
+		return Point{}, fmt.Errorf("%w: a position must be an array of at least two numbers", ErrInvalidGeometry)
+	}
+	x, xOk := arr[0].(float64)
+	y, yOk := arr[1].(float64)
+	if !xOk || !yOk || math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+		return Point{}, fmt.Errorf("%w: a position must be an array of at least two numbers", ErrInvalidGeometry)
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func fromGeoJSONPositions(v interface{}) ([]Point, error) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: expected an array of positions", ErrInvalidGeometry)
+	}
+	points := make([]Point, len(arr))
+	for i, position := range arr {
+		var err error
+		if points[i], err = fromGeoJSONPosition(position); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// geoJSONSRID returns the SRID of a named GeoJSON coordinate reference system such as "EPSG:4326", or 0 if there is
+// none.
+func geoJSONSRID(crs interface{}) (uint32, error) {
+	if crs == nil {
+		return 0, nil
+	}
+	crsObj, _ := crs.(map[string]interface{})
+	props, _ := crsObj["properties"].(map[string]interface{})
+	name, _ := props["name"].(string)
+	if !strings.HasPrefix(strings.ToUpper(name), geoJSONCRSPrefix) {
+		return 0, fmt.Errorf("%w: unsupported coordinate reference system %v", ErrInvalidGeometry, crs)
+	}
+	srid, err := strconv.ParseUint(name[len(geoJSONCRSPrefix):], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: unsupported coordinate reference system %v", ErrInvalidGeometry, crs)
+	}
+	return uint32(srid), nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geometry implements the spatial values stored in POINT, LINESTRING, POLYGON and GEOMETRY columns, and their
+// conversions to and from the well-known binary (WKB), well-known text (WKT) and GeoJSON representations.
+package geometry
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The names of the types of geometries, as returned by ST_GeometryType.
+const (
+	GeometryTypeName   = "GEOMETRY"
+	PointTypeName      = "POINT"
+	LineStringTypeName = "LINESTRING"
+	PolygonTypeName    = "POLYGON"
+)
+
+// ErrInvalidGeometry is returned for data that does not describe a valid geometry.
+var ErrInvalidGeometry = errors.New("invalid geometry")
+
+// Geometry is a spatial value. Coordinates are interpreted in the spatial reference system identified by the SRID of
+// the geometry, with 0 being the Cartesian plane.
+type Geometry interface {
+	// GetSRID returns the spatial reference system identifier of the geometry.
+	GetSRID() uint32
+	// WithSRID returns a copy of the geometry in the spatial reference system given.
+	WithSRID(srid uint32) Geometry
+	// GeometryType returns the name of the type of the geometry.
+	GeometryType() string
+	// String returns the well-known text of the geometry.
+	fmt.Stringer
+
+	validate() error
+}
+
+// Point is a single location.
+type Point struct {
+	SRID uint32
+	X, Y float64
+}
+
+// LineString is a sequence of two or more connected points. The SRIDs of its points are ignored.
+type LineString struct {
+	SRID   uint32
+	Points []Point
+}
+
+// Polygon is an area bounded by an exterior ring, with holes bounded by any further rings. Each ring is a closed
+// LineString of at least four points.
+type Polygon struct {
+	SRID  uint32
+	Rings []LineString
+}
+
+var _ Geometry = Point{}
+var _ Geometry = LineString{}
+var _ Geometry = Polygon{}
+
+// GetSRID implements Geometry.
+func (p Point) GetSRID() uint32 {
+	return p.SRID
+}
+
+// WithSRID implements Geometry.
+func (p Point) WithSRID(srid uint32) Geometry {
+	p.SRID = srid
+	return p
+}
+
+// GeometryType implements Geometry.
+func (p Point) GeometryType() string {
+	return PointTypeName
+}
+
+// String implements Geometry.
+func (p Point) String() string {
+	return ToWKT(p)
+}
+
+func (p Point) validate() error {
+	return nil
+}
+
+// GetSRID implements Geometry.
+func (l LineString) GetSRID() uint32 {
+	return l.SRID
+}
+
+// WithSRID implements Geometry.
+func (l LineString) WithSRID(srid uint32) Geometry {
+	l.SRID = srid
+	return l
+}
+
+// GeometryType implements Geometry.
+func (l LineString) GeometryType() string {
+	return LineStringTypeName
+}
+
+// String implements Geometry.
+func (l LineString) String() string {
+	return ToWKT(l)
+}
+
+func (l LineString) validate() error {
+	if len(l.Points) < 2 {
+		return fmt.Errorf("%w: a linestring must have at least two points", ErrInvalidGeometry)
+	}
+	return nil
+}
+
+// GetSRID implements Geometry.
+func (p Polygon) GetSRID() uint32 {
+	return p.SRID
+}
+
+// WithSRID implements Geometry.
+func (p Polygon) WithSRID(srid uint32) Geometry {
+	p.SRID = srid
+	return p
+}
+
+// GeometryType implements Geometry.
+func (p Polygon) GeometryType() string {
+	return PolygonTypeName
+}
+
+// String implements Geometry.
+func (p Polygon) String() string {
+	return ToWKT(p)
+}
+
+func (p Polygon) validate() error {
+	if len(p.Rings) == 0 {
+		return fmt.Errorf("%w: a polygon must have at least one ring", ErrInvalidGeometry)
+	}
+	for _, ring := range p.Rings {
+		n := len(ring.Points)
+		if n < 4 {
+			return fmt.Errorf("%w: a polygon ring must have at least four points", ErrInvalidGeometry)
+		}
+		if ring.Points[0].X != ring.Points[n-1].X || ring.Points[0].Y != ring.Points[n-1].Y {
+			return fmt.Errorf("%w: a polygon ring must be closed", ErrInvalidGeometry)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGeometries = []struct {
+	wkt string
+	g   Geometry
+}{
+	{"POINT(1 2)", Point{X: 1, Y: 2}},
+	{"POINT(-71.0589 42.3601)", Point{X: -71.0589, Y: 42.3601}},
+	{"LINESTRING(0 0,1.5 2,3 -4)", LineString{Points: []Point{{X: 0, Y: 0}, {X: 1.5, Y: 2}, {X: 3, Y: -4}}}},
+	{"POLYGON((0 0,4 0,4 4,0 0),(1 1,2 1,2 2,1 1))", Polygon{Rings: []LineString{
+		{Points: []Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 0}}},
+		{Points: []Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}}},
+	}}},
+}
+
+func TestWKT(t *testing.T) {
+	for _, test := range testGeometries {
+		t.Run(test.wkt, func(t *testing.T) {
+			assert.Equal(t, test.wkt, ToWKT(test.g))
+			g, err := FromWKT(test.wkt, 0)
+			require.NoError(t, err)
+			assert.Equal(t, test.g, g)
+
+			withSRID := test.g.WithSRID(4326)
+			g, err = FromEWKT(ToEWKT(withSRID))
+			require.NoError(t, err)
+			assert.Equal(t, withSRID, g)
+		})
+	}
+
+	g, err := FromWKT(" point ( 1e2   -3 ) ", 0)
+	require.NoError(t, err)
+	assert.Equal(t, Point{X: 100, Y: -3}, g)
+
+	for _, invalid := range []string{
+		"",
+		"POINT",
+		"POINT()",
+		"POINT(1 2 3)",
+		"POINT(1 2) POINT(3 4)",
+		"POINT(NaN 1)",
+		"LINESTRING(1 2)",
+		"POLYGON((0 0,1 0,1 1,0 1))",
+		"POLYGON((0 0,1 0,0 0))",
+		"MULTIPOINT(1 2)",
+		"SRID=x;POINT(1 2)",
+	} {
+		_, err := FromEWKT(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestWKB(t *testing.T) {
+	for _, test := range testGeometries {
+		t.Run(test.wkt, func(t *testing.T) {
+			g, err := FromWKB(ToWKB(test.g), 0)
+			require.NoError(t, err)
+			assert.Equal(t, test.g, g)
+
+			withSRID := test.g.WithSRID(3857)
+			g, err = Deserialize(Serialize(withSRID))
+			require.NoError(t, err)
+			assert.Equal(t, withSRID, g)
+		})
+	}
+
+	// the storage format is the format MySQL uses
+	assert.Equal(t, "e6100000010100000000000000000000000000000000000040", hex.EncodeToString(Serialize(Point{SRID: 4326, X: 0, Y: 2})))
+
+	bigEndian, err := hex.DecodeString("00000000013ff00000000000004000000000000000")
+	require.NoError(t, err)
+	g, err := FromWKB(bigEndian, 0)
+	require.NoError(t, err)
+	assert.Equal(t, Point{X: 1, Y: 2}, g)
+
+	data := Serialize(LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}})
+	_, err = Deserialize(data[:len(data)-1])
+	assert.Error(t, err)
+	_, err = Deserialize(append(data, 0))
+	assert.Error(t, err)
+	_, err = Deserialize([]byte{1, 2})
+	assert.Error(t, err)
+}
+
+func TestGeoJSON(t *testing.T) {
+	for _, test := range testGeometries {
+		t.Run(test.wkt, func(t *testing.T) {
+			for _, g := range []Geometry{test.g, test.g.WithSRID(4326)} {
+				// round trip through encoding/json, as files are read
+				data, err := json.Marshal(ToGeoJSON(g))
+				require.NoError(t, err)
+				var obj map[string]interface{}
+				require.NoError(t, json.Unmarshal(data, &obj))
+
+				decoded, err := FromGeoJSON(obj)
+				require.NoError(t, err)
+				assert.Equal(t, g, decoded)
+			}
+		})
+	}
+
+	data, err := json.Marshal(ToGeoJSON(Point{X: 1, Y: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"coordinates":[1,2],"type":"Point"}`, string(data))
+
+	_, err = FromGeoJSON(map[string]interface{}{"type": "Point", "coordinates": []interface{}{1.0}})
+	assert.Error(t, err)
+	_, err = FromGeoJSON(map[string]interface{}{"type": "MultiPoint", "coordinates": []interface{}{}})
+	assert.Error(t, err)
+}
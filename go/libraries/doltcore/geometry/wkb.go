@@ -0,0 +1,246 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+
+	wkbPoint      = 1
+	wkbLineString = 2
+	wkbPolygon    = 3
+
+	sridLength = 4
+)
+
+// Serialize returns the storage format of a geometry, which is the format MySQL uses for spatial values: the SRID as
+// a little-endian uint32, followed by the little-endian well-known binary of the geometry.
+func Serialize(g Geometry) []byte {
+	buf := make([]byte, sridLength, sridLength+wkbLength(g))
+	binary.LittleEndian.PutUint32(buf, g.GetSRID())
+	return appendWKB(buf, g)
+}
+
+// Deserialize returns the geometry in the storage format given.
+func Deserialize(data []byte) (Geometry, error) {
+	if len(data) < sridLength {
+		return nil, fmt.Errorf("%w: data is too short", ErrInvalidGeometry)
+	}
+	return FromWKB(data[sridLength:], binary.LittleEndian.Uint32(data))
+}
+
+// ToWKB returns the little-endian well-known binary of a geometry. The WKB of a geometry does not include its SRID.
+func ToWKB(g Geometry) []byte {
+	return appendWKB(make([]byte, 0, wkbLength(g)), g)
+}
+
+// FromWKB returns the geometry with the well-known binary given, in the spatial reference system given. Both byte
+// orders are accepted.
+func FromWKB(data []byte, srid uint32) (Geometry, error) {
+	r := &wkbReader{data: data}
+	g, err := r.readGeometry(srid)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(data) {
+		return nil, fmt.Errorf("%w: unexpected data after the end of the geometry", ErrInvalidGeometry)
+	}
+	return g, nil
+}
+
+func wkbLength(g Geometry) int {
+	const headerLength = 5
+	const pointLength = 16
+	switch g := g.(type) {
+	case Point:
+		return headerLength + pointLength
+	case LineString:
+		return headerLength + 4 + pointLength*len(g.Points)
+	case Polygon:
+		n := headerLength + 4
+		for _, ring := range g.Rings {
+			n += 4 + pointLength*len(ring.Points)
+		}
+		return n
+	default:
+		panic(fmt.Sprintf("unknown geometry type %T", g))
+	}
+}
+
+func appendWKB(buf []byte, g Geometry) []byte {
+	switch g := g.(type) {
+	case Point:
+		buf = appendWKBHeader(buf, wkbPoint)
+		return appendWKBPoint(buf, g)
+	case LineString:
+		buf = appendWKBHeader(buf, wkbLineString)
+		return appendWKBPoints(buf, g.Points)
+	case Polygon:
+		buf = appendWKBHeader(buf, wkbPolygon)
+		buf = appendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = appendWKBPoints(buf, ring.Points)
+		}
+		return buf
+	default:
+		panic(fmt.Sprintf("unknown geometry type %T", g))
+	}
+}
+
+func appendWKBHeader(buf []byte, wkbType uint32) []byte {
+	buf = append(buf, wkbLittleEndian)
+	return appendUint32(buf, wkbType)
+}
+
+func appendWKBPoints(buf []byte, points []Point) []byte {
+	buf = appendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+func appendWKBPoint(buf []byte, p Point) []byte {
+	buf = appendUint64(buf, math.Float64bits(p.X))
+	return appendUint64(buf, math.Float64bits(p.Y))
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readGeometry(srid uint32) (Geometry, error) {
+	if r.pos >= len(r.data) {
+		return nil, fmt.Errorf("%w: data is too short", ErrInvalidGeometry)
+	}
+	switch r.data[r.pos] {
+	case wkbBigEndian:
+		r.order = binary.BigEndian
+	case wkbLittleEndian:
+		r.order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("%w: unknown byte order %d", ErrInvalidGeometry, r.data[r.pos])
+	}
+	r.pos++
+
+	wkbType, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	var g Geometry
+	switch wkbType {
+	case wkbPoint:
+		p, err := r.readPoint()
+		if err != nil {
+			return nil, err
+		}
+		p.SRID = srid
+		g = p
+	case wkbLineString:
+		points, err := r.readPoints()
+		if err != nil {
+			return nil, err
+		}
+		g = LineString{SRID: srid, Points: points}
+	case wkbPolygon:
+		n, err := r.readCount(4)
+		if err != nil {
+			return nil, err
+		}
+		rings := make([]LineString, n)
+		for i := range rings {
+			if rings[i].Points, err = r.readPoints(); err != nil {
+				return nil, err
+			}
+		}
+		g = Polygon{SRID: srid, Rings: rings}
+	default:
+		return nil, fmt.Errorf("%w: unsupported geometry type %d", ErrInvalidGeometry, wkbType)
+	}
+
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (r *wkbReader) readPoints() ([]Point, error) {
+	n, err := r.readCount(16)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, n)
+	for i := range points {
+		if points[i], err = r.readPoint(); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readPoint() (Point, error) {
+	if len(r.data)-r.pos < 16 {
+		return Point{}, fmt.Errorf("%w: data is too short", ErrInvalidGeometry)
+	}
+	x := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	y := math.Float64frombits(r.order.Uint64(r.data[r.pos+8:]))
+	if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+		return Point{}, fmt.Errorf("%w: coordinates must be finite", ErrInvalidGeometry)
+	}
+	r.pos += 16
+	return Point{X: x, Y: y}, nil
+}
+
+// readCount reads the number of elements that follow, each of which takes at least minLength bytes.
+func (r *wkbReader) readCount(minLength int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minLength) > uint64(len(r.data)-r.pos) {
+		return 0, fmt.Errorf("%w: data is too short", ErrInvalidGeometry)
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if len(r.data)-r.pos < 4 {
+		return 0, fmt.Errorf("%w: data is too short", ErrInvalidGeometry)
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const ewktSRIDPrefix = "SRID="
+
+// ToWKT returns the well-known text of a geometry, such as "POINT(1 2)". The WKT of a geometry does not include its
+// SRID.
+func ToWKT(g Geometry) string {
+	sb := &strings.Builder{}
+	sb.WriteString(g.GeometryType())
+	switch g := g.(type) {
+	case Point:
+		sb.WriteRune('(')
+		writeWKTPoint(sb, g)
+		sb.WriteRune(')')
+	case LineString:
+		writeWKTPoints(sb, g.Points)
+	case Polygon:
+		sb.WriteRune('(')
+		for i, ring := range g.Rings {
+			if i > 0 {
+				sb.WriteRune(',')
+			}
+			writeWKTPoints(sb, ring.Points)
+		}
+		sb.WriteRune(')')
+	}
+	return sb.String()
+}
+
+// ToEWKT returns the well-known text of a geometry, prefixed with "SRID=<srid>;" when its SRID is not 0. This is the
+// text format used for spatial values in files such as CSVs, so that their SRIDs are kept.
+func ToEWKT(g Geometry) string {
+	if g.GetSRID() == 0 {
+		return ToWKT(g)
+	}
+	return ewktSRIDPrefix + strconv.FormatUint(uint64(g.GetSRID()), 10) + ";" + ToWKT(g)
+}
+
+// FromWKT returns the geometry with the well-known text given, in the spatial reference system given.
+func FromWKT(text string, srid uint32) (Geometry, error) {
+	p := &wktParser{text: text}
+	g, err := p.parseGeometry(srid)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok != "" {
+		return nil, fmt.Errorf("%w: unexpected %q after the end of the geometry", ErrInvalidGeometry, tok)
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// FromEWKT returns the geometry with the text given, which is either well-known text or well-known text prefixed with
+// "SRID=<srid>;" as written by ToEWKT.
+func FromEWKT(text string) (Geometry, error) {
+	text = strings.TrimSpace(text)
+	if len(text) < len(ewktSRIDPrefix) || !strings.EqualFold(text[:len(ewktSRIDPrefix)], ewktSRIDPrefix) {
+		return FromWKT(text, 0)
+	}
+	sep := strings.IndexRune(text, ';')
+	if sep < 0 {
+		return nil, fmt.Errorf("%w: missing ';' after the SRID", ErrInvalidGeometry)
+	}
+	srid, err := strconv.ParseUint(strings.TrimSpace(text[len(ewktSRIDPrefix):sep]), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid SRID %q", ErrInvalidGeometry, text[len(ewktSRIDPrefix):sep])
+	}
+	return FromWKT(text[sep+1:], uint32(srid))
+}
+
+func writeWKTPoints(sb *strings.Builder, points []Point) {
+	sb.WriteRune('(')
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+		writeWKTPoint(sb, p)
+	}
+	sb.WriteRune(')')
+}
+
+func writeWKTPoint(sb *strings.Builder, p Point) {
+	sb.WriteString(strconv.FormatFloat(p.X, 'g', -1, 64))
+	sb.WriteRune(' ')
+	sb.WriteString(strconv.FormatFloat(p.Y, 'g', -1, 64))
+}
+
+// wktParser splits well-known text into words, numbers and punctuation as it's parsed.
+type wktParser struct {
+	text string
+	pos  int
+}
+
+func (p *wktParser) next() string {
+	for p.pos < len(p.text) && unicode.IsSpace(rune(p.text[p.pos])) {
+		p.pos++
+	}
+	if p.pos == len(p.text) {
+		return ""
+	}
+	start := p.pos
+	switch p.text[p.pos] {
+	case '(', ')', ',':
+		p.pos++
+	default:
+		for p.pos < len(p.text) && !unicode.IsSpace(rune(p.text[p.pos])) && !strings.ContainsRune("(),", rune(p.text[p.pos])) {
+			p.pos++
+		}
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *wktParser) expect(tok string) error {
+	if next := p.next(); next != tok {
+		if next == "" {
+			return fmt.Errorf("%w: expected %q at the end of the text", ErrInvalidGeometry, tok)
+		}
+		return fmt.Errorf("%w: expected %q but found %q", ErrInvalidGeometry, tok, next)
+	}
+	return nil
+}
+
+func (p *wktParser) parseGeometry(srid uint32) (Geometry, error) {
+	typeName := strings.ToUpper(p.next())
+	switch typeName {
+	case PointTypeName:
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		point, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		point.SRID = srid
+		return point, nil
+	case LineStringTypeName:
+		points, err := p.parsePoints()
+		if err != nil {
+			return nil, err
+		}
+		return LineString{SRID: srid, Points: points}, nil
+	case PolygonTypeName:
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		var rings []LineString
+		for {
+			points, err := p.parsePoints()
+			if err != nil {
+				return nil, err
+			}
+			rings = append(rings, LineString{Points: points})
+			if tok := p.next(); tok == ")" {
+				return Polygon{SRID: srid, Rings: rings}, nil
+			} else if tok != "," {
+				return nil, fmt.Errorf("%w: expected \",\" or \")\" but found %q", ErrInvalidGeometry, tok)
+			}
+		}
+	case "":
+		return nil, fmt.Errorf("%w: empty text", ErrInvalidGeometry)
+	default:
+		return nil, fmt.Errorf("%w: unsupported geometry type %q", ErrInvalidGeometry, typeName)
+	}
+}
+
+func (p *wktParser) parsePoints() ([]Point, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var points []Point
+	for {
+		point, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+		if tok := p.next(); tok == ")" {
+			return points, nil
+		} else if tok != "," {
+			return nil, fmt.Errorf("%w: expected \",\" or \")\" but found %q", ErrInvalidGeometry, tok)
+		}
+	}
+}
+
+func (p *wktParser) parsePoint() (Point, error) {
+	x, err := p.parseNumber()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.parseNumber()
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func (p *wktParser) parseNumber() (float64, error) {
+	tok := p.next()
+	f, err := strconv.ParseFloat(tok, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%w: expected a number but found %q", ErrInvalidGeometry, tok)
+	}
+	return f, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// CheckViolation is a row of a table that does not satisfy one of the table's check constraints.
+type CheckViolation struct {
+	Check schema.Check
+	Row   row.Row
+}
+
+// CheckViolations returns every row of the table given that violates one of its enforced check constraints. Each side
+// of a merge satisfies its own check constraints, but the merged rows and schema may not, so merged tables are
+// verified with this before the merge is accepted.
+func CheckViolations(ctx context.Context, tbl *doltdb.Table) ([]CheckViolation, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := expreval.NewCheckEvaluator(sch)
+	if err != nil {
+		return nil, err
+	}
+	if !checks.HasChecks() {
+		return nil, nil
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []CheckViolation
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+
+		violated, err := checks.Violations(ctx, r)
+		if err != nil {
+			return true, err
+		}
+
+		for _, check := range violated {
+			violations = append(violations, CheckViolation{Check: check, Row: r})
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
+
+// TablesWithCheckViolations returns the sorted names of the tables whose merged rows violate their check constraints.
+func TablesWithCheckViolations(tblToStats map[string]*MergeStats) []string {
+	var tblNames []string
+	for tblName, stats := range tblToStats {
+		if len(stats.CheckViolations) > 0 {
+			tblNames = append(tblNames, tblName)
+		}
+	}
+	sort.Strings(tblNames)
+	return tblNames
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// RegenerateColumns recomputes the generated columns of every row of the table given, and returns the table with any
+// rows whose generated values changed updated. Each side of a merge computes its own generated values, but a merged
+// row can combine inputs from both sides, so merged tables are regenerated before they're verified.
+func RegenerateColumns(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	generated, err := expreval.NewGeneratedEvaluator(tbl.ValueReadWriter(), sch)
+	if err != nil {
+		return nil, err
+	}
+	if !generated.HasGenerated() {
+		return tbl, nil
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tblEditor, err := doltdb.NewTableEditor(ctx, tbl, sch)
+	if err != nil {
+		return nil, err
+	}
+	defer tblEditor.Close()
+
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		oldRow, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+
+		newRow, err := generated.Generate(ctx, oldRow)
+		if err != nil {
+			return true, err
+		}
+
+		if row.AreEqual(oldRow, newRow, sch) {
+			return false, nil
+		}
+		return false, tblEditor.UpdateRow(ctx, oldRow, newRow)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tblEditor.Table()
+}
@@ -34,6 +34,14 @@ import (
 
 var ErrFastForward = errors.New("fast forward")
 var ErrSameTblAddedTwice = errors.New("table with same name added in 2 commits can't be merged")
+var ErrSchemaConflict = errors.New("schema conflicts")
+var ErrForeignKeyConflict = errors.New("foreign key conflicts")
+
+// IsConflictError returns whether the error given was returned by a merge because the changes merged conflict in a way
+// which can't be recorded as row conflicts, rather than because the merge failed.
+func IsConflictError(err error) bool {
+	return errors.Is(err, ErrSameTblAddedTwice) || errors.Is(err, ErrSchemaConflict) || errors.Is(err, ErrForeignKeyConflict)
+}
 
 type Merger struct {
 	root      *doltdb.RootValue
@@ -280,6 +288,7 @@ func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows
 	conflictValChan := make(chan types.Value)
 	sm := types.NewStreamingMap(ctx, vrw, conflictValChan)
 	stats := &MergeStats{Operation: TableModified}
+	keyless := schema.IsKeyless(sch)
 
 	eg.Go(func() error {
 		defer close(conflictValChan)
@@ -342,7 +351,11 @@ func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows
 				}
 
 				if keyNilOrMKLess {
-					err = applyChange(ctx, tblEdit, rows, sch, stats, mergeChange)
+					if keyless {
+						err = applyKeylessChange(ctx, tblEdit, sch, stats, mergeKey, mergeChange.OldValue, mergeChange.NewValue)
+					} else {
+						err = applyChange(ctx, tblEdit, rows, sch, stats, mergeChange)
+					}
 					if err != nil {
 						return err
 					}
@@ -351,7 +364,21 @@ func mergeTableData(ctx context.Context, tblName string, sch schema.Schema, rows
 				}
 			}
 
-			if !processed {
+			if !processed && keyless {
+				r, mergeRow, ancRow := change.NewValue, mergeChange.NewValue, change.OldValue
+				mergedRow, err := keylessRowMerge(r, mergeRow, ancRow)
+				if err != nil {
+					return err
+				}
+
+				err = applyKeylessChange(ctx, tblEdit, sch, stats, key, r, mergedRow)
+				if err != nil {
+					return err
+				}
+
+				change = types.ValueChanged{}
+				mergeChange = types.ValueChanged{}
+			} else if !processed {
 				r, mergeRow, ancRow := change.NewValue, mergeChange.NewValue, change.OldValue
 				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow)
 				if err != nil {
@@ -473,6 +500,83 @@ func applyChange(ctx context.Context, tableEditor *doltdb.SessionedTableEditor,
 	return nil
 }
 
+// applyKeylessChange applies the change of a row of a keyless table from the value given to the merged value, either
+// of which is nil if the row is absent. As the rows of keyless tables are identified by their contents, only their
+// cardinality can change, which is applied as the insertion or deletion of the difference in cardinality.
+func applyKeylessChange(ctx context.Context, tableEditor *doltdb.SessionedTableEditor, sch schema.Schema, stats *MergeStats, key, value, mergedValue types.Value) error {
+	card, err := keylessCardinality(value)
+	if err != nil {
+		return err
+	}
+	mergedCard, err := keylessCardinality(mergedValue)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case mergedCard > card:
+		r, err := row.FromNoms(sch, key.(types.Tuple), mergedValue.(types.Tuple))
+		if err != nil {
+			return err
+		}
+		err = tableEditor.InsertRow(ctx, row.WithCardinality(r, mergedCard-card))
+		if err != nil {
+			return err
+		}
+		stats.Adds++
+	case mergedCard < card:
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return err
+		}
+		err = tableEditor.DeleteRow(ctx, row.WithCardinality(r, card-mergedCard))
+		if err != nil {
+			return err
+		}
+		stats.Deletes++
+	}
+
+	return nil
+}
+
+// keylessRowMerge merges the changes made to a row of a keyless table. Any of the values given is nil if the row is
+// absent. The merged cardinality is the ancestor's cardinality plus the changes made on each side, so keyless rows
+// never conflict. The merged value is nil if no identical rows remain.
+func keylessRowMerge(r, mergeRow, ancRow types.Value) (types.Value, error) {
+	card, err := keylessCardinality(r)
+	if err != nil {
+		return nil, err
+	}
+	mergeCard, err := keylessCardinality(mergeRow)
+	if err != nil {
+		return nil, err
+	}
+	ancCard, err := keylessCardinality(ancRow)
+	if err != nil {
+		return nil, err
+	}
+
+	if card+mergeCard <= ancCard {
+		return nil, nil
+	}
+
+	merged := r
+	if merged == nil {
+		merged = mergeRow
+	}
+
+	// the cardinality is the second value of the tuple, following its tag
+	return merged.(types.Tuple).Set(1, types.Uint(card+mergeCard-ancCard))
+}
+
+// keylessCardinality returns the cardinality of the value of a row of a keyless table, or 0 if the value is nil.
+func keylessCardinality(value types.Value) (uint64, error) {
+	if value == nil {
+		return 0, nil
+	}
+	return row.ParseCardinality(value.(types.Tuple))
+}
+
 func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
 	var baseVals row.TaggedValues
 	if baseRow == nil {
@@ -531,8 +635,18 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 
 	resultVals := make(row.TaggedValues)
 
+	// the value of a row includes the values of its collated primary key columns, which may differ in ways their
+	// collation ignores
+	valCols := schema.ValueCols(sch)
+
 	var isConflict bool
-	err = sch.GetNonPKCols().Iter(func(tag uint64, _ schema.Column) (stop bool, err error) {
+	err = valCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if col.IsGenerated() {
+			// generated values never conflict, they are recomputed from the merged row once the merge is done
+			resultVals[tag], _ = rowVals.Get(tag)
+			return false, nil
+		}
+
 		var val types.Value
 		val, isConflict = processTagFunc(tag)
 		resultVals[tag] = val
@@ -548,7 +662,7 @@ func rowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema,
 		return nil, true, nil
 	}
 
-	tpl := resultVals.NomsTupleForNonPKCols(nbf, sch.GetNonPKCols())
+	tpl := resultVals.NomsTupleForNonPKCols(nbf, valCols)
 	v, err := tpl.Value(ctx)
 
 	if err != nil {
@@ -648,6 +762,13 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 		if mergedTable != nil {
 			tblToStats[tblName] = stats
 
+			if stats.Operation == TableModified {
+				mergedTable, err = RegenerateColumns(ctx, mergedTable)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+
 			if stats.Conflicts == 0 {
 				unconflicted = append(unconflicted, tblName)
 			}
@@ -684,7 +805,7 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 			return nil, err
 		}
 		if len(conflicts) > 0 {
-			return nil, fmt.Errorf("foreign key conflicts")
+			return nil, ErrForeignKeyConflict
 		}
 		return root.PutForeignKeyCollection(ctx, mergedFKColl)
 	})
@@ -700,6 +821,22 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 		return nil, nil, err
 	}
 
+	for _, tblName := range unconflicted {
+		if tblToStats[tblName].Operation != TableModified {
+			continue
+		}
+
+		tbl, _, err := newRoot.GetTable(ctx, tblName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tblToStats[tblName].CheckViolations, err = CheckViolations(ctx, tbl)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return newRoot, tblToStats, nil
 }
 
@@ -34,24 +34,32 @@ type SchemaConflict struct {
 	TableName    string
 	ColConflicts []ColConflict
 	IdxConflicts []IdxConflict
+	PKConflicts  []PKConflict
+	ChkConflicts []ChkConflict
 }
 
 var EmptySchConflicts = SchemaConflict{}
 
 func (sc SchemaConflict) Count() int {
-	return len(sc.ColConflicts) + len(sc.IdxConflicts)
+	return len(sc.ColConflicts) + len(sc.IdxConflicts) + len(sc.PKConflicts) + len(sc.ChkConflicts)
 }
 
+// AsError returns an error describing the conflicts, which wraps ErrSchemaConflict.
 func (sc SchemaConflict) AsError() error {
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("schema conflicts for table %s:\n", sc.TableName))
 	for _, c := range sc.ColConflicts {
 		b.WriteString(fmt.Sprintf("\t%s\n", c.String()))
 	}
 	for _, c := range sc.IdxConflicts {
 		b.WriteString(fmt.Sprintf("\t%s\n", c.String()))
 	}
-	return fmt.Errorf(b.String())
+	for _, c := range sc.PKConflicts {
+		b.WriteString(fmt.Sprintf("\t%s\n", c.String()))
+	}
+	for _, c := range sc.ChkConflicts {
+		b.WriteString(fmt.Sprintf("\t%s\n", c.String()))
+	}
+	return fmt.Errorf("%w for table %s:\n%s", ErrSchemaConflict, sc.TableName, b.String())
 }
 
 type ColConflict struct {
@@ -78,6 +86,29 @@ func (c IdxConflict) String() string {
 	return ""
 }
 
+// PKConflict describes a primary key that was changed on either branch since the common ancestor. Rows keyed by
+// different primary keys can't be matched against each other, so these tables can't be merged.
+type PKConflict struct {
+	Ours, Theirs, Ancestor *schema.ColCollection
+}
+
+func (c PKConflict) String() string {
+	fmtPk := func(cc *schema.ColCollection) string {
+		return "(" + strings.Join(cc.GetColumnNames(), ", ") + ")"
+	}
+	return fmt.Sprintf("primary key changed since the common ancestor: ancestor %s, ours %s, theirs %s", fmtPk(c.Ancestor), fmtPk(c.Ours), fmtPk(c.Theirs))
+}
+
+// ChkConflict describes a check constraint that was added or changed on both branches since the common ancestor with
+// different definitions.
+type ChkConflict struct {
+	Ours, Theirs schema.Check
+}
+
+func (c ChkConflict) String() string {
+	return fmt.Sprintf("different definitions for check constraint '%s': ours (%s), theirs (%s)", c.Ours.Name(), c.Ours.Expression(), c.Theirs.Expression())
+}
+
 type FKConflict struct {
 	Kind         conflictKind
 	Ours, Theirs doltdb.ForeignKey
@@ -91,6 +122,15 @@ func SchemaMerge(ourSch, theirSch, ancSch schema.Schema, tblName string) (sch sc
 		TableName: tblName,
 	}
 
+	if !schema.ArePrimaryKeySetsDiffable(ancSch, ourSch) || !schema.ArePrimaryKeySetsDiffable(ancSch, theirSch) {
+		sc.PKConflicts = append(sc.PKConflicts, PKConflict{
+			Ours:     ourSch.GetPKCols(),
+			Theirs:   theirSch.GetPKCols(),
+			Ancestor: ancSch.GetPKCols(),
+		})
+		return nil, sc, nil
+	}
+
 	var mergedCC *schema.ColCollection
 	mergedCC, sc.ColConflicts, err = mergeColumns(ourSch.GetAllCols(), theirSch.GetAllCols(), ancSch.GetAllCols())
 	if err != nil {
@@ -106,6 +146,12 @@ func SchemaMerge(ourSch, theirSch, ancSch schema.Schema, tblName string) (sch sc
 		return nil, sc, nil
 	}
 
+	var mergedChks []schema.Check
+	mergedChks, sc.ChkConflicts = mergeChecks(ourSch.Checks(), theirSch.Checks(), ancSch.Checks())
+	if len(sc.ChkConflicts) > 0 {
+		return nil, sc, nil
+	}
+
 	sch, err = schema.SchemaFromCols(mergedCC)
 	if err != nil {
 		return nil, sc, err
@@ -114,6 +160,7 @@ func SchemaMerge(ourSch, theirSch, ancSch schema.Schema, tblName string) (sch sc
 		sch.Indexes().AddIndex(index)
 		return false, nil
 	})
+	sch.Checks().AddChecks(mergedChks...)
 
 	return sch, sc, nil
 }
@@ -544,3 +591,35 @@ func pruneInvalidForeignKeys(ctx context.Context, fkColl *doltdb.ForeignKeyColle
 
 	return pruned, nil
 }
+
+// mergeChecks performs a three-way merge of check constraints, matched by name. A check constraint dropped on either
+// branch is dropped, and a check constraint changed on only one branch takes that branch's definition.
+func mergeChecks(ours, theirs, anc schema.CheckCollection) (merged []schema.Check, conflicts []ChkConflict) {
+	for _, ourChk := range ours.AllChecks() {
+		theirChk, inTheirs := theirs.GetByNameCaseInsensitive(ourChk.Name())
+		ancChk, inAnc := anc.GetByNameCaseInsensitive(ourChk.Name())
+		switch {
+		case inTheirs && ourChk.Equals(theirChk):
+			merged = append(merged, ourChk)
+		case inTheirs && inAnc && ourChk.Equals(ancChk):
+			merged = append(merged, theirChk)
+		case inTheirs && inAnc && theirChk.Equals(ancChk):
+			merged = append(merged, ourChk)
+		case inTheirs:
+			conflicts = append(conflicts, ChkConflict{Ours: ourChk, Theirs: theirChk})
+		case !inAnc:
+			// added on our branch
+			merged = append(merged, ourChk)
+		}
+		// otherwise it was dropped on their branch
+	}
+
+	for _, theirChk := range theirs.AllChecks() {
+		if !ours.Contains(theirChk.Name()) && !anc.Contains(theirChk.Name()) {
+			// added on their branch
+			merged = append(merged, theirChk)
+		}
+	}
+
+	return merged, conflicts
+}
@@ -49,6 +49,29 @@ func TestMergeSchemas(t *testing.T) {
 	}
 }
 
+func TestMergeSchemasWithPrimaryKeyChanges(t *testing.T) {
+	pkCol := newColTypeInfo("pk", 1, typeinfo.Int32Type, true, schema.NotNullConstraint{})
+	c1 := newColTypeInfo("c1", 2, typeinfo.Int32Type, false, schema.NotNullConstraint{})
+	c1Pk := newColTypeInfo("c1", 2, typeinfo.Int32Type, true, schema.NotNullConstraint{})
+
+	ancSch := schemaFromColsAndIdxs(colCollection(pkCol, c1))
+	changedSch := schemaFromColsAndIdxs(colCollection(pkCol, c1Pk))
+
+	_, sc, err := merge.SchemaMerge(changedSch, ancSch, ancSch, "test")
+	require.NoError(t, err)
+	require.Equal(t, 1, sc.Count())
+	require.Len(t, sc.PKConflicts, 1)
+	assert.Contains(t, sc.AsError().Error(), "primary key changed since the common ancestor: ancestor (pk), ours (pk, c1), theirs (pk)")
+
+	_, sc, err = merge.SchemaMerge(ancSch, changedSch, ancSch, "test")
+	require.NoError(t, err)
+	assert.Len(t, sc.PKConflicts, 1)
+
+	_, sc, err = merge.SchemaMerge(ancSch, ancSch, ancSch, "test")
+	require.NoError(t, err)
+	assert.Equal(t, 0, sc.Count())
+}
+
 type testCommand struct {
 	cmd  cli.Command
 	args []string
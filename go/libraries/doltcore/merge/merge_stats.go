@@ -29,4 +29,6 @@ type MergeStats struct {
 	Deletes       int
 	Modifications int
 	Conflicts     int
+	// CheckViolations are the rows of the merged table that violate its check constraints.
+	CheckViolations []CheckViolation
 }
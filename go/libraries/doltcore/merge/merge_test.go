@@ -214,6 +214,32 @@ func TestRowMerge(t *testing.T) {
 	}
 }
 
+func TestKeylessRowMerge(t *testing.T) {
+	keylessVal := func(card uint64) types.Value {
+		return mustTuple(types.NewTuple(types.Format_7_18, types.Uint(schema.KeylessRowCardinalityTag), types.Uint(card), types.Uint(nameTag), types.String("one")))
+	}
+
+	tests := []struct {
+		name                  string
+		row, mergeRow, ancRow types.Value
+		expected              types.Value
+	}{
+		{"added on both sides", keylessVal(1), keylessVal(2), nil, keylessVal(3)},
+		{"added on one side", nil, keylessVal(2), nil, keylessVal(2)},
+		{"added and removed", keylessVal(4), keylessVal(2), keylessVal(3), keylessVal(3)},
+		{"removed on both sides", keylessVal(1), keylessVal(1), keylessVal(2), nil},
+		{"all removed", nil, keylessVal(1), keylessVal(2), nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := keylessRowMerge(test.row, test.mergeRow, test.ancRow)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, merged)
+		})
+	}
+}
+
 const (
 	tableName = "test-table"
 	name      = "billy bob"
@@ -484,3 +510,13 @@ func TestMergeCommits(t *testing.T) {
 		assert.Fail(t, "%v and %v do not equal", h, eh)
 	}
 }
+
+func TestIsConflictError(t *testing.T) {
+	schConflicts := SchemaConflict{TableName: "t", ColConflicts: []ColConflict{{Kind: NameCollision}}}
+	assert.True(t, IsConflictError(schConflicts.AsError()))
+	assert.Contains(t, schConflicts.AsError().Error(), "schema conflicts for table t:\n")
+	assert.True(t, IsConflictError(ErrSameTblAddedTwice))
+	assert.True(t, IsConflictError(ErrForeignKeyConflict))
+	assert.False(t, IsConflictError(ErrFastForward))
+	assert.False(t, IsConflictError(nil))
+}
@@ -0,0 +1,347 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ViolationType is the kind of constraint broken by a ConstraintViolation.
+type ViolationType string
+
+const (
+	NotNullViolation     ViolationType = "not null"
+	TypeViolation        ViolationType = "type"
+	IndexViolation       ViolationType = "index"
+	UniqueIndexViolation ViolationType = "unique index"
+	ForeignKeyViolation  ViolationType = "foreign key"
+	CheckViolationType   ViolationType = "check"
+)
+
+// ConstraintViolation is a constraint of a table that the table's data doesn't satisfy.
+type ConstraintViolation struct {
+	// Table is the name of the table whose data violates the constraint.
+	Table string
+	// Type is the kind of constraint that is violated.
+	Type ViolationType
+	// Name is the name of the column, index, foreign key or check constraint that is violated.
+	Name string
+	// Key identifies the row that violates the constraint by its primary key values, and is empty when the violation
+	// isn't caused by a single row, or the row has no primary key.
+	Key string
+	// Message describes the violation.
+	Message string
+}
+
+// VerifyRoot checks the data of every table in the root given against the table's constraints, and returns the
+// violations found. Writes through SQL are checked as they happen, but merges forced past foreign key checks and
+// edits made outside of SQL can leave a root with data that doesn't satisfy its schema. Rows are checked against
+// NOT NULL constraints, the bounds of their column types and check constraints, indexes are checked against the rows
+// of their tables and their uniqueness, and foreign keys are checked against the rows of their referenced tables.
+func VerifyRoot(ctx context.Context, root *doltdb.RootValue) ([]ConstraintViolation, error) {
+	tblNames, err := root.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(tblNames)
+
+	var violations []ConstraintViolation
+	for _, tblName := range tblNames {
+		tbl, ok, err := root.GetTable(ctx, tblName)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		tblViolations, err := verifyTable(ctx, tblName, tbl)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, tblViolations...)
+	}
+
+	fkViolations, err := verifyForeignKeys(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(violations, fkViolations...), nil
+}
+
+func verifyTable(ctx context.Context, tblName string, tbl *doltdb.Table) ([]ConstraintViolation, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []ConstraintViolation
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		keyVals, err := row.ParseTaggedValues(key.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+		vals, err := row.ParseTaggedValues(value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+		// collated primary key columns are represented by their collation keys in the key, and their values are kept
+		// in the value
+		for tag, val := range vals {
+			if col, ok := sch.GetPKCols().GetByTag(tag); ok && schema.UsesCollationKeys(col) {
+				keyVals[tag] = val
+			}
+		}
+
+		rowKey := formatRowKey(ctx, sch, keyVals)
+		err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			val, ok := keyVals[tag]
+			if !ok {
+				val, ok = vals[tag]
+			}
+			if !ok || types.IsNull(val) {
+				if !col.IsNullable() {
+					violations = append(violations, ConstraintViolation{
+						Table:   tblName,
+						Type:    NotNullViolation,
+						Name:    col.Name,
+						Key:     rowKey,
+						Message: fmt.Sprintf("column `%s` is NULL but is not nullable", col.Name),
+					})
+				}
+				return false, nil
+			}
+			if err := verifyValueType(ctx, col, val); err != nil {
+				violations = append(violations, ConstraintViolation{
+					Table:   tblName,
+					Type:    TypeViolation,
+					Name:    col.Name,
+					Key:     rowKey,
+					Message: fmt.Sprintf("value of column `%s` is not a valid %s: %s", col.Name, col.TypeInfo.ToSqlType().String(), err.Error()),
+				})
+			}
+			return false, nil
+		})
+		return err != nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checkViolations, err := CheckViolations(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+	for _, cv := range checkViolations {
+		keyVals, err := row.GetTaggedVals(cv.Row)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, ConstraintViolation{
+			Table:   tblName,
+			Type:    CheckViolationType,
+			Name:    cv.Check.Name(),
+			Key:     formatRowKey(ctx, sch, keyVals),
+			Message: fmt.Sprintf("check constraint `%s` is violated", cv.Check.Name()),
+		})
+	}
+
+	for _, idx := range sch.Indexes().AllIndexes() {
+		violation, err := verifyIndex(ctx, tblName, tbl, idx)
+		if err != nil {
+			return nil, err
+		}
+		if violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	return violations, nil
+}
+
+// verifyValueType returns an error if the value given can't be stored in the column given. Integers are checked
+// before they're converted to the column's type, as the conversion truncates them.
+func verifyValueType(ctx context.Context, col schema.Column, val types.Value) error {
+	if col.TypeInfo == typeinfo.UnknownType {
+		return nil
+	}
+
+	var sqlVal interface{}
+	switch val := val.(type) {
+	case types.Int:
+		sqlVal = int64(val)
+	case types.Uint:
+		sqlVal = uint64(val)
+	default:
+		var err error
+		sqlVal, err = col.TypeInfo.ConvertNomsValueToValue(ctx, val)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := col.TypeInfo.ToSqlType().Convert(sqlVal)
+	return err
+}
+
+// verifyIndex returns a violation if the data of the index given doesn't match the rows of its table, or isn't a
+// valid index map.
+func verifyIndex(ctx context.Context, tblName string, tbl *doltdb.Table, idx schema.Index) (*ConstraintViolation, error) {
+	violationType := IndexViolation
+	if idx.IsUnique() {
+		violationType = UniqueIndexViolation
+	}
+
+	indexData, err := tbl.GetIndexRowData(ctx, idx.Name())
+	if err != nil {
+		return &ConstraintViolation{Table: tblName, Type: violationType, Name: idx.Name(), Message: err.Error()}, nil
+	}
+
+	iter, err := indexData.Iterator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.VerifyMap(ctx, iter, tbl.Format()); err != nil {
+		return &ConstraintViolation{Table: tblName, Type: violationType, Name: idx.Name(), Message: err.Error()}, nil
+	}
+
+	rebuilt, err := tbl.RebuildIndexRowData(ctx, idx.Name())
+	if err != nil {
+		// rebuilding a unique index fails when the rows themselves hold duplicate keys
+		if idx.IsUnique() {
+			return &ConstraintViolation{Table: tblName, Type: violationType, Name: idx.Name(), Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	if !rebuilt.Equals(indexData) {
+		return &ConstraintViolation{
+			Table:   tblName,
+			Type:    violationType,
+			Name:    idx.Name(),
+			Message: fmt.Sprintf("index `%s` does not match the rows of `%s`", idx.Name(), tblName),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func verifyForeignKeys(ctx context.Context, root *doltdb.RootValue) ([]ConstraintViolation, error) {
+	fkColl, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []ConstraintViolation
+	for _, fk := range fkColl.AllKeys() {
+		message, err := verifyForeignKey(ctx, root, fk)
+		if err != nil {
+			return nil, err
+		}
+		if message != "" {
+			violations = append(violations, ConstraintViolation{
+				Table:   fk.TableName,
+				Type:    ForeignKeyViolation,
+				Name:    fk.Name,
+				Message: message,
+			})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Table < violations[j].Table
+	})
+	return violations, nil
+}
+
+// verifyForeignKey returns a message describing why the foreign key given is violated, or an empty string if it's
+// satisfied.
+func verifyForeignKey(ctx context.Context, root *doltdb.RootValue, fk doltdb.ForeignKey) (string, error) {
+	child, childSch, ok, err := getTableAndSchema(ctx, root, fk.TableName)
+	if err != nil || !ok {
+		return fmt.Sprintf("table `%s` does not exist", fk.TableName), err
+	}
+	parent, parentSch, ok, err := getTableAndSchema(ctx, root, fk.ReferencedTableName)
+	if err != nil || !ok {
+		return fmt.Sprintf("referenced table `%s` does not exist", fk.ReferencedTableName), err
+	}
+	if err := fk.ValidateTableSchema(childSch); err != nil {
+		return err.Error(), nil
+	}
+	if err := fk.ValidateReferencedTableSchema(parentSch); err != nil {
+		return err.Error(), nil
+	}
+
+	childRowData, err := child.GetRowData(ctx)
+	if err != nil {
+		return "", err
+	}
+	childIdxData, err := child.GetIndexRowData(ctx, fk.TableIndex)
+	if err != nil {
+		return "", err
+	}
+	parentIdxData, err := parent.GetIndexRowData(ctx, fk.ReferencedTableIndex)
+	if err != nil {
+		return "", err
+	}
+
+	childIdx := childSch.Indexes().GetByName(fk.TableIndex)
+	parentIdx := parentSch.Indexes().GetByName(fk.ReferencedTableIndex)
+	err = fk.ConstraintIsSatisfied(ctx, child.ValueReadWriter(), childRowData, childIdxData, parentIdxData, childIdx, parentIdx)
+	if err != nil {
+		return err.Error(), nil
+	}
+	return "", nil
+}
+
+func getTableAndSchema(ctx context.Context, root *doltdb.RootValue, tblName string) (*doltdb.Table, schema.Schema, bool, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil || !ok {
+		return nil, nil, ok, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return tbl, sch, true, nil
+}
+
+// formatRowKey returns the primary key values of a row, formatted as a comma separated list of assignments.
+func formatRowKey(ctx context.Context, sch schema.Schema, vals row.TaggedValues) string {
+	var assignments []string
+	_ = sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		str := "NULL"
+		if val, ok := vals[tag]; ok && !types.IsNull(val) {
+			if formatted, err := col.TypeInfo.FormatValue(ctx, val); err == nil && formatted != nil {
+				str = *formatted
+			}
+		}
+		assignments = append(assignments, fmt.Sprintf("%s=%s", col.Name, str))
+		return false, nil
+	})
+	return strings.Join(assignments, ", ")
+}
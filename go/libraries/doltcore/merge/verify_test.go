@@ -0,0 +1,115 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestVerifyRoot(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	vrw := root.VRW()
+
+	idCol := schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{})
+	nameCol := schema.NewColumn("name", 1, types.StringKind, false, schema.NotNullConstraint{})
+	ageCol, err := schema.NewColumnWithTypeInfo("age", 2, typeinfo.Int8Type, false, "", false, "")
+	require.NoError(t, err)
+	colColl, err := schema.NewColCollection(idCol, nameCol, ageCol)
+	require.NoError(t, err)
+	sch, err := schema.SchemaFromCols(colColl)
+	require.NoError(t, err)
+	_, err = sch.Indexes().AddIndexByColTags("idx_age", []uint64{2}, schema.IndexProperties{IsUnique: true, IsUserDefined: true})
+	require.NoError(t, err)
+
+	// the rows are written without the checks made by writes through SQL
+	mustTuple := func(vals ...types.Value) types.Tuple {
+		tpl, err := types.NewTuple(vrw.Format(), vals...)
+		require.NoError(t, err)
+		return tpl
+	}
+	rowData, err := types.NewMap(ctx, vrw,
+		mustTuple(types.Uint(0), types.Int(1)), mustTuple(types.Uint(1), types.String("ada"), types.Uint(2), types.Int(36)),
+		mustTuple(types.Uint(0), types.Int(2)), mustTuple(types.Uint(2), types.Int(300)),
+		mustTuple(types.Uint(0), types.Int(3)), mustTuple(types.Uint(1), types.String("alan"), types.Uint(2), types.Int(37)),
+	)
+	require.NoError(t, err)
+
+	schVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, sch)
+	require.NoError(t, err)
+	tbl, err := doltdb.NewTable(ctx, vrw, schVal, rowData, nil)
+	require.NoError(t, err)
+	tbl, err = tbl.RebuildIndexData(ctx)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, "people", tbl)
+	require.NoError(t, err)
+
+	violations, err := VerifyRoot(ctx, root)
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+	assert.Equal(t, ConstraintViolation{
+		Table:   "people",
+		Type:    NotNullViolation,
+		Name:    "name",
+		Key:     "id=2",
+		Message: "column `name` is NULL but is not nullable",
+	}, violations[0])
+	assert.Equal(t, TypeViolation, violations[1].Type)
+	assert.Equal(t, "age", violations[1].Name)
+	assert.Equal(t, "id=2", violations[1].Key)
+
+	// an index that doesn't match the table's rows
+	emptyIdx, err := types.NewMap(ctx, vrw)
+	require.NoError(t, err)
+	staleTbl, err := tbl.SetIndexRowData(ctx, "idx_age", emptyIdx)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, "people", staleTbl)
+	require.NoError(t, err)
+
+	violations, err = VerifyRoot(ctx, root)
+	require.NoError(t, err)
+	require.Len(t, violations, 3)
+	assert.Equal(t, UniqueIndexViolation, violations[2].Type)
+	assert.Equal(t, "index `idx_age` does not match the rows of `people`", violations[2].Message)
+
+	// rows with duplicate values for a unique index
+	rowData, err = rowData.Edit().Set(
+		mustTuple(types.Uint(0), types.Int(3)), mustTuple(types.Uint(1), types.String("alan"), types.Uint(2), types.Int(36)),
+	).Map(ctx)
+	require.NoError(t, err)
+	dupTbl, err := tbl.UpdateRows(ctx, rowData)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, "people", dupTbl)
+	require.NoError(t, err)
+
+	violations, err = VerifyRoot(ctx, root)
+	require.NoError(t, err)
+	require.Len(t, violations, 3)
+	assert.Equal(t, UniqueIndexViolation, violations[2].Type)
+	assert.Equal(t, "idx_age", violations[2].Name)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
@@ -37,9 +38,6 @@ const (
 	tableWriterGCRate = 2 << 16
 )
 
-// ErrNoPK is an error returned if a schema is missing a required primary key
-var ErrNoPK = errors.New("schema does not contain a primary key")
-
 // TableDataLocation is a dolt table that that can be imported from or exported to.
 type TableDataLocation struct {
 	// Name the name of a table
@@ -92,10 +90,6 @@ func (dl TableDataLocation) NewReader(ctx context.Context, root *doltdb.RootValu
 // NewCreatingWriter will create a TableWriteCloser for a DataLocation that will create a new table, or overwrite
 // an existing table.
 func (dl TableDataLocation) NewCreatingWriter(ctx context.Context, _ DataMoverOptions, dEnv *env.DoltEnv, root *doltdb.RootValue, _ bool, outSch schema.Schema, statsCB noms.StatsCB, useGC bool) (table.TableWriteCloser, error) {
-	if outSch.GetPKCols().Size() == 0 {
-		return nil, ErrNoPK
-	}
-
 	m, err := types.NewMap(ctx, root.VRW())
 	if err != nil {
 		return nil, err
@@ -119,6 +113,16 @@ func (dl TableDataLocation) NewCreatingWriter(ctx context.Context, _ DataMoverOp
 		return nil, err
 	}
 
+	generated, err := expreval.NewGeneratedEvaluator(root.VRW(), outSch)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := expreval.NewCheckEvaluator(outSch)
+	if err != nil {
+		return nil, err
+	}
+
 	return &tableEditorWriteCloser{
 		dEnv:        dEnv,
 		insertOnly:  true,
@@ -126,6 +130,8 @@ func (dl TableDataLocation) NewCreatingWriter(ctx context.Context, _ DataMoverOp
 		statsCB:     statsCB,
 		tableEditor: tableEditor,
 		tableSch:    outSch,
+		generated:   generated,
+		checks:      checks,
 		useGC:       useGC,
 	}, nil
 }
@@ -155,6 +161,16 @@ func (dl TableDataLocation) NewUpdatingWriter(ctx context.Context, _ DataMoverOp
 		return nil, err
 	}
 
+	generated, err := expreval.NewGeneratedEvaluator(root.VRW(), tblSch)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := expreval.NewCheckEvaluator(tblSch)
+	if err != nil {
+		return nil, err
+	}
+
 	return &tableEditorWriteCloser{
 		dEnv:        dEnv,
 		insertOnly:  false,
@@ -162,6 +178,8 @@ func (dl TableDataLocation) NewUpdatingWriter(ctx context.Context, _ DataMoverOp
 		statsCB:     statsCB,
 		tableEditor: tableEditor,
 		tableSch:    tblSch,
+		generated:   generated,
+		checks:      checks,
 		useGC:       useGC,
 	}, nil
 }
@@ -205,6 +223,16 @@ func (dl TableDataLocation) NewReplacingWriter(ctx context.Context, _ DataMoverO
 		return nil, err
 	}
 
+	generated, err := expreval.NewGeneratedEvaluator(root.VRW(), tblSch)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := expreval.NewCheckEvaluator(tblSch)
+	if err != nil {
+		return nil, err
+	}
+
 	return &tableEditorWriteCloser{
 		dEnv:        dEnv,
 		insertOnly:  true,
@@ -212,6 +240,8 @@ func (dl TableDataLocation) NewReplacingWriter(ctx context.Context, _ DataMoverO
 		statsCB:     statsCB,
 		tableEditor: tableEditor,
 		tableSch:    tblSch,
+		generated:   generated,
+		checks:      checks,
 		useGC:       useGC,
 	}, nil
 }
@@ -221,6 +251,8 @@ type tableEditorWriteCloser struct {
 	tableEditor *doltdb.SessionedTableEditor
 	initialData types.Map
 	tableSch    schema.Schema
+	generated   *expreval.GeneratedEvaluator
+	checks      *expreval.CheckEvaluator
 	insertOnly  bool
 	useGC       bool
 
@@ -256,7 +288,24 @@ func (te *tableEditorWriteCloser) WriteRow(ctx context.Context, r row.Row) error
 	}
 	_ = atomic.AddInt64(&te.gcOps, 1)
 
-	if te.insertOnly {
+	generatedRow, err := te.generated.Generate(ctx, r)
+	if err != nil {
+		if expreval.ErrGeneratedColumnNull.Is(err) {
+			return table.NewBadRow(r, err.Error())
+		}
+		return err
+	}
+	r = generatedRow
+
+	if err := te.checks.Check(ctx, r); err != nil {
+		if expreval.ErrCheckConstraintViolated.Is(err) {
+			return table.NewBadRow(r, err.Error())
+		}
+		return err
+	}
+
+	// rows of keyless tables can't be matched to the rows they update, so they are always appended
+	if te.insertOnly || schema.IsKeyless(te.tableSch) {
 		_ = atomic.AddInt64(&te.statOps, 1)
 		te.stats.Additions++
 		return te.tableEditor.InsertRow(ctx, r)
@@ -290,6 +290,7 @@ func replayCommitWithNewTag(ctx context.Context, root, parentRoot, rebasedParent
 				return nil, err
 			}
 		}
+		rebasedSch.Checks().AddChecks(sch.Checks().AllChecks()...)
 
 		// super schema rebase
 		ss, _, err := root.GetSuperSchema(ctx, tblName)
@@ -499,13 +500,14 @@ func dropValsForDeletedColumns(ctx context.Context, nbf *types.NomsBinFormat, ro
 			return types.EmptyMap, err
 		}
 
+		valCols := schema.ValueCols(sch)
 		for valtag := range vtv {
-			if _, found := sch.GetNonPKCols().GetByTag(valtag); !found {
+			if _, found := valCols.GetByTag(valtag); !found {
 				delete(vtv, valtag)
 			}
 		}
 
-		re.Set(k, vtv.NomsTupleForNonPKCols(nbf, sch.GetNonPKCols()))
+		re.Set(k, vtv.NomsTupleForNonPKCols(nbf, valCols))
 	}
 
 	prunedRowData, err := re.Map(ctx)
@@ -555,7 +557,7 @@ func modifyDifferenceTag(d *ndiff.Difference, nbf *types.NomsBinFormat, rSch sch
 
 			newTv[newTag] = val
 		}
-		valTup = newTv.NomsTupleForNonPKCols(nbf, rSch.GetNonPKCols())
+		valTup = newTv.NomsTupleForNonPKCols(nbf, schema.ValueCols(rSch))
 	}
 
 	return keyTup, valTup, nil
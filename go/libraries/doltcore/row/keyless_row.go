@@ -0,0 +1,237 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package row
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrKeylessIndex is returned when attempting to index the rows of a keyless table.
+var ErrKeylessIndex = errors.New("indexes are not supported on tables without a primary key")
+
+// keylessRow is a row of a table without a primary key. Such rows are keyed by an id derived from a hash of their
+// contents, so that identical rows share a single entry in the table's row data. The number of identical rows is
+// stored alongside the column values as the row's cardinality.
+type keylessRow struct {
+	// id is the row id read from storage, or the id computed from the row's values for new rows.
+	id   types.UUID
+	vals TaggedValues
+	card uint64
+	nbf  *types.NomsBinFormat
+}
+
+var _ Row = keylessRow{}
+
+func newKeylessRow(nbf *types.NomsBinFormat, sch schema.Schema, colVals TaggedValues, card uint64) (Row, error) {
+	allCols := sch.GetAllCols()
+
+	vals := make(TaggedValues, len(colVals))
+	_, err := colVals.Iter(func(tag uint64, val types.Value) (stop bool, err error) {
+		col, ok := allCols.GetByTag(tag)
+
+		if !ok {
+			return false, errors.New("Trying to set a value on an unknown tag is a bug.  Validation should happen upstream.")
+		} else if !types.IsNull(val) && col.Kind != val.Kind() {
+			return false, errors.New("bug.  Setting a value to an incorrect kind. col:" + col.Name)
+		}
+
+		vals[tag] = val
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := keylessRowId(nbf, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return keylessRow{id: id, vals: vals, card: card, nbf: nbf}, nil
+}
+
+func keylessRowFromTupleSlices(nbf *types.NomsBinFormat, sch schema.Schema, keySl, valSl types.TupleValueSlice) (Row, error) {
+	if len(keySl) != 2 || keySl[0] != types.Uint(schema.KeylessRowIdTag) {
+		return nil, errors.New("bug.  Reading a keyed row with a keyless schema.")
+	}
+
+	id, ok := keySl[1].(types.UUID)
+	if !ok {
+		return nil, errors.New("bug.  Invalid row id for a keyless row.")
+	}
+
+	allCols := sch.GetAllCols()
+
+	var card uint64
+	vals := make(TaggedValues, len(valSl)/2)
+	err := valSl.Iter(func(tag uint64, val types.Value) (stop bool, err error) {
+		if tag == schema.KeylessRowCardinalityTag {
+			c, ok := val.(types.Uint)
+			if !ok {
+				return false, errors.New("bug.  Invalid cardinality for a keyless row.")
+			}
+			card = uint64(c)
+			return false, nil
+		}
+
+		col, ok := allCols.GetByTag(tag)
+		if !ok || types.IsNull(val) {
+			return false, nil
+		}
+
+		if col.Kind != val.Kind() {
+			return false, errors.New("bug.  Setting a value to an incorrect kind. col:" + col.Name)
+		}
+
+		vals[tag] = val
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keylessRow{id: id, vals: vals, card: card, nbf: nbf}, nil
+}
+
+// keylessRowId returns the id of a keyless row with the values given, which is derived from a hash of the non-null
+// values.
+func keylessRowId(nbf *types.NomsBinFormat, vals TaggedValues) (types.UUID, error) {
+	tags := make([]uint64, 0, len(vals))
+	for tag, val := range vals {
+		if !types.IsNull(val) {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+
+	contents := vals.nomsTupleForTags(nbf, tags, false)
+	tpl, err := types.NewTuple(nbf, contents.vs...)
+
+	if err != nil {
+		return types.UUID{}, err
+	}
+
+	h, err := tpl.Hash(nbf)
+
+	if err != nil {
+		return types.UUID{}, err
+	}
+
+	var id types.UUID
+	copy(id[:], h[:])
+	return id, nil
+}
+
+func (kr keylessRow) NomsMapKey(sch schema.Schema) types.LesserValuable {
+	return TupleVals{[]types.Value{types.Uint(schema.KeylessRowIdTag), kr.id}, kr.nbf}
+}
+
+func (kr keylessRow) NomsMapValue(sch schema.Schema) types.Valuable {
+	colVals := kr.vals.nomsTupleForTags(kr.nbf, sch.GetNonPKCols().SortedTags, false)
+	vals := make([]types.Value, 0, len(colVals.vs)+2)
+	vals = append(vals, types.Uint(schema.KeylessRowCardinalityTag), types.Uint(kr.card))
+	vals = append(vals, colVals.vs...)
+	return TupleVals{vals, kr.nbf}
+}
+
+func (kr keylessRow) IterCols(cb func(tag uint64, val types.Value) (stop bool, err error)) (bool, error) {
+	return kr.vals.Iter(cb)
+}
+
+func (kr keylessRow) IterSchema(sch schema.Schema, cb func(tag uint64, val types.Value) (stop bool, err error)) (bool, error) {
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (bool, error) {
+		value, _ := kr.GetColVal(tag)
+		return cb(tag, value)
+	})
+
+	return false, err
+}
+
+func (kr keylessRow) GetColVal(tag uint64) (types.Value, bool) {
+	return kr.vals.Get(tag)
+}
+
+func (kr keylessRow) SetColVal(tag uint64, val types.Value, sch schema.Schema) (Row, error) {
+	if _, ok := sch.GetAllCols().GetByTag(tag); !ok {
+		return nil, errors.New("can't set a column whose tag isn't in the schema.  verify before calling this function.")
+	}
+
+	// the row's contents change, and with them its id
+	vals := kr.vals.Set(tag, val)
+	id, err := keylessRowId(kr.nbf, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return keylessRow{id: id, vals: vals, card: kr.card, nbf: kr.nbf}, nil
+}
+
+func (kr keylessRow) ReduceToIndex(idx schema.Index) (Row, error) {
+	return nil, ErrKeylessIndex
+}
+
+func (kr keylessRow) ReduceToIndexPartialKey(idx schema.Index) (types.Tuple, error) {
+	return types.EmptyTuple(kr.nbf), ErrKeylessIndex
+}
+
+func (kr keylessRow) Format() *types.NomsBinFormat {
+	return kr.nbf
+}
+
+// GetCardinality returns the number of identical rows represented by the row given. Rows of tables with a primary key
+// are always unique.
+func GetCardinality(r Row) uint64 {
+	if kr, ok := r.(keylessRow); ok {
+		return kr.card
+	}
+	return 1
+}
+
+// WithCardinality returns a copy of the keyless row given representing |card| identical rows. Rows of tables with a
+// primary key are returned unchanged.
+func WithCardinality(r Row, card uint64) Row {
+	if kr, ok := r.(keylessRow); ok {
+		kr.card = card
+		return kr
+	}
+	return r
+}
+
+// ParseCardinality returns the cardinality stored in the noms map value of a row of a keyless table.
+func ParseCardinality(nomsVal types.Tuple) (uint64, error) {
+	tag, err := nomsVal.Get(0)
+	if err != nil {
+		return 0, err
+	}
+	if tag != types.Uint(schema.KeylessRowCardinalityTag) {
+		return 0, errors.New("bug.  Reading the cardinality of a keyed row.")
+	}
+
+	card, err := nomsVal.Get(1)
+	if err != nil {
+		return 0, err
+	}
+	c, ok := card.(types.Uint)
+	if !ok {
+		return 0, errors.New("bug.  Invalid cardinality for a keyless row.")
+	}
+	return uint64(c), nil
+}
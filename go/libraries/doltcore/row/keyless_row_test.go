@@ -0,0 +1,89 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package row
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var keylessSch = schema.MustSchemaFromCols(testNonKeyColColl)
+
+func newKeylessTestRow(t *testing.T, vals TaggedValues) Row {
+	r, err := New(types.Format_7_18, keylessSch, vals)
+	require.NoError(t, err)
+	return r
+}
+
+func keylessNoms(t *testing.T, r Row) (types.Tuple, types.Tuple) {
+	ctx := context.Background()
+	key, err := r.NomsMapKey(keylessSch).Value(ctx)
+	require.NoError(t, err)
+	val, err := r.NomsMapValue(keylessSch).Value(ctx)
+	require.NoError(t, err)
+	return key.(types.Tuple), val.(types.Tuple)
+}
+
+func TestKeylessRowIds(t *testing.T) {
+	r1 := newKeylessTestRow(t, TaggedValues{addrColTag: addrVal, ageColTag: ageVal})
+	r2 := newKeylessTestRow(t, TaggedValues{ageColTag: ageVal, addrColTag: addrVal, titleColTag: types.NullValue})
+	r3 := newKeylessTestRow(t, TaggedValues{addrColTag: addrVal, ageColTag: types.Uint(54)})
+
+	k1, _ := keylessNoms(t, r1)
+	k2, _ := keylessNoms(t, r2)
+	k3, _ := keylessNoms(t, r3)
+
+	assert.True(t, k1.Equals(k2), "identical rows should have the same id")
+	assert.False(t, k1.Equals(k3), "different rows should have different ids")
+
+	updated, err := r1.SetColVal(ageColTag, types.Uint(54), keylessSch)
+	require.NoError(t, err)
+	updatedKey, _ := keylessNoms(t, updated)
+	assert.True(t, updatedKey.Equals(k3), "updating a row's values should update its id")
+
+	_, err = r1.SetColVal(unusedTag, types.Uint(54), keylessSch)
+	assert.Error(t, err)
+}
+
+func TestKeylessRowRoundTrip(t *testing.T) {
+	r := newKeylessTestRow(t, TaggedValues{addrColTag: addrVal, ageColTag: ageVal})
+	assert.Equal(t, uint64(1), GetCardinality(r))
+
+	r = WithCardinality(r, 3)
+	assert.Equal(t, uint64(3), GetCardinality(r))
+
+	key, val := keylessNoms(t, r)
+	card, err := ParseCardinality(val)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), card)
+
+	read, err := FromNoms(keylessSch, key, val)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), GetCardinality(read))
+	assert.True(t, AreEqual(r, read, keylessSch))
+
+	readKey, readVal := keylessNoms(t, read)
+	assert.True(t, key.Equals(readKey))
+	assert.True(t, val.Equals(readVal))
+
+	_, err = read.ReduceToIndex(index)
+	assert.Equal(t, ErrKeylessIndex, err)
+}
@@ -88,7 +88,13 @@ func (nr nomsRow) Format() *types.NomsBinFormat {
 	return nr.nbf
 }
 
+// New returns a new row for the schema given with the values given. Rows of keyless schemas are created with a
+// cardinality of 1.
 func New(nbf *types.NomsBinFormat, sch schema.Schema, colVals TaggedValues) (Row, error) {
+	if schema.IsKeyless(sch) {
+		return newKeylessRow(nbf, sch, colVals, 1)
+	}
+
 	allCols := sch.GetAllCols()
 
 	keyVals := make(TaggedValues)
@@ -165,6 +171,10 @@ func fromTaggedVals(nbf *types.NomsBinFormat, sch schema.Schema, keyVals, nonKey
 }
 
 func FromTupleSlices(nbf *types.NomsBinFormat, sch schema.Schema, keySl, valSl types.TupleValueSlice) (Row, error) {
+	if schema.IsKeyless(sch) {
+		return keylessRowFromTupleSlices(nbf, sch, keySl, valSl)
+	}
+
 	allCols := sch.GetAllCols()
 
 	err := keySl.Iter(func(tag uint64, val types.Value) (stop bool, err error) {
@@ -174,7 +184,7 @@ func FromTupleSlices(nbf *types.NomsBinFormat, sch schema.Schema, keySl, valSl t
 			return false, errors.New("Trying to set a value on an unknown tag is a bug for the key.  Validation should happen upstream. col:" + col.Name)
 		} else if !col.IsPartOfPK {
 			return false, errors.New("writing columns that are not part of the primary key to pk values. col:" + col.Name)
-		} else if !types.IsNull(val) && col.Kind != val.Kind() {
+		} else if !types.IsNull(val) && col.Kind != val.Kind() && !schema.UsesCollationKeys(col) {
 			return false, errors.New("bug.  Setting a value to an incorrect kind. col: " + col.Name)
 		}
 
@@ -185,6 +195,12 @@ func FromTupleSlices(nbf *types.NomsBinFormat, sch schema.Schema, keySl, valSl t
 		return nil, err
 	}
 
+	taggedKeyVals, err := TaggedValuesFromTupleValueSlice(keySl)
+
+	if err != nil {
+		return nil, err
+	}
+
 	filteredVals := make(TaggedValues, len(valSl))
 	err = valSl.Iter(func(tag uint64, val types.Value) (stop bool, err error) {
 		col, ok := allCols.GetByTag(tag)
@@ -193,7 +209,11 @@ func FromTupleSlices(nbf *types.NomsBinFormat, sch schema.Schema, keySl, valSl t
 		}
 
 		if col.IsPartOfPK {
-			return false, errors.New("writing columns that are part of the primary key to non-pk values. col:" + col.Name)
+			if keyVal, ok := taggedKeyVals[tag]; !ok || keyVal.Kind() != types.InlineBlobKind || !schema.UsesCollationKeys(col) {
+				return false, errors.New("writing columns that are part of the primary key to non-pk values. col:" + col.Name)
+			}
+			// the values of collated primary key columns are kept in the value, as the key holds their collation keys
+			taggedKeyVals[tag] = val
 		} else if !types.IsNull(val) {
 			if col.Kind != val.Kind() {
 				return false, errors.New("bug.  Setting a value to an incorrect kind. col:" + col.Name)
@@ -209,12 +229,6 @@ func FromTupleSlices(nbf *types.NomsBinFormat, sch schema.Schema, keySl, valSl t
 		return nil, err
 	}
 
-	taggedKeyVals, err := TaggedValuesFromTupleValueSlice(keySl)
-
-	if err != nil {
-		return nil, err
-	}
-
 	return nomsRow{taggedKeyVals, filteredVals, nbf}, nil
 }
 
@@ -241,7 +255,8 @@ func (nr nomsRow) ReduceToIndex(idx schema.Index) (Row, error) {
 		nbf:   nr.nbf,
 	}
 
-	for _, tag := range idx.AllTags() {
+	indexedCount := idx.Count()
+	for i, tag := range idx.AllTags() {
 		val, ok := nr.key[tag]
 		if !ok {
 			val, ok = nr.value[tag]
@@ -249,6 +264,10 @@ func (nr nomsRow) ReduceToIndex(idx schema.Index) (Row, error) {
 				continue
 			}
 		}
+		// the primary key columns which follow the indexed columns are kept as they are
+		if col, ok := idx.GetColumn(tag); ok && i < indexedCount {
+			val = schema.IndexKeyValue(col, val)
+		}
 		newRow.key[tag] = val
 	}
 
@@ -265,6 +284,9 @@ func (nr nomsRow) ReduceToIndexPartialKey(idx schema.Index) (types.Tuple, error)
 				val = types.NullValue
 			}
 		}
+		if col, ok := idx.GetColumn(tag); ok {
+			val = schema.IndexKeyValue(col, val)
+		}
 		vals = append(vals, types.Uint(tag), val)
 	}
 	return types.NewTuple(nr.nbf, vals...)
@@ -275,5 +297,19 @@ func (nr nomsRow) NomsMapKey(sch schema.Schema) types.LesserValuable {
 }
 
 func (nr nomsRow) NomsMapValue(sch schema.Schema) types.Valuable {
-	return nr.value.NomsTupleForNonPKCols(nr.nbf, sch.GetNonPKCols())
+	valCols := schema.ValueCols(sch)
+	if valCols == sch.GetNonPKCols() {
+		return nr.value.NomsTupleForNonPKCols(nr.nbf, valCols)
+	}
+
+	// collated primary key columns are represented by their collation keys in the row's key, so their values are kept
+	// in the row's value
+	vals := nr.value.copy()
+	for _, tag := range valCols.Tags {
+		if val, ok := nr.key[tag]; ok {
+			vals[tag] = val
+		}
+	}
+
+	return vals.NomsTupleForNonPKCols(nr.nbf, valCols)
 }
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -341,6 +343,43 @@ func TestConvToAndFromTuple(t *testing.T) {
 	}
 }
 
+func TestConvToAndFromTupleCollatedKey(t *testing.T) {
+	ctx := context.Background()
+
+	ti, err := typeinfo.FromSqlType(sql.MustCreateString(sqltypes.VarChar, 20, sql.Collation_utf8mb4_general_ci))
+	require.NoError(t, err)
+	lnCol, err := schema.NewColumnWithTypeInfo(lnColName, lnColTag, ti, true, "", false, "")
+	require.NoError(t, err)
+	addrCol, err := schema.NewColumnWithTypeInfo(addrColName, addrColTag, typeinfo.StringDefaultType, false, "", false, "")
+	require.NoError(t, err)
+	colColl, err := schema.NewColCollection(lnCol, addrCol)
+	require.NoError(t, err)
+	collatedSch := schema.MustSchemaFromCols(colColl)
+
+	r, err := New(types.Format_7_18, collatedSch, TaggedValues{
+		lnColTag:   types.String("Go"),
+		addrColTag: types.String("here"),
+	})
+	require.NoError(t, err)
+	other, err := New(types.Format_7_18, collatedSch, TaggedValues{
+		lnColTag:   types.String("go"),
+		addrColTag: types.String("there"),
+	})
+	require.NoError(t, err)
+
+	keyVal, err := r.NomsMapKey(collatedSch).Value(ctx)
+	require.NoError(t, err)
+	otherKeyVal, err := other.NomsMapKey(collatedSch).Value(ctx)
+	require.NoError(t, err)
+	assert.True(t, keyVal.Equals(otherKeyVal), "values equal according to the collation should share a key")
+
+	valVal, err := r.NomsMapValue(collatedSch).Value(ctx)
+	require.NoError(t, err)
+	r2, err := FromNoms(collatedSch, keyVal.(types.Tuple), valVal.(types.Tuple))
+	require.NoError(t, err)
+	assert.True(t, AreEqual(r, r2, collatedSch))
+}
+
 func TestReduceToIndex(t *testing.T) {
 	taggedValues := []struct {
 		row           TaggedValues
@@ -62,8 +62,14 @@ func (tvs TupleVals) Less(nbf *types.NomsBinFormat, other types.LesserValuable)
 	return types.TupleKind < other.Kind(), nil
 }
 
+// NomsTupleForPKCols returns the key tuple of the primary key columns given. Collated string columns are represented by
+// their collation keys, so that keys which are equal according to their collations identify the same row.
 func (tt TaggedValues) NomsTupleForPKCols(nbf *types.NomsBinFormat, pkCols *schema.ColCollection) TupleVals {
-	return tt.nomsTupleForTags(nbf, pkCols.Tags, true)
+	tpl := tt.nomsTupleForTags(nbf, pkCols.Tags, true)
+	for i, tag := range pkCols.Tags {
+		tpl.vs[i*2+1] = schema.IndexKeyValue(pkCols.TagToCol[tag], tpl.vs[i*2+1])
+	}
+	return tpl
 }
 
 func (tt TaggedValues) NomsTupleForNonPKCols(nbf *types.NomsBinFormat, nonPKCols *schema.ColCollection) TupleVals {
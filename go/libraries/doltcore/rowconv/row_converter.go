@@ -15,6 +15,7 @@
 package rowconv
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
@@ -39,8 +40,9 @@ func newIdentityConverter(mapping *FieldMapping) *RowConverter {
 	return &RowConverter{mapping, true, nil}
 }
 
-// NewRowConverter creates a row converter from a given FieldMapping.
-func NewRowConverter(mapping *FieldMapping) (*RowConverter, error) {
+// NewRowConverter creates a row converter from a given FieldMapping. Converted values which are stored out-of-line are
+// written to the ValueReadWriter given.
+func NewRowConverter(ctx context.Context, vrw types.ValueReadWriter, mapping *FieldMapping) (*RowConverter, error) {
 	if nec, err := isNecessary(mapping.SrcSch, mapping.DestSch, mapping.SrcToDest); err != nil {
 		return nil, err
 	} else if !nec {
@@ -60,10 +62,9 @@ func NewRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
 				return v, nil
 			}
-		}
-		if typeinfo.IsStringType(destCol.TypeInfo) {
+		} else if typeinfo.IsStringType(destCol.TypeInfo) {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				val, err := srcCol.TypeInfo.FormatValue(v)
+				val, err := srcCol.TypeInfo.FormatValue(ctx, v)
 				if err != nil {
 					return nil, err
 				}
@@ -74,7 +75,7 @@ func NewRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 			}
 		} else {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				return typeinfo.Convert(v, srcCol.TypeInfo, destCol.TypeInfo)
+				return typeinfo.Convert(ctx, vrw, v, srcCol.TypeInfo, destCol.TypeInfo)
 			}
 		}
 	}
@@ -83,7 +84,7 @@ func NewRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 }
 
 // NewImportRowConverter creates a row converter from a given FieldMapping specifically for importing.
-func NewImportRowConverter(mapping *FieldMapping) (*RowConverter, error) {
+func NewImportRowConverter(ctx context.Context, vrw types.ValueReadWriter, mapping *FieldMapping) (*RowConverter, error) {
 	if nec, err := isNecessary(mapping.SrcSch, mapping.DestSch, mapping.SrcToDest); err != nil {
 		return nil, err
 	} else if !nec {
@@ -103,10 +104,9 @@ func NewImportRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
 				return v, nil
 			}
-		}
-		if typeinfo.IsStringType(destCol.TypeInfo) {
+		} else if typeinfo.IsStringType(destCol.TypeInfo) {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				val, err := srcCol.TypeInfo.FormatValue(v)
+				val, err := srcCol.TypeInfo.FormatValue(ctx, v)
 				if err != nil {
 					return nil, err
 				}
@@ -118,15 +118,15 @@ func NewImportRowConverter(mapping *FieldMapping) (*RowConverter, error) {
 		} else if destCol.TypeInfo.Equals(typeinfo.PseudoBoolType) || destCol.TypeInfo.Equals(typeinfo.Int8Type) {
 			// BIT(1) and BOOLEAN (MySQL alias for TINYINT or Int8) are both logical stand-ins for a bool type
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				intermediateVal, err := typeinfo.Convert(v, srcCol.TypeInfo, typeinfo.BoolType)
+				intermediateVal, err := typeinfo.Convert(ctx, vrw, v, srcCol.TypeInfo, typeinfo.BoolType)
 				if err != nil {
 					return nil, err
 				}
-				return typeinfo.Convert(intermediateVal, typeinfo.BoolType, destCol.TypeInfo)
+				return typeinfo.Convert(ctx, vrw, intermediateVal, typeinfo.BoolType, destCol.TypeInfo)
 			}
 		} else {
 			convFuncs[srcTag] = func(v types.Value) (types.Value, error) {
-				return typeinfo.Convert(v, srcCol.TypeInfo, destCol.TypeInfo)
+				return typeinfo.Convert(ctx, vrw, v, srcCol.TypeInfo, destCol.TypeInfo)
 			}
 		}
 	}
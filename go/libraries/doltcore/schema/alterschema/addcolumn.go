@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
@@ -87,8 +88,6 @@ func updateTableWithNewSchema(ctx context.Context, tblName string, tbl *doltdb.T
 		return doltdb.NewTable(ctx, vrw, newSchemaVal, rowData, &indexData)
 	}
 
-	me := rowData.Edit()
-
 	newSqlSchema, err := sqlutil.FromDoltSchema(tblName, newSchema)
 	if err != nil {
 		return nil, err
@@ -104,12 +103,39 @@ func updateTableWithNewSchema(ctx context.Context, tblName string, tbl *doltdb.T
 		return nil, fmt.Errorf("could not find tag `%d` in new schema", tag)
 	}
 
+	// the rows of keyless tables are keyed by their contents, which now include the default value
+	if schema.IsKeyless(newSchema) {
+		oldSchema, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := rebuildKeylessRowData(ctx, vrw, rowData, oldSchema, newSchema, func(vals row.TaggedValues) (row.TaggedValues, error) {
+			oldRow, err := row.New(rowData.Format(), newSchema, vals)
+			if err != nil {
+				return nil, err
+			}
+			newRow, err := sqlutil.ApplyDefaults(ctx, vrw, newSchema, newSqlSchema, []int{columnIndex}, oldRow)
+			if err != nil {
+				return nil, err
+			}
+			return row.GetTaggedVals(newRow)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return doltdb.NewTable(ctx, vrw, newSchemaVal, m, &indexData)
+	}
+
+	me := rowData.Edit()
+
 	err = rowData.Iter(ctx, func(k, v types.Value) (stop bool, err error) {
 		oldRow, _, err := tbl.GetRow(ctx, k.(types.Tuple), newSchema)
 		if err != nil {
 			return true, err
 		}
-		newRow, err := sqlutil.ApplyDefaults(ctx, newSchema, newSqlSchema, []int{columnIndex}, oldRow)
+		newRow, err := sqlutil.ApplyDefaults(ctx, vrw, newSchema, newSqlSchema, []int{columnIndex}, oldRow)
 		if err != nil {
 			return true, err
 		}
@@ -161,6 +187,7 @@ func addColumnToSchema(sch schema.Schema, tag uint64, newColName string, typeInf
 		return nil, err
 	}
 	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	newSch.Checks().AddChecks(sch.Checks().AllChecks()...)
 
 	return newSch, nil
 }
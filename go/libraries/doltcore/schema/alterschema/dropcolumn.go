@@ -93,6 +93,7 @@ func DropColumn(ctx context.Context, tbl *doltdb.Table, colName string, foreignK
 		return nil, err
 	}
 	newSch.Indexes().AddIndex(tblSch.Indexes().AllIndexes()...)
+	newSch.Checks().AddChecks(tblSch.Checks().AllChecks()...)
 
 	vrw := tbl.ValueReadWriter()
 	schemaVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, newSch)
@@ -102,6 +103,17 @@ func DropColumn(ctx context.Context, tbl *doltdb.Table, colName string, foreignK
 	}
 
 	rd, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows of keyless tables are keyed by their contents, which no longer include the dropped column
+	if schema.IsKeyless(newSch) {
+		rd, err = rebuildKeylessRowData(ctx, vrw, rd, tblSch, newSch, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	indexData, err := tbl.GetIndexData(ctx)
 	if err != nil {
@@ -0,0 +1,123 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// rebuildKeylessRowData returns the row data given, read with the old schema, as the row data of a keyless table with
+// the new schema. The values of each row are passed through |rebuild| if it is not nil, and each row is keyed by the
+// hash of its new contents. Rows which become identical are merged into a single row, summing their cardinalities.
+func rebuildKeylessRowData(
+	ctx context.Context,
+	vrw types.ValueReadWriter,
+	rowData types.Map,
+	oldSch, newSch schema.Schema,
+	rebuild func(vals row.TaggedValues) (row.TaggedValues, error),
+) (types.Map, error) {
+	newRow := func(key, value types.Value) (row.Row, uint64, error) {
+		oldRow, err := row.FromNoms(oldSch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		vals, err := row.GetTaggedVals(oldRow)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// drop the values of any columns removed from the schema
+		for tag := range vals {
+			if _, ok := newSch.GetAllCols().GetByTag(tag); !ok {
+				delete(vals, tag)
+			}
+		}
+
+		if rebuild != nil {
+			vals, err = rebuild(vals)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
+		r, err := row.New(rowData.Format(), newSch, vals)
+		if err != nil {
+			return nil, 0, err
+		}
+		return r, row.GetCardinality(oldRow), nil
+	}
+
+	// Rows which become identical are only known once every row has been rebuilt, so the first pass sums the
+	// cardinality of each new row and the second writes them.
+	cards := make(map[hash.Hash]uint64)
+	err := rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, card, err := newRow(key, value)
+		if err != nil {
+			return true, err
+		}
+
+		h, err := keyHash(ctx, r, newSch)
+		if err != nil {
+			return true, err
+		}
+
+		cards[h] += card
+		return false, nil
+	})
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	emptyMap, err := types.NewMap(ctx, vrw)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+	me := emptyMap.Edit()
+
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, _, err := newRow(key, value)
+		if err != nil {
+			return true, err
+		}
+
+		h, err := keyHash(ctx, r, newSch)
+		if err != nil {
+			return true, err
+		}
+
+		r = row.WithCardinality(r, cards[h])
+		me.Set(r.NomsMapKey(newSch), r.NomsMapValue(newSch))
+		return false, nil
+	})
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	return me.Map(ctx)
+}
+
+func keyHash(ctx context.Context, r row.Row, sch schema.Schema) (hash.Hash, error) {
+	key, err := r.NomsMapKey(sch).Value(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return key.Hash(r.Format())
+}
@@ -23,11 +23,24 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+// TypeConversion controls how existing values are handled when a column's type is changed.
+type TypeConversion int
+
+const (
+	// StrictConversion fails the modification if any existing value cannot be represented in the new type.
+	StrictConversion TypeConversion = iota
+	// LossyConversion replaces values that cannot be represented in the new type with NULL, or with the zero value of
+	// the new type when the column does not accept NULLs.
+	LossyConversion
+)
+
 // ModifyColumn modifies the column with the name given, replacing it with the new definition provided. A column with
-// the name given must exist in the schema of the table.
+// the name given must exist in the schema of the table. If the type of the column changes, existing values are
+// converted using StrictConversion.
 func ModifyColumn(
 	ctx context.Context,
 	tbl *doltdb.Table,
@@ -35,6 +48,20 @@ func ModifyColumn(
 	newCol schema.Column,
 	order *ColumnOrder,
 ) (*doltdb.Table, error) {
+	return ModifyColumnWithConversion(ctx, tbl, existingCol, newCol, order, StrictConversion)
+}
+
+// ModifyColumnWithConversion is the same as ModifyColumn, but converts the existing values of a column whose type
+// changes using the conversion mode given. The column keeps its tag, so its history remains connected across the
+// type change.
+func ModifyColumnWithConversion(
+	ctx context.Context,
+	tbl *doltdb.Table,
+	existingCol schema.Column,
+	newCol schema.Column,
+	order *ColumnOrder,
+	conversion TypeConversion,
+) (*doltdb.Table, error) {
 
 	sch, err := tbl.GetSchema(ctx)
 	if err != nil {
@@ -55,14 +82,27 @@ func ModifyColumn(
 		return nil, err
 	}
 
-	updatedTable, err := updateTableWithModifiedColumn(ctx, tbl, newSchema, newCol)
+	typeChanged := existingCol.Kind != newCol.Kind || !existingCol.TypeInfo.Equals(newCol.TypeInfo)
+
+	// columns created before collations were supported start using their collation once they're modified
+	collationChanged := typeinfo.IsCollated(existingCol.TypeInfo) != typeinfo.IsCollated(newCol.TypeInfo)
+
+	// primary key columns whose collation changes are re-keyed along with columns whose type changes, as collated
+	// primary key columns are represented by their collation keys in row keys
+	rowsChanged := typeChanged || collationChanged && existingCol.IsPartOfPK
+
+	updatedTable, err := updateTableWithModifiedColumn(ctx, tbl, sch, newSchema, existingCol, newCol, rowsChanged, conversion)
 	if err != nil {
 		return nil, err
 	}
 
-	if !newCol.TypeInfo.Equals(existingCol.TypeInfo) ||
-		newCol.IsNullable() != existingCol.IsNullable() {
-		for _, index := range sch.Indexes().IndexesWithTag(existingCol.Tag) {
+	if typeChanged || collationChanged || newCol.IsNullable() != existingCol.IsNullable() {
+		indexes := sch.Indexes().IndexesWithTag(existingCol.Tag)
+		if rowsChanged && existingCol.IsPartOfPK {
+			// every index row embeds the primary key
+			indexes = sch.Indexes().AllIndexes()
+		}
+		for _, index := range indexes {
 			rebuiltIndexData, err := updatedTable.RebuildIndexRowData(ctx, index.Name())
 			if err != nil {
 				return nil, err
@@ -85,7 +125,10 @@ func validateModifyColumn(ctx context.Context, tbl *doltdb.Table, existingCol sc
 	}
 
 	if existingCol.Kind != modifiedCol.Kind || !existingCol.TypeInfo.Equals(modifiedCol.TypeInfo) {
-		return errors.New("unsupported feature: column types cannot be changed")
+		if existingCol.TypeInfo.GetTypeIdentifier() == typeinfo.UnknownTypeIdentifier ||
+			modifiedCol.TypeInfo.GetTypeIdentifier() == typeinfo.UnknownTypeIdentifier {
+			return errors.New("unsupported feature: column types of unknown type cannot be changed")
+		}
 	}
 
 	cols := sch.GetAllCols()
@@ -106,9 +149,16 @@ func validateModifyColumn(ctx context.Context, tbl *doltdb.Table, existingCol sc
 	return nil
 }
 
-// updateTableWithModifiedColumn updates the existing table with the new schema. No data is changed.
-// TODO: type changes
-func updateTableWithModifiedColumn(ctx context.Context, tbl *doltdb.Table, newSchema schema.Schema, modifiedCol schema.Column) (*doltdb.Table, error) {
+// updateTableWithModifiedColumn updates the existing table with the new schema. If the type of the column changed,
+// every row is rewritten with its value converted to the new type.
+func updateTableWithModifiedColumn(
+	ctx context.Context,
+	tbl *doltdb.Table,
+	oldSchema, newSchema schema.Schema,
+	oldCol, modifiedCol schema.Column,
+	typeChanged bool,
+	conversion TypeConversion,
+) (*doltdb.Table, error) {
 	vrw := tbl.ValueReadWriter()
 	newSchemaVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, newSchema)
 	if err != nil {
@@ -120,6 +170,13 @@ func updateTableWithModifiedColumn(ctx context.Context, tbl *doltdb.Table, newSc
 		return nil, err
 	}
 
+	if typeChanged {
+		rowData, err = convertRowData(ctx, vrw, rowData, oldSchema, newSchema, oldCol, modifiedCol, conversion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Iterate over the rows in the table, checking for nils (illegal if the column is declared not null)
 	if !modifiedCol.IsNullable() {
 		err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
@@ -148,6 +205,110 @@ func updateTableWithModifiedColumn(ctx context.Context, tbl *doltdb.Table, newSc
 	return doltdb.NewTable(ctx, vrw, newSchemaVal, rowData, &indexData)
 }
 
+// convertRowData returns the row data given with the values of the modified column converted to its new type. When
+// the column is part of the primary key the map is rebuilt, as converted keys may sort differently.
+func convertRowData(
+	ctx context.Context,
+	vrw types.ValueReadWriter,
+	rowData types.Map,
+	oldSchema, newSchema schema.Schema,
+	oldCol, modifiedCol schema.Column,
+	conversion TypeConversion,
+) (types.Map, error) {
+	// the rows of keyless tables are keyed by their contents, so converted rows are re-keyed and any rows that become
+	// identical are merged
+	if schema.IsKeyless(newSchema) {
+		return rebuildKeylessRowData(ctx, vrw, rowData, oldSchema, newSchema, func(vals row.TaggedValues) (row.TaggedValues, error) {
+			if val, ok := vals[oldCol.Tag]; ok && !types.IsNull(val) {
+				newVal, err := convertValue(ctx, vrw, val, oldCol, modifiedCol, conversion)
+				if err != nil {
+					return nil, err
+				}
+				vals[modifiedCol.Tag] = newVal
+			}
+			return vals, nil
+		})
+	}
+
+	var me *types.MapEditor
+	if modifiedCol.IsPartOfPK {
+		emptyMap, err := types.NewMap(ctx, vrw)
+		if err != nil {
+			return types.EmptyMap, err
+		}
+		me = emptyMap.Edit()
+	} else {
+		me = rowData.Edit()
+	}
+
+	err := rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(oldSchema, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return false, err
+		}
+
+		if val, ok := r.GetColVal(oldCol.Tag); ok && !types.IsNull(val) {
+			newVal, err := convertValue(ctx, vrw, val, oldCol, modifiedCol, conversion)
+			if err != nil {
+				return true, err
+			}
+
+			r, err = r.SetColVal(modifiedCol.Tag, newVal, newSchema)
+			if err != nil {
+				return true, err
+			}
+		}
+
+		newKey, err := r.NomsMapKey(newSchema).Value(ctx)
+		if err != nil {
+			return true, err
+		}
+		newValue, err := r.NomsMapValue(newSchema).Value(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		me.Set(newKey, newValue)
+		return false, nil
+	})
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	converted, err := me.Map(ctx)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if converted.Len() != rowData.Len() {
+		return types.EmptyMap, fmt.Errorf("cannot change the type of column %s: converted values produce duplicate primary keys", oldCol.Name)
+	}
+
+	return converted, nil
+}
+
+// convertValue converts a single value of the existing column into the type of the modified column.
+func convertValue(ctx context.Context, vrw types.ValueReadWriter, val types.Value, oldCol, modifiedCol schema.Column, conversion TypeConversion) (types.Value, error) {
+	newVal, err := typeinfo.Convert(ctx, vrw, val, oldCol.TypeInfo, modifiedCol.TypeInfo)
+	if err == nil && (newVal == nil || types.IsNull(newVal) || newVal.Kind() == modifiedCol.Kind) {
+		return newVal, nil
+	}
+
+	if conversion == StrictConversion {
+		str, _ := oldCol.TypeInfo.FormatValue(ctx, val)
+		if str == nil {
+			return nil, fmt.Errorf("cannot convert value of column %s to type %s", oldCol.Name, modifiedCol.TypeInfo.ToSqlType().String())
+		}
+		return nil, fmt.Errorf("cannot convert value '%s' of column %s to type %s", *str, oldCol.Name, modifiedCol.TypeInfo.ToSqlType().String())
+	}
+
+	if modifiedCol.IsNullable() {
+		return types.NullValue, nil
+	}
+
+	return modifiedCol.TypeInfo.ConvertValueToNomsValue(ctx, vrw, modifiedCol.TypeInfo.ToSqlType().Zero())
+}
+
 // replaceColumnInSchema replaces the column with the name given with its new definition, optionally reordering it.
 func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schema.Column, order *ColumnOrder) (schema.Schema, error) {
 	// If no order is specified, insert in the same place as the existing column
@@ -200,5 +361,6 @@ func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schem
 		return nil, err
 	}
 	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	newSch.Checks().AddChecks(sch.Checks().AllChecks()...)
 	return newSch, nil
 }
@@ -16,14 +16,18 @@ package alterschema
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -33,6 +37,7 @@ func TestModifyColumn(t *testing.T) {
 		existingColumn schema.Column
 		newColumn      schema.Column
 		order          *ColumnOrder
+		conversion     TypeConversion
 		expectedSchema schema.Schema
 		expectedRows   []row.Row
 		expectedErr    string
@@ -104,10 +109,69 @@ func TestModifyColumn(t *testing.T) {
 			expectedErr:    "A column with the name name already exists",
 		},
 		{
-			name:           "type change",
+			name:           "type change widen string",
+			existingColumn: schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+			newColumn:      varcharColumn("name", dtestutils.NameTag, 100),
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+				varcharColumn("name", dtestutils.NameTag, 100),
+				schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+			expectedRows: dtestutils.TypedRows,
+		},
+		{
+			name:           "type change uint to string",
+			existingColumn: schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, false, schema.NotNullConstraint{}),
+			newColumn:      schema.NewColumn("age", dtestutils.AgeTag, types.StringKind, false, schema.NotNullConstraint{}),
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("age", dtestutils.AgeTag, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+			expectedRows: rowsWithColVal(dtestutils.TypedRows, dtestutils.AgeTag, func(i int) types.Value {
+				return types.String(fmt.Sprint(dtestutils.Ages[i]))
+			}),
+		},
+		{
+			name:           "type change primary key",
 			existingColumn: schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, true, schema.NotNullConstraint{}),
-			newColumn:      schema.NewColumn("newId", dtestutils.IdTag, types.StringKind, true, schema.NotNullConstraint{}),
-			expectedErr:    "unsupported feature: column types cannot be changed",
+			newColumn:      schema.NewColumn("id", dtestutils.IdTag, types.StringKind, true, schema.NotNullConstraint{}),
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.StringKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+			expectedRows: rowsWithColVal(dtestutils.TypedRows, dtestutils.IdTag, func(i int) types.Value {
+				return types.String(dtestutils.UUIDS[i].String())
+			}),
+		},
+		{
+			name:           "type change strict, value doesn't fit",
+			existingColumn: schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+			newColumn:      varcharColumn("name", dtestutils.NameTag, 4),
+			expectedErr:    "cannot convert value 'Bill Billerson' of column name",
+		},
+		{
+			name:           "type change lossy, value doesn't fit",
+			existingColumn: schema.NewColumn("name", dtestutils.NameTag, types.StringKind, false, schema.NotNullConstraint{}),
+			newColumn:      varcharColumn("name", dtestutils.NameTag, 4),
+			conversion:     LossyConversion,
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", dtestutils.IdTag, types.UUIDKind, true, schema.NotNullConstraint{}),
+				varcharColumn("name", dtestutils.NameTag, 4),
+				schema.NewColumn("age", dtestutils.AgeTag, types.UintKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", dtestutils.IsMarriedTag, types.BoolKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("title", dtestutils.TitleTag, types.StringKind, false),
+			),
+			expectedRows: rowsWithColVal(dtestutils.TypedRows, dtestutils.NameTag, func(i int) types.Value {
+				return types.String("")
+			}),
 		},
 	}
 
@@ -121,7 +185,7 @@ func TestModifyColumn(t *testing.T) {
 			tbl, _, err := root.GetTable(ctx, tableName)
 			assert.NoError(t, err)
 
-			updatedTable, err := ModifyColumn(ctx, tbl, tt.existingColumn, tt.newColumn, tt.order)
+			updatedTable, err := ModifyColumnWithConversion(ctx, tbl, tt.existingColumn, tt.newColumn, tt.order, tt.conversion)
 			if len(tt.expectedErr) > 0 {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
@@ -165,3 +229,27 @@ func TestModifyColumn(t *testing.T) {
 		})
 	}
 }
+
+func varcharColumn(name string, tag uint64, length int64) schema.Column {
+	ti, err := typeinfo.FromSqlType(sql.MustCreateStringWithDefaults(sqltypes.VarChar, length))
+	if err != nil {
+		panic(err)
+	}
+	col, err := schema.NewColumnWithTypeInfo(name, tag, ti, false, "", false, "", schema.NotNullConstraint{})
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+func rowsWithColVal(rows []row.Row, tag uint64, val func(i int) types.Value) []row.Row {
+	var updated []row.Row
+	for i, r := range rows {
+		r, err := r.SetColVal(tag, val(i), dtestutils.TypedSchema)
+		if err != nil {
+			panic(err)
+		}
+		updated = append(updated, r)
+	}
+	return updated
+}
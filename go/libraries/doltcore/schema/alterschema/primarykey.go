@@ -0,0 +1,265 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrDuplicatePrimaryKey is returned when the rows of a table are not unique over a new primary key.
+var ErrDuplicatePrimaryKey = errors.New("duplicate primary key")
+
+// ChangePrimaryKey replaces the primary key of the table given with the columns named, in the order given. Columns
+// keep their tags. As the order of primary key columns follows the order of the columns in the schema, the new
+// primary key columns are moved together to the position of the first of them when they are named out of order.
+// Every row is re-keyed, which fails if any of the new key columns holds a NULL or if the rows are not unique over the
+// new key, and every index is rebuilt. The rows of a keyless table are unique over the new key only if none of them
+// has a cardinality above 1.
+func ChangePrimaryKey(ctx context.Context, tbl *doltdb.Table, pkColNames []string) (*doltdb.Table, error) {
+	if tbl == nil {
+		panic("invalid parameters")
+	}
+
+	if len(pkColNames) == 0 {
+		return nil, schema.ErrNoPrimaryKeyColumns
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allCols := sch.GetAllCols()
+	var pkCols []schema.Column
+	pkTags := make(map[uint64]bool)
+	for _, name := range pkColNames {
+		col, ok := allCols.GetByNameCaseInsensitive(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", schema.ErrColNotFound, name)
+		}
+		if pkTags[col.Tag] {
+			return nil, fmt.Errorf("column %s appears more than once in the primary key", col.Name)
+		}
+		pkTags[col.Tag] = true
+
+		col.IsPartOfPK = true
+		if col.IsNullable() {
+			col.Constraints = append(append([]schema.ColConstraint{}, col.Constraints...), schema.NotNullConstraint{})
+		}
+		pkCols = append(pkCols, col)
+	}
+
+	var cols []schema.Column
+	pkColsPlaced := false
+	err = allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if !pkTags[tag] {
+			col.IsPartOfPK = false
+			cols = append(cols, col)
+		} else if !pkColsPlaced {
+			cols = append(cols, pkCols...)
+			pkColsPlaced = true
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+	if err != nil {
+		return nil, err
+	}
+
+	newSch, err := schema.SchemaFromCols(colColl)
+	if err != nil {
+		return nil, err
+	}
+	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	newSch.Checks().AddChecks(sch.Checks().AllChecks()...)
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err = rekeyRowData(ctx, tbl.ValueReadWriter(), rowData, sch, newSch)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedTable, err := newTableWithRowData(ctx, tbl, newSch, rowData)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range newSch.Indexes().AllIndexes() {
+		rebuiltIndexData, err := updatedTable.RebuildIndexRowData(ctx, index.Name())
+		if err != nil {
+			return nil, err
+		}
+		updatedTable, err = updatedTable.SetIndexRowData(ctx, index.Name(), rebuiltIndexData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updatedTable, nil
+}
+
+// DropPrimaryKey removes the primary key of the table given, making it a keyless table. Identical rows are merged into
+// a single row with a cardinality counting them. Keyless tables cannot be indexed, so this fails if the table has any
+// indexes.
+func DropPrimaryKey(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error) {
+	if tbl == nil {
+		panic("invalid parameters")
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.IsKeyless(sch) {
+		return nil, schema.ErrNoPrimaryKeyColumns
+	}
+	if sch.Indexes().Count() > 0 {
+		return nil, fmt.Errorf("cannot drop the primary key: %w", row.ErrKeylessIndex)
+	}
+
+	var cols []schema.Column
+	err = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		col.IsPartOfPK = false
+		cols = append(cols, col)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	colColl, err := schema.NewColCollection(cols...)
+	if err != nil {
+		return nil, err
+	}
+
+	newSch, err := schema.SchemaFromCols(colColl)
+	if err != nil {
+		return nil, err
+	}
+	newSch.Checks().AddChecks(sch.Checks().AllChecks()...)
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err = rebuildKeylessRowData(ctx, tbl.ValueReadWriter(), rowData, sch, newSch, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTableWithRowData(ctx, tbl, newSch, rowData)
+}
+
+// newTableWithRowData returns a copy of the table given with the schema and row data given.
+func newTableWithRowData(ctx context.Context, tbl *doltdb.Table, sch schema.Schema, rowData types.Map) (*doltdb.Table, error) {
+	vrw := tbl.ValueReadWriter()
+	schemaVal, err := encoding.MarshalSchemaAsNomsValue(ctx, vrw, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	indexData, err := tbl.GetIndexData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return doltdb.NewTable(ctx, vrw, schemaVal, rowData, &indexData)
+}
+
+// rekeyRowData returns a new map with every row of the one given keyed by the primary key of the new schema.
+func rekeyRowData(ctx context.Context, vrw types.ValueReadWriter, rowData types.Map, oldSch, newSch schema.Schema) (types.Map, error) {
+	emptyMap, err := types.NewMap(ctx, vrw)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+	me := emptyMap.Edit()
+
+	pkCols := newSch.GetPKCols()
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		oldRow, err := row.FromNoms(oldSch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+
+		if row.GetCardinality(oldRow) > 1 {
+			return true, fmt.Errorf("%w: rows are not unique over (%s)", ErrDuplicatePrimaryKey, strings.Join(pkCols.GetColumnNames(), ", "))
+		}
+
+		taggedVals, err := row.GetTaggedVals(oldRow)
+		if err != nil {
+			return true, err
+		}
+
+		r, err := row.New(rowData.Format(), newSch, taggedVals)
+		if err != nil {
+			return true, err
+		}
+
+		err = pkCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			if val, ok := r.GetColVal(tag); !ok || types.IsNull(val) {
+				return true, fmt.Errorf("cannot add column %s to the primary key: it contains NULL values", col.Name)
+			}
+			return false, nil
+		})
+		if err != nil {
+			return true, err
+		}
+
+		newKey, err := r.NomsMapKey(newSch).Value(ctx)
+		if err != nil {
+			return true, err
+		}
+		newValue, err := r.NomsMapValue(newSch).Value(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		me.Set(newKey, newValue)
+		return false, nil
+	})
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	rekeyed, err := me.Map(ctx)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if rekeyed.Len() != rowData.Len() {
+		return types.EmptyMap, fmt.Errorf("%w: rows are not unique over (%s)", ErrDuplicatePrimaryKey, strings.Join(pkCols.GetColumnNames(), ", "))
+	}
+
+	return rekeyed, nil
+}
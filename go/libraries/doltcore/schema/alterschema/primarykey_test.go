@@ -0,0 +1,209 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alterschema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestChangePrimaryKey(t *testing.T) {
+	tests := []struct {
+		name            string
+		pkColNames      []string
+		expectedPkCols  []string
+		expectedColumns []string
+		expectedErr     error
+	}{
+		{
+			name:            "single column",
+			pkColNames:      []string{"name"},
+			expectedPkCols:  []string{"name"},
+			expectedColumns: []string{"id", "name", "age", "is_married", "title"},
+		},
+		{
+			name:            "composite key out of column order",
+			pkColNames:      []string{"age", "name"},
+			expectedPkCols:  []string{"age", "name"},
+			expectedColumns: []string{"id", "age", "name", "is_married", "title"},
+		},
+		{
+			name:            "nullable column",
+			pkColNames:      []string{"id", "title"},
+			expectedPkCols:  []string{"id", "title"},
+			expectedColumns: []string{"id", "title", "name", "age", "is_married"},
+		},
+		{
+			name:        "duplicate values",
+			pkColNames:  []string{"is_married"},
+			expectedErr: ErrDuplicatePrimaryKey,
+		},
+		{
+			name:        "column not found",
+			pkColNames:  []string{"unknown"},
+			expectedErr: schema.ErrColNotFound,
+		},
+		{
+			name:        "no columns",
+			pkColNames:  []string{},
+			expectedErr: schema.ErrNoPrimaryKeyColumns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dEnv := createEnvWithSeedData(t)
+			ctx := context.Background()
+
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			tbl, _, err := root.GetTable(ctx, tableName)
+			require.NoError(t, err)
+
+			updatedTable, err := ChangePrimaryKey(ctx, tbl, tt.pkColNames)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+			require.NoError(t, err)
+
+			sch, err := updatedTable.GetSchema(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPkCols, sch.GetPKCols().GetColumnNames())
+			assert.Equal(t, tt.expectedColumns, sch.GetAllCols().GetColumnNames())
+
+			// columns keep their tags, and key columns become NOT NULL
+			oldSch, err := tbl.GetSchema(ctx)
+			require.NoError(t, err)
+			_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+				oldCol, ok := oldSch.GetAllCols().GetByName(col.Name)
+				assert.True(t, ok)
+				assert.Equal(t, oldCol.Tag, tag)
+				if col.IsPartOfPK {
+					assert.False(t, col.IsNullable())
+				}
+				return false, nil
+			})
+
+			rowData, err := updatedTable.GetRowData(ctx)
+			require.NoError(t, err)
+			require.Equal(t, uint64(len(dtestutils.TypedRows)), rowData.Len())
+
+			var foundVals []row.TaggedValues
+			err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+				r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+				if err != nil {
+					return false, err
+				}
+				vals, err := row.GetTaggedVals(r)
+				if err != nil {
+					return false, err
+				}
+				foundVals = append(foundVals, vals)
+				return false, nil
+			})
+			require.NoError(t, err)
+
+			var expectedVals []row.TaggedValues
+			for _, r := range dtestutils.TypedRows {
+				vals, err := row.GetTaggedVals(r)
+				require.NoError(t, err)
+				expectedVals = append(expectedVals, vals)
+			}
+			assert.ElementsMatch(t, expectedVals, foundVals)
+
+			index := sch.Indexes().GetByName(dtestutils.IndexName)
+			require.NotNil(t, index)
+			indexRows, err := updatedTable.GetIndexRowData(ctx, index.Name())
+			require.NoError(t, err)
+			expectedIndexRows, err := updatedTable.RebuildIndexRowData(ctx, index.Name())
+			require.NoError(t, err)
+			assert.True(t, indexRows.Equals(expectedIndexRows), "index contents are incorrect")
+		})
+	}
+}
+
+func TestDropPrimaryKey(t *testing.T) {
+	dEnv := createEnvWithSeedData(t)
+	ctx := context.Background()
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, _, err := root.GetTable(ctx, tableName)
+	require.NoError(t, err)
+
+	// keyless tables can't be indexed
+	_, err = DropPrimaryKey(ctx, tbl)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, row.ErrKeylessIndex))
+
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	_, err = sch.Indexes().RemoveIndex(dtestutils.IndexName)
+	require.NoError(t, err)
+	tbl, err = tbl.UpdateSchema(ctx, sch)
+	require.NoError(t, err)
+	tbl, err = tbl.DeleteIndexRowData(ctx, dtestutils.IndexName)
+	require.NoError(t, err)
+
+	updatedTable, err := DropPrimaryKey(ctx, tbl)
+	require.NoError(t, err)
+
+	newSch, err := updatedTable.GetSchema(ctx)
+	require.NoError(t, err)
+	assert.True(t, schema.IsKeyless(newSch))
+	assert.Equal(t, sch.GetAllCols().GetColumnNames(), newSch.GetAllCols().GetColumnNames())
+
+	rowData, err := updatedTable.GetRowData(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(dtestutils.TypedRows)), rowData.Len())
+
+	var foundVals []row.TaggedValues
+	err = rowData.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(newSch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return false, err
+		}
+		assert.Equal(t, uint64(1), row.GetCardinality(r))
+		vals, err := row.GetTaggedVals(r)
+		if err != nil {
+			return false, err
+		}
+		foundVals = append(foundVals, vals)
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	var expectedVals []row.TaggedValues
+	for _, r := range dtestutils.TypedRows {
+		vals, err := row.GetTaggedVals(r)
+		require.NoError(t, err)
+		expectedVals = append(expectedVals, vals)
+	}
+	assert.ElementsMatch(t, expectedVals, foundVals)
+
+	_, err = DropPrimaryKey(ctx, updatedTable)
+	assert.True(t, errors.Is(err, schema.ErrNoPrimaryKeyColumns))
+}
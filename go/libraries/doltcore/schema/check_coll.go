@@ -0,0 +1,174 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Check is a CHECK constraint on a table. The expression is stored as the SQL text it was defined with, and references
+// columns by name. Column-level CHECK constraints are stored as table-level constraints, as they are in MySQL.
+type Check interface {
+	// Name returns the name of the check constraint.
+	Name() string
+	// Expression returns the SQL expression that every row of the table must satisfy.
+	Expression() string
+	// Enforced returns whether the check constraint is enforced on writes.
+	Enforced() bool
+	// Equals returns whether this check constraint is equivalent to another.
+	Equals(other Check) bool
+}
+
+type checkImpl struct {
+	name       string
+	expression string
+	enforced   bool
+}
+
+var _ Check = (*checkImpl)(nil)
+
+// NewCheck returns a Check with the given name, expression and enforcement.
+func NewCheck(name, expression string, enforced bool) Check {
+	return &checkImpl{name: name, expression: expression, enforced: enforced}
+}
+
+// Name implements Check.
+func (c *checkImpl) Name() string {
+	return c.name
+}
+
+// Expression implements Check.
+func (c *checkImpl) Expression() string {
+	return c.expression
+}
+
+// Enforced implements Check.
+func (c *checkImpl) Enforced() bool {
+	return c.enforced
+}
+
+// Equals implements Check.
+func (c *checkImpl) Equals(other Check) bool {
+	return strings.ToLower(c.name) == strings.ToLower(other.Name()) &&
+		c.expression == other.Expression() &&
+		c.enforced == other.Enforced()
+}
+
+type CheckCollection interface {
+	// AddCheck adds a check constraint with the given name, expression and enforcement. Names are case-insensitive and
+	// must be unique within the collection.
+	AddCheck(name, expression string, enforced bool) (Check, error)
+	// AddChecks adds the given check constraints, overwriting any current check constraints with the same name.
+	// It does not perform any kind of checking, and is intended for schema modifications.
+	AddChecks(checks ...Check)
+	// AllChecks returns a slice containing all of the check constraints in this collection, in the order they were added.
+	AllChecks() []Check
+	// Contains returns whether a check constraint with the given case-insensitive name exists in this collection.
+	Contains(name string) bool
+	// Count returns the number of check constraints in this collection.
+	Count() int
+	// Equals returns whether this check collection is equivalent to another.
+	Equals(other CheckCollection) bool
+	// GetByNameCaseInsensitive returns the check constraint with a matching case-insensitive name, the bool return value
+	// indicates if a match was found.
+	GetByNameCaseInsensitive(name string) (Check, bool)
+	// RemoveCheck removes the check constraint with the given case-insensitive name.
+	RemoveCheck(name string) (Check, error)
+}
+
+type checkCollectionImpl struct {
+	checks []Check
+}
+
+// NewCheckCollection returns an empty CheckCollection.
+func NewCheckCollection() CheckCollection {
+	return &checkCollectionImpl{}
+}
+
+func (cc *checkCollectionImpl) AddCheck(name, expression string, enforced bool) (Check, error) {
+	if cc.Contains(name) {
+		return nil, fmt.Errorf("`%s` already exists as a check constraint for this table", name)
+	}
+	if strings.TrimSpace(expression) == "" {
+		return nil, fmt.Errorf("check constraint `%s` has an empty expression", name)
+	}
+	check := NewCheck(name, expression, enforced)
+	cc.checks = append(cc.checks, check)
+	return check, nil
+}
+
+func (cc *checkCollectionImpl) AddChecks(checks ...Check) {
+	for _, check := range checks {
+		if i := cc.indexOf(check.Name()); i >= 0 {
+			cc.checks[i] = check
+		} else {
+			cc.checks = append(cc.checks, check)
+		}
+	}
+}
+
+func (cc *checkCollectionImpl) AllChecks() []Check {
+	checks := make([]Check, len(cc.checks))
+	copy(checks, cc.checks)
+	return checks
+}
+
+func (cc *checkCollectionImpl) Contains(name string) bool {
+	return cc.indexOf(name) >= 0
+}
+
+func (cc *checkCollectionImpl) Count() int {
+	return len(cc.checks)
+}
+
+func (cc *checkCollectionImpl) Equals(other CheckCollection) bool {
+	if cc.Count() != other.Count() {
+		return false
+	}
+	for _, check := range cc.checks {
+		otherCheck, ok := other.GetByNameCaseInsensitive(check.Name())
+		if !ok || !check.Equals(otherCheck) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cc *checkCollectionImpl) GetByNameCaseInsensitive(name string) (Check, bool) {
+	if i := cc.indexOf(name); i >= 0 {
+		return cc.checks[i], true
+	}
+	return nil, false
+}
+
+func (cc *checkCollectionImpl) RemoveCheck(name string) (Check, error) {
+	i := cc.indexOf(name)
+	if i < 0 {
+		return nil, fmt.Errorf("`%s` does not exist as a check constraint for this table", name)
+	}
+	check := cc.checks[i]
+	cc.checks = append(cc.checks[:i], cc.checks[i+1:]...)
+	return check, nil
+}
+
+func (cc *checkCollectionImpl) indexOf(name string) int {
+	for i, check := range cc.checks {
+		if strings.ToLower(check.Name()) == strings.ToLower(name) {
+			return i
+		}
+	}
+	return -1
+}
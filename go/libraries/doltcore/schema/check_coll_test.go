@@ -0,0 +1,60 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCollection(t *testing.T) {
+	checks := NewCheckCollection()
+	_, err := checks.AddCheck("chk_a", "a > 0", true)
+	require.NoError(t, err)
+	_, err = checks.AddCheck("chk_b", "b < a", false)
+	require.NoError(t, err)
+
+	_, err = checks.AddCheck("CHK_A", "a > 1", true)
+	assert.Error(t, err)
+	_, err = checks.AddCheck("chk_c", " ", true)
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, checks.Count())
+	assert.True(t, checks.Contains("Chk_B"))
+	check, ok := checks.GetByNameCaseInsensitive("CHK_B")
+	require.True(t, ok)
+	assert.Equal(t, "chk_b", check.Name())
+	assert.Equal(t, "b < a", check.Expression())
+	assert.False(t, check.Enforced())
+
+	checks.AddChecks(NewCheck("chk_b", "b < a", true), NewCheck("chk_c", "c IS NOT NULL", true))
+	all := checks.AllChecks()
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"chk_a", "chk_b", "chk_c"}, []string{all[0].Name(), all[1].Name(), all[2].Name()})
+	assert.True(t, all[1].Enforced())
+
+	other := NewCheckCollection()
+	other.AddChecks(all[2], all[0], all[1])
+	assert.True(t, checks.Equals(other))
+
+	_, err = checks.RemoveCheck("CHK_A")
+	require.NoError(t, err)
+	_, err = checks.RemoveCheck("chk_a")
+	assert.Error(t, err)
+	assert.Equal(t, 2, checks.Count())
+	assert.False(t, checks.Equals(other))
+}
@@ -34,6 +34,13 @@ var ErrColNameCollision = errors.New("two different columns with the same name e
 // ErrNoPrimaryKeyColumns is an error that is returned when no primary key columns are found
 var ErrNoPrimaryKeyColumns = errors.New("no primary key columns")
 
+// ErrNoColumns is an error that is returned when a schema to be written has no columns at all
+var ErrNoColumns = errors.New("no columns")
+
+// ErrBlobKeyColumn is an error that is returned when a column whose values are stored out-of-line as blobs is used
+// as part of a primary key or index
+var ErrBlobKeyColumn = errors.New("BLOB and long TEXT columns cannot be used in a primary key or index")
+
 var EmptyColColl = &ColCollection{
 	[]Column{},
 	[]uint64{},
@@ -46,6 +46,7 @@ var (
 		false,
 		"",
 		nil,
+		"",
 	}
 )
 
@@ -84,6 +85,10 @@ type Column struct {
 
 	// Constraints are rules that can be checked on each column to say if the columns value is valid
 	Constraints []ColConstraint
+
+	// Generated is the expression that computes the values of this column if it's a generated column, and is empty
+	// otherwise. This is the string representation of a sql.Expression.
+	Generated string
 }
 
 // NewColumn creates a Column instance with the default type info for the NomsKind
@@ -118,6 +123,7 @@ func NewColumnWithTypeInfo(name string, tag uint64, typeInfo typeinfo.TypeInfo,
 		autoIncrement,
 		comment,
 		constraints,
+		"",
 	}, nil
 }
 
@@ -142,9 +148,15 @@ func (c Column) Equals(other Column) bool {
 		c.IsPartOfPK == other.IsPartOfPK &&
 		c.TypeInfo.Equals(other.TypeInfo) &&
 		c.Default == other.Default &&
+		c.Generated == other.Generated &&
 		ColConstraintsAreEqual(c.Constraints, other.Constraints)
 }
 
+// IsGenerated returns whether the values of the column are computed from the other columns of its row.
+func (c Column) IsGenerated() bool {
+	return c.Generated != ""
+}
+
 // KindString returns the string representation of the NomsKind stored in the column.
 func (c Column) KindString() string {
 	return KindToLwrStr[c.Kind]
@@ -52,6 +52,8 @@ type encodedColumn struct {
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
 
+	Generated string `noms:"generated,omitempty" json:"generated,omitempty"`
+
 	// NB: all new fields must have the 'omitempty' annotation. See comment above
 }
 
@@ -91,6 +93,7 @@ func encodeColumn(col schema.Column) encodedColumn {
 		AutoIncrement: col.AutoIncrement,
 		Comment:       col.Comment,
 		Constraints:   encodeAllColConstraints(col.Constraints),
+		Generated:     col.Generated,
 	}
 }
 
@@ -108,7 +111,12 @@ func (nfd encodedColumn) decodeColumn() (schema.Column, error) {
 		return schema.Column{}, errors.New("cannot decode column due to unknown schema format")
 	}
 	colConstraints := decodeAllColConstraint(nfd.Constraints)
-	return schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, nfd.Default, nfd.AutoIncrement, nfd.Comment, colConstraints...)
+	col, err := schema.NewColumnWithTypeInfo(nfd.Name, nfd.Tag, typeInfo, nfd.IsPartOfPK, nfd.Default, nfd.AutoIncrement, nfd.Comment, colConstraints...)
+	if err != nil {
+		return schema.Column{}, err
+	}
+	col.Generated = nfd.Generated
+	return col, nil
 }
 
 type encodedConstraint struct {
@@ -146,9 +154,16 @@ type encodedIndex struct {
 	IsSystemDefined bool     `noms:"hidden,omitempty" json:"hidden,omitempty"` // Was previously named Hidden, do not change noms name
 }
 
+type encodedCheck struct {
+	Name       string `noms:"name" json:"name"`
+	Expression string `noms:"expression" json:"expression"`
+	Enforced   bool   `noms:"enforced" json:"enforced"`
+}
+
 type schemaData struct {
 	Columns         []encodedColumn `noms:"columns" json:"columns"`
 	IndexCollection []encodedIndex  `noms:"idxColl,omitempty" json:"idxColl,omitempty"`
+	Checks          []encodedCheck  `noms:"checks,omitempty" json:"checks,omitempty"`
 }
 
 func toSchemaData(sch schema.Schema) (schemaData, error) {
@@ -178,7 +193,16 @@ func toSchemaData(sch schema.Schema) (schemaData, error) {
 		}
 	}
 
-	return schemaData{encCols, encodedIndexes}, nil
+	var encodedChecks []encodedCheck
+	for _, check := range sch.Checks().AllChecks() {
+		encodedChecks = append(encodedChecks, encodedCheck{
+			Name:       check.Name(),
+			Expression: check.Expression(),
+			Enforced:   check.Enforced(),
+		})
+	}
+
+	return schemaData{encCols, encodedIndexes, encodedChecks}, nil
 }
 
 func (sd schemaData) decodeSchema() (schema.Schema, error) {
@@ -219,6 +243,10 @@ func (sd schemaData) decodeSchema() (schema.Schema, error) {
 		}
 	}
 
+	for _, encodedCheck := range sd.Checks {
+		sch.Checks().AddChecks(schema.NewCheck(encodedCheck.Name, encodedCheck.Expression, encodedCheck.Enforced))
+	}
+
 	return sch, nil
 }
 
@@ -97,6 +97,60 @@ func TestNomsMarshalling(t *testing.T) {
 
 }
 
+func TestCheckMarshalling(t *testing.T) {
+	sch := createTestSchema()
+	sch.Checks().AddChecks(schema.NewCheck("chk_age", "age < 200", true), schema.NewCheck("chk_name", "first <> last", false))
+
+	db, err := dbfactory.MemFactory{}.CreateDB(context.Background(), types.Format_7_18, nil, nil)
+	require.NoError(t, err)
+	val, err := MarshalSchemaAsNomsValue(context.Background(), db, sch)
+	require.NoError(t, err)
+	unmarshalled, err := UnmarshalSchemaNomsValue(context.Background(), types.Format_7_18, val)
+	require.NoError(t, err)
+
+	eq, err := schema.SchemasAreEqual(sch, unmarshalled)
+	require.NoError(t, err)
+	assert.True(t, eq)
+	checks := unmarshalled.Checks().AllChecks()
+	require.Len(t, checks, 2)
+	assert.Equal(t, "chk_age", checks[0].Name())
+	assert.Equal(t, "age < 200", checks[0].Expression())
+	assert.True(t, checks[0].Enforced())
+	assert.Equal(t, "chk_name", checks[1].Name())
+	assert.False(t, checks[1].Enforced())
+}
+
+func TestGeneratedColumnMarshalling(t *testing.T) {
+	full := schema.NewColumn("full", 5, types.StringKind, false)
+	full.Generated = "concat(first, ' ', last)"
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("id", 4, types.UUIDKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("first", 1, types.StringKind, false),
+		schema.NewColumn("last", 2, types.StringKind, false),
+		full,
+	)
+	require.NoError(t, err)
+	sch := schema.MustSchemaFromCols(colColl)
+
+	db, err := dbfactory.MemFactory{}.CreateDB(context.Background(), types.Format_7_18, nil, nil)
+	require.NoError(t, err)
+	val, err := MarshalSchemaAsNomsValue(context.Background(), db, sch)
+	require.NoError(t, err)
+	unmarshalled, err := UnmarshalSchemaNomsValue(context.Background(), types.Format_7_18, val)
+	require.NoError(t, err)
+
+	eq, err := schema.SchemasAreEqual(sch, unmarshalled)
+	require.NoError(t, err)
+	assert.True(t, eq)
+	col, ok := unmarshalled.GetAllCols().GetByName("full")
+	require.True(t, ok)
+	assert.True(t, col.IsGenerated())
+	assert.Equal(t, "concat(first, ' ', last)", col.Generated)
+	col, ok = unmarshalled.GetAllCols().GetByName("first")
+	require.True(t, ok)
+	assert.False(t, col.IsGenerated())
+}
+
 func TestTypeInfoMarshalling(t *testing.T) {
 	//TODO: determine the storage format for BINARY
 	//TODO: determine the storage format for BLOB
@@ -213,6 +267,8 @@ type testEncodedColumn struct {
 	Comment string `noms:"comment,omitempty" json:"comment,omitempty"`
 
 	Constraints []encodedConstraint `noms:"col_constraints" json:"col_constraints"`
+
+	Generated string `noms:"generated,omitempty" json:"generated,omitempty"`
 }
 
 type testEncodedIndex struct {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -155,12 +156,13 @@ func (ix *indexImpl) Schema() Schema {
 	cols := make([]Column, len(ix.allTags))
 	for i, tag := range ix.allTags {
 		col := ix.indexColl.colColl.TagToCol[tag]
+		ti := indexKeyTypeInfo(col, i < len(ix.tags))
 		cols[i] = Column{
 			Name:        col.Name,
 			Tag:         tag,
-			Kind:        col.Kind,
+			Kind:        ti.NomsKind(),
 			IsPartOfPK:  true,
-			TypeInfo:    col.TypeInfo,
+			TypeInfo:    ti,
 			Constraints: nil,
 		}
 	}
@@ -207,7 +209,7 @@ func (ix *indexImpl) VerifyMap(ctx context.Context, iter types.MapIterator, nbf
 			} else {
 				if types.IsNull(v) {
 					hasNull = true
-				} else if v.Kind() != cols[colIndex].TypeInfo.NomsKind() {
+				} else if v.Kind() != indexKeyTypeInfo(cols[colIndex], colIndex < len(ix.tags)).NomsKind() {
 					return fmt.Errorf("column value in map does not match what index `%s` expects", ix.name)
 				}
 			}
@@ -234,6 +236,35 @@ func (ix *indexImpl) VerifyMap(ctx context.Context, iter types.MapIterator, nbf
 	return err
 }
 
+// IndexKeyValue returns the value that represents the given value of the column given in index keys, and in row keys
+// if it's a primary key column. Collated string columns are represented by their collation keys, so that values that
+// are equal according to the collation share a key. The primary key columns which follow the indexed columns of an
+// index are represented by their values, which are converted when rows are looked up by them.
+func IndexKeyValue(col Column, v types.Value) types.Value {
+	if !UsesCollationKeys(col) {
+		return v
+	}
+	return typeinfo.CollationKeyValue(col.TypeInfo, v)
+}
+
+// UsesCollationKeys returns whether the column given is represented by collation keys in index keys, and in row keys
+// if it's a primary key column. The values of such primary key columns are kept in the values of rows (see ValueCols).
+func UsesCollationKeys(col Column) bool {
+	return typeinfo.IsCollated(col.TypeInfo)
+}
+
+// indexKeyTypeInfo returns the type of the values that represent the column given in index keys, depending on whether
+// it's one of the indexed columns or one of the primary key columns which follow them.
+func indexKeyTypeInfo(col Column, indexed bool) typeinfo.TypeInfo {
+	if !UsesCollationKeys(col) {
+		return col.TypeInfo
+	}
+	if indexed {
+		return typeinfo.InlineBlobType
+	}
+	return typeinfo.ByteWise(col.TypeInfo)
+}
+
 // copy returns an exact copy of the calling index.
 func (ix *indexImpl) copy() *indexImpl {
 	newIx := *ix
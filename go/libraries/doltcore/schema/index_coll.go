@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 type IndexCollection interface {
@@ -139,6 +141,11 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 	if ixc.hasIndexOnTags(tags...) {
 		return nil, fmt.Errorf("cannot create a duplicate index on this table")
 	}
+	for _, tag := range tags {
+		if col, ok := ixc.colColl.GetByTag(tag); ok && col.Kind == types.BlobKind {
+			return nil, ErrBlobKeyColumn
+		}
+	}
 	index := &indexImpl{
 		indexColl:     ixc,
 		name:          indexName,
@@ -14,6 +14,8 @@
 
 package schema
 
+import "github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+
 // Schema is an interface for retrieving the columns that make up a schema
 type Schema interface {
 	// GetPKCols gets the collection of columns which make the primary key.
@@ -27,6 +29,9 @@ type Schema interface {
 
 	// Indexes returns a collection of all indexes on the table that this schema belongs to.
 	Indexes() IndexCollection
+
+	// Checks returns a collection of all CHECK constraints on the table that this schema belongs to.
+	Checks() CheckCollection
 }
 
 // ColFromTag returns a schema.Column from a schema and a tag
@@ -66,7 +71,70 @@ func SchemasAreEqual(sch1, sch2 Schema) (bool, error) {
 	if !colCollIsEqual {
 		return false, nil
 	}
-	return sch1.Indexes().Equals(sch2.Indexes()), nil
+	if !sch1.Indexes().Equals(sch2.Indexes()) {
+		return false, nil
+	}
+	return sch1.Checks().Equals(sch2.Checks()), nil
+}
+
+// IsKeyless returns whether the schema given has no primary key columns. The rows of keyless tables are keyed by a
+// hash of their contents, and store the number of identical rows as their cardinality.
+func IsKeyless(sch Schema) bool {
+	return sch.GetPKCols().Size() == 0 && sch.GetAllCols().Size() > 0
+}
+
+// ArePrimaryKeySetsDiffable returns whether the primary keys of two schemas consist of the same columns in the same
+// order, so that the rows of a table with one schema can be matched by key against the rows of a table with the other.
+func ArePrimaryKeySetsDiffable(fromSch, toSch Schema) bool {
+	if fromSch == nil || toSch == nil {
+		return true
+	}
+
+	fromPks, toPks := fromSch.GetPKCols(), toSch.GetPKCols()
+	if fromPks.Size() != toPks.Size() {
+		return false
+	}
+
+	for i := 0; i < fromPks.Size(); i++ {
+		fromCol, toCol := fromPks.GetAtIndex(i), toPks.GetAtIndex(i)
+		if fromCol.Tag != toCol.Tag {
+			return false
+		}
+		// collated keys are only comparable if they're built with the same collation
+		if UsesCollationKeys(fromCol) || UsesCollationKeys(toCol) {
+			fromCollation, _ := typeinfo.CollationOf(fromCol.TypeInfo)
+			toCollation, _ := typeinfo.CollationOf(toCol.TypeInfo)
+			if UsesCollationKeys(fromCol) != UsesCollationKeys(toCol) || fromCollation != toCollation {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// ValueCols returns the columns whose values are stored in the values of the rows of the schema given. These are its
+// non-primary key columns, followed by any primary key columns which are represented by collation keys in row keys, so
+// that rows keep the values they were written with.
+func ValueCols(sch Schema) *ColCollection {
+	var collatedPKCols []Column
+	for _, col := range sch.GetPKCols().GetColumns() {
+		if UsesCollationKeys(col) {
+			collatedPKCols = append(collatedPKCols, col)
+		}
+	}
+
+	if len(collatedPKCols) == 0 {
+		return sch.GetNonPKCols()
+	}
+
+	cols, err := NewColCollection(append(sch.GetNonPKCols().GetColumns(), collatedPKCols...)...)
+	if err != nil {
+		// the columns come from a valid schema, so they can't collide
+		panic(err)
+	}
+
+	return cols
 }
 
 // TODO: this function never returns an error
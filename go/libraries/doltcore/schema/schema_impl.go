@@ -17,6 +17,8 @@ package schema
 import (
 	"strconv"
 	"strings"
+
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 // EmptySchema is an instance of a schema with no columns.
@@ -25,14 +27,16 @@ var EmptySchema = &schemaImpl{
 	nonPKCols:       EmptyColColl,
 	allCols:         EmptyColColl,
 	indexCollection: NewIndexCollection(nil),
+	checkCollection: NewCheckCollection(),
 }
 
 type schemaImpl struct {
 	pkCols, nonPKCols, allCols *ColCollection
 	indexCollection            IndexCollection
+	checkCollection            CheckCollection
 }
 
-// SchemaFromCols creates a Schema from a collection of columns
+// SchemaFromCols creates a Schema from a collection of columns. A schema without any primary key columns is keyless.
 func SchemaFromCols(allCols *ColCollection) (Schema, error) {
 	var pkCols []Column
 	var nonPKCols []Column
@@ -45,10 +49,6 @@ func SchemaFromCols(allCols *ColCollection) (Schema, error) {
 		}
 	}
 
-	if len(pkCols) == 0 {
-		return nil, ErrNoPrimaryKeyColumns
-	}
-
 	pkColColl, _ := NewColCollection(pkCols...)
 	nonPKColColl, _ := NewColCollection(nonPKCols...)
 
@@ -57,6 +57,7 @@ func SchemaFromCols(allCols *ColCollection) (Schema, error) {
 		nonPKCols:       nonPKColColl,
 		allCols:         allCols,
 		indexCollection: NewIndexCollection(allCols),
+		checkCollection: NewCheckCollection(),
 	}, nil
 }
 
@@ -70,16 +71,8 @@ func MustSchemaFromCols(typedColColl *ColCollection) Schema {
 
 // ValidateForInsert returns an error if the given schema cannot be written to the dolt database.
 func ValidateForInsert(allCols *ColCollection) error {
-	var seenPkCol bool
-	for _, c := range allCols.cols {
-		if c.IsPartOfPK {
-			seenPkCol = true
-			break
-		}
-	}
-
-	if !seenPkCol {
-		return ErrNoPrimaryKeyColumns
+	if allCols.Size() == 0 {
+		return ErrNoColumns
 	}
 
 	colNames := make(map[string]bool)
@@ -96,6 +89,10 @@ func ValidateForInsert(allCols *ColCollection) error {
 		}
 		colNames[col.Name] = true
 
+		if col.IsPartOfPK && col.Kind == types.BlobKind {
+			return true, ErrBlobKeyColumn
+		}
+
 		return false, nil
 	})
 
@@ -121,6 +118,7 @@ func UnkeyedSchemaFromCols(allCols *ColCollection) Schema {
 		nonPKCols:       nonPKColColl,
 		allCols:         nonPKColColl,
 		indexCollection: NewIndexCollection(nil),
+		checkCollection: NewCheckCollection(),
 	}
 }
 
@@ -158,6 +156,7 @@ func SchemaFromPKAndNonPKCols(pkCols, nonPKCols *ColCollection) (Schema, error)
 		nonPKCols:       nonPKCols,
 		allCols:         allColColl,
 		indexCollection: NewIndexCollection(allColColl),
+		checkCollection: NewCheckCollection(),
 	}, nil
 }
 
@@ -209,3 +208,7 @@ func (si *schemaImpl) String() string {
 func (si *schemaImpl) Indexes() IndexCollection {
 	return si.indexCollection
 }
+
+func (si *schemaImpl) Checks() CheckCollection {
+	return si.checkCollection
+}
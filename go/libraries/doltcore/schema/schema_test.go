@@ -47,14 +47,14 @@ var ageVal = types.Uint(53)
 var titleVal = types.NullValue
 
 var pkCols = []Column{
-	{lnColName, lnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil},
-	{fnColName, fnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil},
+	{lnColName, lnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil, ""},
+	{fnColName, fnColTag, types.StringKind, true, typeinfo.StringDefaultType, "", false, "", nil, ""},
 }
 var nonPkCols = []Column{
-	{addrColName, addrColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-	{ageColName, ageColTag, types.UintKind, false, typeinfo.FromKind(types.UintKind), "", false, "", nil},
-	{titleColName, titleColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
-	{reservedColName, reservedColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil},
+	{addrColName, addrColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, ""},
+	{ageColName, ageColTag, types.UintKind, false, typeinfo.FromKind(types.UintKind), "", false, "", nil, ""},
+	{titleColName, titleColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, ""},
+	{reservedColName, reservedColTag, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, ""},
 }
 
 var allCols = append(append([]Column(nil), pkCols...), nonPkCols...)
@@ -82,8 +82,11 @@ func TestSchemaWithNoPKs(t *testing.T) {
 	colColl, err := NewColCollection(nonPkCols...)
 	require.NoError(t, err)
 
-	_, err = SchemaFromCols(colColl)
-	assert.Equal(t, ErrNoPrimaryKeyColumns, err)
+	sch, err := SchemaFromCols(colColl)
+	require.NoError(t, err)
+	assert.True(t, IsKeyless(sch))
+	assert.Equal(t, 0, sch.GetPKCols().Size())
+	assert.Equal(t, len(nonPkCols), sch.GetNonPKCols().Size())
 
 	assert.NotPanics(t, func() {
 		UnkeyedSchemaFromCols(colColl)
@@ -98,7 +101,7 @@ func TestValidateForInsert(t *testing.T) {
 	})
 
 	t.Run("Name collision", func(t *testing.T) {
-		cols := append(allCols, Column{titleColName, 100, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil})
+		cols := append(allCols, Column{titleColName, 100, types.StringKind, false, typeinfo.StringDefaultType, "", false, "", nil, ""})
 		colColl, err := NewColCollection(cols...)
 		require.NoError(t, err)
 
@@ -110,10 +113,16 @@ func TestValidateForInsert(t *testing.T) {
 	t.Run("No primary keys", func(t *testing.T) {
 		colColl, err := NewColCollection(nonPkCols...)
 		require.NoError(t, err)
+		assert.NoError(t, ValidateForInsert(colColl))
+	})
+
+	t.Run("No columns", func(t *testing.T) {
+		colColl, err := NewColCollection()
+		require.NoError(t, err)
 
 		err = ValidateForInsert(colColl)
 		assert.Error(t, err)
-		assert.Equal(t, err, ErrNoPrimaryKeyColumns)
+		assert.Equal(t, err, ErrNoColumns)
 	})
 }
 
@@ -29,6 +29,13 @@ import (
 const (
 	// ReservedTagMin is the start of a range of tags which the user should not be able to use in their schemas.
 	ReservedTagMin uint64 = 1 << 50
+
+	// KeylessRowIdTag is the tag of the content-derived row id which forms the key of every row of a keyless table.
+	KeylessRowIdTag = ReservedTagMin
+
+	// KeylessRowCardinalityTag is the tag of the number of identical rows stored in the value of every row of a
+	// keyless table.
+	KeylessRowCardinalityTag = ReservedTagMin + 1
 )
 
 func ErrTagPrevUsed(tag uint64, newColName, tableName string) error {
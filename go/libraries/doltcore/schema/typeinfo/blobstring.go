@@ -0,0 +1,206 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	blobStringTypeParam_Collate  = "collate"
+	blobStringTypeParam_Collated = "collated"
+	blobStringTypeParam_Length   = "length"
+)
+
+// blobStringType handles the TEXT types whose values may be too large to store inline in a row, such as MEDIUMTEXT and
+// LONGTEXT. Values are stored out-of-line as Blobs, which are chunked so that large documents are deduplicated across
+// versions rather than being rewritten in full with every change.
+type blobStringType struct {
+	sqlStringType sql.StringType
+	// collated is false for columns created before collations were supported, whose values are compared byte-wise. It
+	// isn't part of the type, so it's left out of Equals.
+	collated bool
+}
+
+var _ TypeInfo = (*blobStringType)(nil)
+
+func CreateBlobStringTypeFromParams(params map[string]string) (TypeInfo, error) {
+	var length int64
+	var collation sql.Collation
+	var err error
+	if collationStr, ok := params[blobStringTypeParam_Collate]; ok {
+		collation, err = sql.ParseCollation(nil, &collationStr, false)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf(`create blobstring type info is missing param "%v"`, blobStringTypeParam_Collate)
+	}
+	if maxLengthStr, ok := params[blobStringTypeParam_Length]; ok {
+		length, err = strconv.ParseInt(maxLengthStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf(`create blobstring type info is missing param "%v"`, blobStringTypeParam_Length)
+	}
+	sqlType, err := sql.CreateString(sqltypes.Text, length, collation)
+	if err != nil {
+		return nil, err
+	}
+	return &blobStringType{sqlType, params[blobStringTypeParam_Collated] == "true"}, nil
+}
+
+// ConvertNomsValueToValue implements TypeInfo interface.
+func (ti *blobStringType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
+	if val, ok := v.(types.Blob); ok {
+		data, err := readBlob(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), v.Kind())
+}
+
+// ConvertValueToNomsValue implements TypeInfo interface.
+func (ti *blobStringType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	if v == nil {
+		return types.NullValue, nil
+	}
+	strVal, err := ti.sqlStringType.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+	val, ok := strVal.(string)
+	if ok {
+		return writeBlob(ctx, vrw, []byte(val))
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert value "%v" of type "%T" as it is invalid`, ti.String(), v, v)
+}
+
+// Equals implements TypeInfo interface.
+func (ti *blobStringType) Equals(other TypeInfo) bool {
+	if other == nil {
+		return false
+	}
+	if ti2, ok := other.(*blobStringType); ok {
+		return ti.sqlStringType.MaxCharacterLength() == ti2.sqlStringType.MaxCharacterLength() &&
+			ti.sqlStringType.Collation() == ti2.sqlStringType.Collation()
+	}
+	return false
+}
+
+// FormatValue implements TypeInfo interface.
+func (ti *blobStringType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
+	if val, ok := v.(types.Blob); ok {
+		data, err := readBlob(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		res := string(data)
+		return &res, nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a string`, ti.String(), v.Kind())
+}
+
+// GetTypeIdentifier implements TypeInfo interface.
+func (ti *blobStringType) GetTypeIdentifier() Identifier {
+	return BlobStringTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo interface.
+func (ti *blobStringType) GetTypeParams() map[string]string {
+	typeParams := map[string]string{
+		blobStringTypeParam_Collate: ti.sqlStringType.Collation().String(),
+		blobStringTypeParam_Length:  strconv.FormatInt(ti.sqlStringType.MaxCharacterLength(), 10),
+	}
+	if ti.collated {
+		typeParams[blobStringTypeParam_Collated] = "true"
+	}
+	return typeParams
+}
+
+// IsValid implements TypeInfo interface.
+func (ti *blobStringType) IsValid(v types.Value) bool {
+	if val, ok := v.(types.Blob); ok {
+		return int64(val.Len()) <= ti.sqlStringType.MaxByteLength()
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return true
+	}
+	return false
+}
+
+// NomsKind implements TypeInfo interface.
+func (ti *blobStringType) NomsKind() types.NomsKind {
+	return types.BlobKind
+}
+
+// ParseValue implements TypeInfo interface.
+func (ti *blobStringType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil {
+		return types.NullValue, nil
+	}
+	return ti.ConvertValueToNomsValue(ctx, vrw, *str)
+}
+
+// String implements TypeInfo interface.
+func (ti *blobStringType) String() string {
+	return fmt.Sprintf(`BlobString(%v, %v)`, ti.sqlStringType.Collation().String(), ti.sqlStringType.MaxCharacterLength())
+}
+
+// ToSqlType implements TypeInfo interface.
+func (ti *blobStringType) ToSqlType() sql.Type {
+	return ti.sqlStringType
+}
+
+// IsBlobType returns whether the given TypeInfo stores its values out-of-line as Blobs.
+func IsBlobType(ti TypeInfo) bool {
+	return ti.NomsKind() == types.BlobKind
+}
+
+// writeBlob stores the given data as a Blob in the ValueReadWriter given.
+func writeBlob(ctx context.Context, vrw types.ValueReadWriter, data []byte) (types.Blob, error) {
+	if vrw == nil {
+		return types.Blob{}, fmt.Errorf("cannot write a blob value without a ValueReadWriter")
+	}
+	return types.NewBlob(ctx, vrw, bytes.NewReader(data))
+}
+
+// readBlob reads the full contents of a Blob, streaming its chunks from the ValueReadWriter it was read from.
+func readBlob(ctx context.Context, b types.Blob) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, b.Len()))
+	_, err := io.Copy(buf, b.Reader(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
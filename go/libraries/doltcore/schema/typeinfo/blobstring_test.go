@@ -0,0 +1,133 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestBlobStringFromSqlType(t *testing.T) {
+	tests := []struct {
+		sqlType  sql.Type
+		expected Identifier
+	}{
+		{sql.CreateTinyText(sql.Collation_Default), VarStringTypeIdentifier},
+		{sql.CreateText(sql.Collation_Default), VarStringTypeIdentifier},
+		{sql.CreateMediumText(sql.Collation_Default), BlobStringTypeIdentifier},
+		{sql.CreateLongText(sql.Collation_Default), BlobStringTypeIdentifier},
+	}
+
+	for _, test := range tests {
+		t.Run(test.sqlType.String(), func(t *testing.T) {
+			ti, err := FromSqlType(test.sqlType)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ti.GetTypeIdentifier())
+			assert.Equal(t, test.sqlType, ti.ToSqlType())
+
+			fromParams, err := FromTypeParams(ti.GetTypeIdentifier(), ti.GetTypeParams())
+			require.NoError(t, err)
+			assert.True(t, ti.Equals(fromParams))
+		})
+	}
+}
+
+func TestBlobStringConvertRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+	ti := &blobStringType{sql.CreateLongText(sql.Collation_Default), true}
+
+	for _, str := range []string{"", "abc", "هذا هو بعض نماذج النص", strings.Repeat("a long document. ", 100000)} {
+		val, err := ti.ConvertValueToNomsValue(ctx, vrw, str)
+		require.NoError(t, err)
+		require.Equal(t, types.BlobKind, val.Kind())
+		assert.True(t, ti.IsValid(val))
+
+		out, err := ti.ConvertNomsValueToValue(ctx, val)
+		require.NoError(t, err)
+		assert.Equal(t, str, out)
+
+		formatted, err := ti.FormatValue(ctx, val)
+		require.NoError(t, err)
+		assert.Equal(t, str, *formatted)
+
+		parsed, err := ti.ParseValue(ctx, vrw, formatted)
+		require.NoError(t, err)
+		assert.True(t, val.Equals(parsed))
+	}
+}
+
+func TestBlobStringChunking(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+	ti := &blobStringType{sql.CreateLongText(sql.Collation_Default), true}
+
+	// chunk boundaries are found by a rolling hash of the content, so the document mustn't repeat itself
+	rnd := rand.New(rand.NewSource(0))
+	sb := strings.Builder{}
+	for sb.Len() < 1<<20 {
+		sb.WriteString(strconv.Itoa(rnd.Int()))
+		sb.WriteByte(' ')
+	}
+	doc := sb.String()
+	val, err := ti.ConvertValueToNomsValue(ctx, vrw, doc)
+	require.NoError(t, err)
+
+	// the value held in the row only references the chunks of the document
+	var refs []types.Ref
+	err = val.WalkRefs(vrw.Format(), func(r types.Ref) error {
+		refs = append(refs, r)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, len(refs) > 1)
+
+	// an edit to the end of the document leaves the chunks at its start unchanged
+	edited, err := ti.ConvertValueToNomsValue(ctx, vrw, doc+"an addendum.")
+	require.NoError(t, err)
+	editedRefs := make(hash.HashSet)
+	err = edited.WalkRefs(vrw.Format(), func(r types.Ref) error {
+		editedRefs.Insert(r.TargetHash())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, editedRefs.Has(refs[0].TargetHash()))
+}
+
+func TestBlobStringNullHandling(t *testing.T) {
+	ti := &blobStringType{sql.CreateLongText(sql.Collation_Default), true}
+
+	val, err := ti.ConvertValueToNomsValue(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.NullValue, val)
+
+	val, err = ti.ParseValue(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.NullValue, val)
+
+	str := "abc"
+	_, err = ti.ParseValue(context.Background(), nil, &str)
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strconv"
@@ -33,7 +34,7 @@ var _ TypeInfo = (*boolType)(nil)
 var BoolType TypeInfo = &boolType{sql.MustCreateBitType(1)}
 
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *boolType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+func (ti *boolType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
 	if val, ok := v.(types.Bool); ok {
 		if val {
 			return uint64(1), nil
@@ -47,7 +48,7 @@ func (ti *boolType) ConvertNomsValueToValue(v types.Value) (interface{}, error)
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *boolType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *boolType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	switch val := v.(type) {
 	case nil:
 		return types.NullValue, nil
@@ -88,7 +89,7 @@ func (ti *boolType) ConvertValueToNomsValue(v interface{}) (types.Value, error)
 		}
 		return types.Bool(valInt != 0), nil
 	case []byte:
-		return ti.ConvertValueToNomsValue(string(val))
+		return ti.ConvertValueToNomsValue(ctx, vrw, string(val))
 	default:
 		return nil, fmt.Errorf(`"%v" cannot convert value "%v" of type "%T" as it is invalid`, ti.String(), v, v)
 	}
@@ -104,7 +105,7 @@ func (ti *boolType) Equals(other TypeInfo) bool {
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *boolType) FormatValue(v types.Value) (*string, error) {
+func (ti *boolType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
 	if val, ok := v.(types.Bool); ok {
 		res := ""
 		if val {
@@ -132,8 +133,12 @@ func (ti *boolType) GetTypeParams() map[string]string {
 
 // IsValid implements TypeInfo interface.
 func (ti *boolType) IsValid(v types.Value) bool {
-	_, err := ti.ConvertNomsValueToValue(v)
-	return err == nil
+	switch v.(type) {
+	case types.Bool, types.Null, nil:
+		return true
+	default:
+		return false
+	}
 }
 
 // NomsKind implements TypeInfo interface.
@@ -142,11 +147,11 @@ func (ti *boolType) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *boolType) ParseValue(str *string) (types.Value, error) {
+func (ti *boolType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	if str == nil || *str == "" {
 		return types.NullValue, nil
 	}
-	return ti.ConvertValueToNomsValue(*str)
+	return ti.ConvertValueToNomsValue(ctx, vrw, *str)
 }
 
 // String implements TypeInfo interface.
@@ -0,0 +1,186 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// String columns compare their values according to their collation, so that with the default utf8mb4_0900_ai_ci
+// collation 'acme' equals 'ACME' and 'Acmé'. String columns created before collations were supported compare their
+// values byte-wise whatever collation they declare, as their existing index data is ordered that way. Such columns
+// use their collation once they're modified by ALTER TABLE, which rebuilds their indexes.
+
+// collationRules describe how a collation compares strings.
+type collationRules struct {
+	// binary collations compare the bytes of strings
+	binary bool
+	// padSpace collations ignore trailing spaces
+	padSpace bool
+	// ignoreCase and ignoreAccents control the comparisons of the other collations, which follow the Unicode
+	// Collation Algorithm
+	ignoreCase    bool
+	ignoreAccents bool
+}
+
+// rulesForCollation returns the rules of the collation given, which are derived from its name as in MySQL: _bin
+// collations compare bytes, and _ci collations are case-insensitive and, unless they're accent-sensitive (_as_ci),
+// accent-insensitive. Of the other collations, only the UCA 9.0.0 (_0900_) collations don't pad with spaces.
+func rulesForCollation(collation sql.Collation) collationRules {
+	name := strings.ToLower(collation.String())
+	rules := collationRules{padSpace: !strings.Contains(name, "_0900_")}
+	switch {
+	case collation == sql.Collation_binary || strings.HasSuffix(name, "_bin"):
+		return collationRules{binary: true}
+	case strings.HasSuffix(name, "_as_ci"):
+		rules.ignoreCase = true
+	case strings.HasSuffix(name, "_ci"):
+		rules.ignoreCase = true
+		rules.ignoreAccents = true
+	}
+	return rules
+}
+
+// collators holds a pool of collators for each combination of ignoreCase and ignoreAccents, as collators can't be
+// used concurrently.
+var collators [2][2]sync.Pool
+
+func init() {
+	for _, ignoreCase := range []bool{false, true} {
+		for _, ignoreAccents := range []bool{false, true} {
+			var opts []collate.Option
+			if ignoreCase {
+				opts = append(opts, collate.IgnoreCase, collate.IgnoreWidth)
+			}
+			if ignoreAccents {
+				opts = append(opts, collate.IgnoreDiacritics)
+			}
+			collators[boolIndex(ignoreCase)][boolIndex(ignoreAccents)].New = func() interface{} {
+				return collate.New(language.Und, opts...)
+			}
+		}
+	}
+}
+
+func boolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (rules collationRules) trim(s string) string {
+	if rules.padSpace {
+		return strings.TrimRight(s, " ")
+	}
+	return s
+}
+
+func (rules collationRules) compare(a, b string) int {
+	if rules.binary {
+		return strings.Compare(a, b)
+	}
+	a, b = rules.trim(a), rules.trim(b)
+	pool := &collators[boolIndex(rules.ignoreCase)][boolIndex(rules.ignoreAccents)]
+	c := pool.Get().(*collate.Collator)
+	defer pool.Put(c)
+	return c.CompareString(a, b)
+}
+
+func (rules collationRules) key(s string) []byte {
+	if rules.binary {
+		return []byte(s)
+	}
+	s = rules.trim(s)
+	pool := &collators[boolIndex(rules.ignoreCase)][boolIndex(rules.ignoreAccents)]
+	c := pool.Get().(*collate.Collator)
+	defer pool.Put(c)
+	var buf collate.Buffer
+	return c.KeyFromString(&buf, s)
+}
+
+// CompareCollated compares two strings according to the collation given, returning -1, 0 or 1.
+func CompareCollated(collation sql.Collation, a, b string) int {
+	return rulesForCollation(collation).compare(a, b)
+}
+
+// CollationKey returns the sort key of a string for the collation given. Keys compare byte-wise in the order of their
+// strings in the collation, and strings that are equal according to the collation have equal keys.
+func CollationKey(collation sql.Collation, s string) []byte {
+	return rulesForCollation(collation).key(s)
+}
+
+// CollationOf returns the collation of the string type given, and whether values of the type are compared using it.
+func CollationOf(ti TypeInfo) (sql.Collation, bool) {
+	switch ti := ti.(type) {
+	case *varStringType:
+		return ti.sqlStringType.Collation(), ti.collated
+	case *blobStringType:
+		return ti.sqlStringType.Collation(), ti.collated
+	default:
+		return "", false
+	}
+}
+
+// IsCollated returns whether the values of the type given are compared using a collation that differs from comparing
+// their bytes.
+func IsCollated(ti TypeInfo) bool {
+	collation, ok := CollationOf(ti)
+	return ok && !rulesForCollation(collation).binary
+}
+
+// ByteWise returns the type given with its values compared byte-wise rather than by its collation, such as for the
+// primary key values that follow the collation keys of indexed values in index keys.
+func ByteWise(ti TypeInfo) TypeInfo {
+	switch ti := ti.(type) {
+	case *varStringType:
+		return &varStringType{ti.sqlStringType, false}
+	case *blobStringType:
+		return &blobStringType{ti.sqlStringType, false}
+	default:
+		return ti
+	}
+}
+
+// CollationKeyValue returns the value that represents the value given in index keys. Values of collated types are
+// represented by their collation key, so that indexes are ordered by the collation and values that are equal according
+// to it share a key. Other values are returned unchanged.
+func CollationKeyValue(ti TypeInfo, v types.Value) types.Value {
+	str, ok := v.(types.String)
+	if !ok || !IsCollated(ti) {
+		return v
+	}
+	collation, _ := CollationOf(ti)
+	return types.InlineBlob(CollationKey(collation, string(str)))
+}
+
+// ValuesEqual returns whether two values of the type given are equal, according to the type's collation if it has
+// one.
+func ValuesEqual(ti TypeInfo, a, b types.Value) bool {
+	strA, okA := a.(types.String)
+	strB, okB := b.(types.String)
+	if okA && okB && IsCollated(ti) {
+		collation, _ := CollationOf(ti)
+		return CompareCollated(collation, string(strA), string(strB)) == 0
+	}
+	return a.Equals(b)
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestCompareCollated(t *testing.T) {
+	tests := []struct {
+		collation sql.Collation
+		a         string
+		b         string
+		output    int
+	}{
+		{sql.Collation_utf8mb4_0900_ai_ci, "acme", "ACME", 0},
+		{sql.Collation_utf8mb4_0900_ai_ci, "café", "CAFE", 0},
+		{sql.Collation_utf8mb4_0900_ai_ci, "alpha", "Beta", -1},
+		{sql.Collation_utf8mb4_0900_ai_ci, "b", "a ", 1},
+		{sql.Collation_utf8mb4_0900_ai_ci, "a", "a ", -1},
+		{sql.Collation_utf8mb4_general_ci, "a", "a ", 0},
+		{sql.Collation_utf8mb4_0900_as_ci, "café", "CAFE", 1},
+		{sql.Collation_utf8mb4_0900_as_ci, "café", "CAFÉ", 0},
+		{sql.Collation_utf8mb4_bin, "acme", "ACME", 1},
+		{sql.Collation_binary, "B", "a", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v %v", test.collation, test.a, test.b), func(t *testing.T) {
+			assert.Equal(t, test.output, CompareCollated(test.collation, test.a, test.b))
+			assert.Equal(t, -test.output, CompareCollated(test.collation, test.b, test.a))
+		})
+	}
+}
+
+func TestCollationKeyValue(t *testing.T) {
+	collated := &varStringType{sql.CreateLongText(sql.Collation_Default), true}
+	legacy := &varStringType{sql.CreateLongText(sql.Collation_Default), false}
+	binary := &varStringType{sql.CreateLongText(sql.Collation_utf8mb4_bin), true}
+
+	assert.True(t, IsCollated(collated))
+	assert.False(t, IsCollated(legacy))
+	assert.False(t, IsCollated(binary))
+	assert.False(t, IsCollated(Int32Type))
+
+	lower := CollationKeyValue(collated, types.String("acme"))
+	upper := CollationKeyValue(collated, types.String("ACME"))
+	other := CollationKeyValue(collated, types.String("beta"))
+	require.Equal(t, types.InlineBlobKind, lower.Kind())
+	assert.True(t, lower.Equals(upper))
+	less, err := lower.Less(types.Format_Default, other)
+	require.NoError(t, err)
+	assert.True(t, less)
+	assert.Equal(t, types.NullValue, CollationKeyValue(collated, types.NullValue))
+
+	assert.Equal(t, types.String("acme"), CollationKeyValue(legacy, types.String("acme")))
+	assert.Equal(t, types.String("acme"), CollationKeyValue(binary, types.String("acme")))
+
+	assert.True(t, ValuesEqual(collated, types.String("acme"), types.String("ACME")))
+	assert.False(t, ValuesEqual(legacy, types.String("acme"), types.String("ACME")))
+	assert.True(t, ValuesEqual(Int32Type, types.Int(1), types.Int(1)))
+}
+
+func TestCollatedParams(t *testing.T) {
+	ti, err := FromSqlType(sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20))
+	require.NoError(t, err)
+	assert.True(t, IsCollated(ti))
+	roundTrip, err := FromTypeParams(ti.GetTypeIdentifier(), ti.GetTypeParams())
+	require.NoError(t, err)
+	assert.True(t, ti.Equals(roundTrip))
+
+	// columns persisted before collations were supported have no collated param
+	params := ti.GetTypeParams()
+	delete(params, varStringTypeParam_Collated)
+	legacy, err := FromTypeParams(ti.GetTypeIdentifier(), params)
+	require.NoError(t, err)
+	assert.False(t, IsCollated(legacy))
+	assert.True(t, ti.Equals(legacy))
+}
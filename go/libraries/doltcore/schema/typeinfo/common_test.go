@@ -152,10 +152,10 @@ func generateVarStringType(t *testing.T, length int64, rts bool) *varStringType
 	if rts {
 		t, err := sql.CreateStringWithDefaults(sqltypes.Char, length)
 		if err == nil {
-			return &varStringType{t}
+			return &varStringType{t, true}
 		}
 	}
-	return &varStringType{sql.MustCreateStringWithDefaults(sqltypes.VarChar, length)}
+	return &varStringType{sql.MustCreateStringWithDefaults(sqltypes.VarChar, length), true}
 }
 
 func loop(t *testing.T, start int64, endInclusive int64, numOfSteps uint16, loopedFunc func(int64)) {
@@ -0,0 +1,297 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/geometry"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Spatial values are stored as InlineBlobs holding the format MySQL uses for them, which is the SRID of the value
+// followed by its well-known binary. Spatial values are given to the engine as geometry.Geometry values, and are
+// written to files as text in the form "SRID=4326;POINT(1 2)", with the SRID prefix omitted when it's 0.
+const (
+	geometryTypeParam_Type = "type"
+)
+
+type geometryType struct {
+	sqlGeometryType sqlGeometryType
+}
+
+var _ TypeInfo = (*geometryType)(nil)
+
+var (
+	GeometryType   = &geometryType{sqlGeometryType{geometry.GeometryTypeName}}
+	PointType      = &geometryType{sqlGeometryType{geometry.PointTypeName}}
+	LineStringType = &geometryType{sqlGeometryType{geometry.LineStringTypeName}}
+	PolygonType    = &geometryType{sqlGeometryType{geometry.PolygonTypeName}}
+)
+
+func CreateGeometryTypeFromParams(params map[string]string) (TypeInfo, error) {
+	if typeName, ok := params[geometryTypeParam_Type]; ok {
+		if ti, ok := GeometryTypeFromName(typeName); ok {
+			return ti, nil
+		}
+		return nil, fmt.Errorf(`create geometry type info has "%v" param with value "%v"`, geometryTypeParam_Type, typeName)
+	}
+	return nil, fmt.Errorf(`create geometry type info is missing "%v" param`, geometryTypeParam_Type)
+}
+
+// GeometryTypeFromName returns the spatial TypeInfo for the SQL type name given, such as POINT, and whether the name
+// is one of a spatial type.
+func GeometryTypeFromName(name string) (TypeInfo, bool) {
+	switch strings.ToUpper(name) {
+	case geometry.GeometryTypeName:
+		return GeometryType, true
+	case geometry.PointTypeName:
+		return PointType, true
+	case geometry.LineStringTypeName:
+		return LineStringType, true
+	case geometry.PolygonTypeName:
+		return PolygonType, true
+	default:
+		return nil, false
+	}
+}
+
+// ConvertNomsValueToValue implements TypeInfo interface.
+func (ti *geometryType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
+	if val, ok := v.(types.InlineBlob); ok {
+		return geometry.Deserialize(val)
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), v.Kind())
+}
+
+// ConvertValueToNomsValue implements TypeInfo interface.
+func (ti *geometryType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	var g interface{}
+	var err error
+	switch val := v.(type) {
+	case nil:
+		return types.NullValue, nil
+	case map[string]interface{}:
+		// GeoJSON objects, such as those read from JSON files
+		g, err = geometry.FromGeoJSON(val)
+		if err == nil {
+			g, err = ti.sqlGeometryType.Convert(g)
+		}
+	case string:
+		// text is parsed as well-known text when it's not in the storage format
+		g, err = ti.sqlGeometryType.Convert(val)
+		if err != nil {
+			if parsed, parseErr := geometry.FromEWKT(val); parseErr == nil {
+				g, err = ti.sqlGeometryType.Convert(parsed)
+			}
+		}
+	default:
+		g, err = ti.sqlGeometryType.Convert(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return types.InlineBlob(geometry.Serialize(g.(geometry.Geometry))), nil
+}
+
+// Equals implements TypeInfo interface.
+func (ti *geometryType) Equals(other TypeInfo) bool {
+	if other == nil {
+		return false
+	}
+	if ti2, ok := other.(*geometryType); ok {
+		return ti.sqlGeometryType.typeName == ti2.sqlGeometryType.typeName
+	}
+	return false
+}
+
+// FormatValue implements TypeInfo interface.
+func (ti *geometryType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
+	if val, ok := v.(types.InlineBlob); ok {
+		g, err := geometry.Deserialize(val)
+		if err != nil {
+			return nil, err
+		}
+		res := geometry.ToEWKT(g)
+		return &res, nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a string`, ti.String(), v.Kind())
+}
+
+// GetTypeIdentifier implements TypeInfo interface.
+func (ti *geometryType) GetTypeIdentifier() Identifier {
+	return GeometryTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo interface.
+func (ti *geometryType) GetTypeParams() map[string]string {
+	return map[string]string{geometryTypeParam_Type: ti.sqlGeometryType.typeName}
+}
+
+// IsValid implements TypeInfo interface.
+func (ti *geometryType) IsValid(v types.Value) bool {
+	if val, ok := v.(types.InlineBlob); ok {
+		_, err := ti.sqlGeometryType.Convert([]byte(val))
+		return err == nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return true
+	}
+	return false
+}
+
+// NomsKind implements TypeInfo interface.
+func (ti *geometryType) NomsKind() types.NomsKind {
+	return types.InlineBlobKind
+}
+
+// ParseValue implements TypeInfo interface.
+func (ti *geometryType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil || *str == "" {
+		return types.NullValue, nil
+	}
+	g, err := geometry.FromEWKT(*str)
+	if err != nil {
+		return nil, fmt.Errorf(`"%v" cannot convert the string "%v" to a value: %v`, ti.String(), *str, err)
+	}
+	return ti.ConvertValueToNomsValue(ctx, vrw, g)
+}
+
+// String implements TypeInfo interface.
+func (ti *geometryType) String() string {
+	return ti.sqlGeometryType.String()
+}
+
+// ToSqlType implements TypeInfo interface.
+func (ti *geometryType) ToSqlType() sql.Type {
+	return ti.sqlGeometryType
+}
+
+// sqlGeometryType is the sql.Type of spatial columns, which the engine doesn't provide. Its values are
+// geometry.Geometry values, and it accepts those as well as values in the storage format.
+type sqlGeometryType struct {
+	typeName string
+}
+
+var _ sql.Type = sqlGeometryType{}
+
+// Compare implements sql.Type interface.
+func (t sqlGeometryType) Compare(a interface{}, b interface{}) (int, error) {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0, nil
+		case a == nil:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+	ga, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	gb, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Compare(geometry.Serialize(ga.(geometry.Geometry)), geometry.Serialize(gb.(geometry.Geometry))), nil
+}
+
+// Convert implements sql.Type interface.
+func (t sqlGeometryType) Convert(v interface{}) (interface{}, error) {
+	var g geometry.Geometry
+	var err error
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case geometry.Geometry:
+		g = val
+	case []byte:
+		g, err = geometry.Deserialize(val)
+	case string:
+		g, err = geometry.Deserialize([]byte(val))
+	default:
+		return nil, fmt.Errorf("cannot convert value of type %T to %s", v, t.typeName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get geometry object from data sent to the %s field: %v", t.typeName, err)
+	}
+	if t.typeName != geometry.GeometryTypeName && g.GeometryType() != t.typeName {
+		return nil, fmt.Errorf("cannot store a %s in a %s field", g.GeometryType(), t.typeName)
+	}
+	return g, nil
+}
+
+// MustConvert implements sql.Type interface.
+func (t sqlGeometryType) MustConvert(v interface{}) interface{} {
+	value, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Promote implements sql.Type interface.
+func (t sqlGeometryType) Promote() sql.Type {
+	return sqlGeometryType{geometry.GeometryTypeName}
+}
+
+// SQL implements sql.Type interface.
+func (t sqlGeometryType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+	g, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	return sqltypes.MakeTrusted(sqltypes.Geometry, geometry.Serialize(g.(geometry.Geometry))), nil
+}
+
+// String implements sql.Type interface.
+func (t sqlGeometryType) String() string {
+	return t.typeName
+}
+
+// Type implements sql.Type interface.
+func (t sqlGeometryType) Type() query.Type {
+	return sqltypes.Geometry
+}
+
+// Zero implements sql.Type interface.
+func (t sqlGeometryType) Zero() interface{} {
+	switch t.typeName {
+	case geometry.LineStringTypeName:
+		return geometry.LineString{Points: []geometry.Point{{}, {}}}
+	case geometry.PolygonTypeName:
+		return geometry.Polygon{Rings: []geometry.LineString{{Points: []geometry.Point{{}, {}, {}, {}}}}}
+	default:
+		return geometry.Point{}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/geometry"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestGeometryConvertValueToNomsValue(t *testing.T) {
+	point := geometry.Point{SRID: 4326, X: 1, Y: 2}
+	line := geometry.LineString{Points: []geometry.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+
+	tests := []struct {
+		typ         TypeInfo
+		input       interface{}
+		output      geometry.Geometry
+		expectedErr bool
+	}{
+		{PointType, point, point, false},
+		{PointType, geometry.Serialize(point), point, false},
+		{PointType, string(geometry.Serialize(point)), point, false},
+		{PointType, "SRID=4326;POINT(1 2)", point, false},
+		{PointType, map[string]interface{}{"type": "Point", "coordinates": []interface{}{1.0, 2.0},
+			"crs": map[string]interface{}{"type": "name", "properties": map[string]interface{}{"name": "EPSG:4326"}}}, point, false},
+		{PointType, line, nil, true},
+		{PointType, "LINESTRING(0 0,1 1)", nil, true},
+		{PointType, "POINT(1)", nil, true},
+		{PointType, 5, nil, true},
+		{LineStringType, "LINESTRING(0 0, 1 1)", line, false},
+		{GeometryType, line, line, false},
+		{GeometryType, point, point, false},
+		{PolygonType, "POLYGON((0 0,1 0,1 1,0 1))", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typ.String(), func(t *testing.T) {
+			val, err := test.typ.ConvertValueToNomsValue(context.Background(), nil, test.input)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, types.InlineBlob(geometry.Serialize(test.output)), val)
+			assert.True(t, test.typ.IsValid(val))
+
+			g, err := test.typ.ConvertNomsValueToValue(context.Background(), val)
+			require.NoError(t, err)
+			assert.Equal(t, test.output, g)
+		})
+	}
+}
+
+func TestGeometryFormatValue(t *testing.T) {
+	tests := []struct {
+		input  geometry.Geometry
+		output string
+	}{
+		{geometry.Point{X: 1.5, Y: -2}, "POINT(1.5 -2)"},
+		{geometry.Point{SRID: 4326, X: 1, Y: 2}, "SRID=4326;POINT(1 2)"},
+		{geometry.LineString{Points: []geometry.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}, "LINESTRING(0 0,1 1)"},
+		{geometry.Polygon{Rings: []geometry.LineString{{Points: []geometry.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}},
+			"POLYGON((0 0,1 0,1 1,0 0))"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.output, func(t *testing.T) {
+			str, err := GeometryType.FormatValue(context.Background(), types.InlineBlob(geometry.Serialize(test.input)))
+			require.NoError(t, err)
+			assert.Equal(t, test.output, *str)
+
+			val, err := GeometryType.ParseValue(context.Background(), nil, str)
+			require.NoError(t, err)
+			assert.Equal(t, types.InlineBlob(geometry.Serialize(test.input)), val)
+		})
+	}
+
+	assert.False(t, PointType.IsValid(types.InlineBlob{1, 2, 3}))
+}
@@ -15,8 +15,10 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"strconv"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/vitess/go/sqltypes"
@@ -24,6 +26,15 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+const (
+	inlineBlobTypeParam_Length        = "length"
+	inlineBlobTypeParam_SQL           = "sql"
+	inlineBlobTypeParam_SQL_Binary    = "bin"
+	inlineBlobTypeParam_SQL_VarBinary = "varbin"
+)
+
+// inlineBlobType stores binary values inline in the row as an InlineBlob. BINARY and VARBINARY columns use it, so that
+// they may be part of a primary key or an index, unlike BLOB columns, whose values are stored out-of-line.
 type inlineBlobType struct {
 	sqlBinaryType sql.StringType
 }
@@ -32,8 +43,41 @@ var _ TypeInfo = (*inlineBlobType)(nil)
 
 var InlineBlobType = &inlineBlobType{sql.MustCreateBinary(sqltypes.VarBinary, math.MaxUint16)}
 
+func CreateInlineBlobTypeFromParams(params map[string]string) (TypeInfo, error) {
+	if len(params) == 0 {
+		return InlineBlobType, nil
+	}
+	var length int64
+	var err error
+	if lengthStr, ok := params[inlineBlobTypeParam_Length]; ok {
+		length, err = strconv.ParseInt(lengthStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf(`create inlineblob type info is missing param "%v"`, inlineBlobTypeParam_Length)
+	}
+	if sqlStr, ok := params[inlineBlobTypeParam_SQL]; ok {
+		var sqlType sql.StringType
+		switch sqlStr {
+		case inlineBlobTypeParam_SQL_Binary:
+			sqlType, err = sql.CreateBinary(sqltypes.Binary, length)
+		case inlineBlobTypeParam_SQL_VarBinary:
+			sqlType, err = sql.CreateBinary(sqltypes.VarBinary, length)
+		default:
+			return nil, fmt.Errorf(`create inlineblob type info has "%v" param with value "%v"`, inlineBlobTypeParam_SQL, sqlStr)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &inlineBlobType{sqlType}, nil
+	} else {
+		return nil, fmt.Errorf(`create inlineblob type info is missing param "%v"`, inlineBlobTypeParam_SQL)
+	}
+}
+
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *inlineBlobType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+func (ti *inlineBlobType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
 	if val, ok := v.(types.InlineBlob); ok {
 		return string(val), nil
 	}
@@ -44,7 +88,7 @@ func (ti *inlineBlobType) ConvertNomsValueToValue(v types.Value) (interface{}, e
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *inlineBlobType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *inlineBlobType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	if v == nil {
 		return types.NullValue, nil
 	}
@@ -64,14 +108,17 @@ func (ti *inlineBlobType) Equals(other TypeInfo) bool {
 	if other == nil {
 		return false
 	}
-	_, ok := other.(*inlineBlobType)
-	return ok
+	if ti2, ok := other.(*inlineBlobType); ok {
+		return ti.sqlBinaryType.MaxCharacterLength() == ti2.sqlBinaryType.MaxCharacterLength() &&
+			ti.sqlBinaryType.Type() == ti2.sqlBinaryType.Type()
+	}
+	return false
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *inlineBlobType) FormatValue(v types.Value) (*string, error) {
+func (ti *inlineBlobType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
 	if val, ok := v.(types.InlineBlob); ok {
-		convVal, err := ti.ConvertNomsValueToValue(val)
+		convVal, err := ti.ConvertNomsValueToValue(ctx, val)
 		if err != nil {
 			return nil, err
 		}
@@ -94,13 +141,30 @@ func (ti *inlineBlobType) GetTypeIdentifier() Identifier {
 
 // GetTypeParams implements TypeInfo interface.
 func (ti *inlineBlobType) GetTypeParams() map[string]string {
-	return nil
+	if ti.isDefault() {
+		return nil
+	}
+	typeParams := map[string]string{
+		inlineBlobTypeParam_Length: strconv.FormatInt(ti.sqlBinaryType.MaxCharacterLength(), 10),
+	}
+	switch ti.sqlBinaryType.Type() {
+	case sqltypes.Binary:
+		typeParams[inlineBlobTypeParam_SQL] = inlineBlobTypeParam_SQL_Binary
+	case sqltypes.VarBinary:
+		typeParams[inlineBlobTypeParam_SQL] = inlineBlobTypeParam_SQL_VarBinary
+	default:
+		panic(fmt.Errorf(`unknown inlineblob type info sql type "%v"`, ti.sqlBinaryType.Type().String()))
+	}
+	return typeParams
 }
 
 // IsValid implements TypeInfo interface.
 func (ti *inlineBlobType) IsValid(v types.Value) bool {
-	if _, ok := v.(types.InlineBlob); ok {
-		return true
+	if val, ok := v.(types.InlineBlob); ok {
+		if ti.sqlBinaryType.Type() == sqltypes.Binary {
+			return int64(len(val)) == ti.sqlBinaryType.MaxByteLength()
+		}
+		return int64(len(val)) <= ti.sqlBinaryType.MaxByteLength()
 	}
 	if _, ok := v.(types.Null); ok || v == nil {
 		return true
@@ -114,7 +178,7 @@ func (ti *inlineBlobType) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *inlineBlobType) ParseValue(str *string) (types.Value, error) {
+func (ti *inlineBlobType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	if str == nil || *str == "" {
 		return types.NullValue, nil
 	}
@@ -130,10 +194,27 @@ func (ti *inlineBlobType) ParseValue(str *string) (types.Value, error) {
 
 // String implements TypeInfo interface.
 func (ti *inlineBlobType) String() string {
-	return "InlineBlob"
+	if ti.isDefault() {
+		return "InlineBlob"
+	}
+	sqlType := ""
+	switch ti.sqlBinaryType.Type() {
+	case sqltypes.Binary:
+		sqlType = "Binary"
+	case sqltypes.VarBinary:
+		sqlType = "VarBinary"
+	default:
+		panic(fmt.Errorf(`unknown inlineblob type info sql type "%v"`, ti.sqlBinaryType.Type().String()))
+	}
+	return fmt.Sprintf(`InlineBlob(%v, SQL: %v)`, ti.sqlBinaryType.MaxCharacterLength(), sqlType)
 }
 
 // ToSqlType implements TypeInfo interface.
 func (ti *inlineBlobType) ToSqlType() sql.Type {
 	return ti.sqlBinaryType
 }
+
+// isDefault returns whether this is InlineBlobType, which is serialized without any params.
+func (ti *inlineBlobType) isDefault() bool {
+	return ti.sqlBinaryType.Type() == sqltypes.VarBinary && ti.sqlBinaryType.MaxCharacterLength() == math.MaxUint16
+}
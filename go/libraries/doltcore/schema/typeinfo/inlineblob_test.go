@@ -15,16 +15,39 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+func TestInlineBlobFromSqlType(t *testing.T) {
+	for _, sqlType := range []sql.Type{
+		sql.MustCreateBinary(sqltypes.Binary, 10),
+		sql.MustCreateBinary(sqltypes.VarBinary, 10),
+		sql.MustCreateBinary(sqltypes.VarBinary, 65535),
+	} {
+		t.Run(sqlType.String(), func(t *testing.T) {
+			ti, err := FromSqlType(sqlType)
+			require.NoError(t, err)
+			assert.Equal(t, InlineBlobTypeIdentifier, ti.GetTypeIdentifier())
+			assert.Equal(t, types.InlineBlobKind, ti.NomsKind())
+			assert.Equal(t, sqlType, ti.ToSqlType())
+
+			fromParams, err := FromTypeParams(ti.GetTypeIdentifier(), ti.GetTypeParams())
+			require.NoError(t, err)
+			assert.True(t, ti.Equals(fromParams))
+		})
+	}
+}
+
 func TestInlineBlobConvertNomsValueToValue(t *testing.T) {
 	tests := []struct {
 		input  types.InlineBlob
@@ -46,7 +69,7 @@ func TestInlineBlobConvertNomsValueToValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, InlineBlobType.String(), test.input), func(t *testing.T) {
-			output, err := InlineBlobType.ConvertNomsValueToValue(test.input)
+			output, err := InlineBlobType.ConvertNomsValueToValue(context.Background(), test.input)
 			require.NoError(t, err)
 			require.Equal(t, test.output, output)
 		})
@@ -93,7 +116,7 @@ func TestInlineBlobConvertValueToNomsValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, InlineBlobType.String(), test.input), func(t *testing.T) {
-			output, err := InlineBlobType.ConvertValueToNomsValue(test.input)
+			output, err := InlineBlobType.ConvertValueToNomsValue(context.Background(), nil, test.input)
 			if !test.expectedErr {
 				require.NoError(t, err)
 				assert.Equal(t, test.output, output)
@@ -125,7 +148,7 @@ func TestInlineBlobFormatValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, InlineBlobType.String(), test.input), func(t *testing.T) {
-			output, err := InlineBlobType.FormatValue(test.input)
+			output, err := InlineBlobType.FormatValue(context.Background(), test.input)
 			require.NoError(t, err)
 			require.Equal(t, test.output, *output)
 		})
@@ -153,7 +176,7 @@ func TestInlineBlobParseValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, InlineBlobType.String(), test.input), func(t *testing.T) {
-			output, err := InlineBlobType.ParseValue(&test.input)
+			output, err := InlineBlobType.ParseValue(context.Background(), nil, &test.input)
 			require.NoError(t, err)
 			assert.Equal(t, test.output, output)
 		})
@@ -0,0 +1,307 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// A JSON document is stored as a Tuple holding its encoded root value. Scalars are stored as their Noms equivalents,
+// with JSON null stored as NullValue and numbers stored exactly as Decimals, whose encoding doesn't depend on the
+// NomsBinFormat. Arrays and objects are stored as Tuples whose first value marks the kind of container. The members
+// of an object follow as alternating keys and values, sorted by key, so that documents which differ only in the order
+// of their members or the formatting of their numbers are stored identically.
+const (
+	jsonArrayMarker  = types.Uint(0)
+	jsonObjectMarker = types.Uint(1)
+)
+
+type jsonType struct{}
+
+var _ TypeInfo = (*jsonType)(nil)
+
+var JSONType = &jsonType{}
+
+// ConvertNomsValueToValue implements TypeInfo interface.
+func (ti *jsonType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
+	if val, ok := v.(types.Tuple); ok {
+		doc, err := decodeJSONDocument(val)
+		if err != nil {
+			return nil, err
+		}
+		return marshalJSON(doc)
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), v.Kind())
+}
+
+// ConvertValueToNomsValue implements TypeInfo interface.
+func (ti *jsonType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	var doc interface{}
+	switch val := v.(type) {
+	case nil:
+		return types.NullValue, nil
+	case string:
+		if err := unmarshalJSON([]byte(val), &doc); err != nil {
+			return nil, fmt.Errorf(`"%v" cannot convert the string "%v" to a value: invalid JSON text`, ti.String(), val)
+		}
+	case []byte:
+		if err := unmarshalJSON(val, &doc); err != nil {
+			return nil, fmt.Errorf(`"%v" cannot convert the string "%v" to a value: invalid JSON text`, ti.String(), string(val))
+		}
+	default:
+		// values produced by the engine, such as the results of JSON_EXTRACT, are normalized through their encoding
+		data, err := json.Marshal(val)
+		if err != nil {
+			return nil, fmt.Errorf(`"%v" cannot convert value "%v" of type "%T" as it is invalid`, ti.String(), v, v)
+		}
+		if err = unmarshalJSON(data, &doc); err != nil {
+			return nil, err
+		}
+	}
+	return encodeJSONDocument(doc)
+}
+
+// Equals implements TypeInfo interface.
+func (ti *jsonType) Equals(other TypeInfo) bool {
+	if other == nil {
+		return false
+	}
+	_, ok := other.(*jsonType)
+	return ok
+}
+
+// FormatValue implements TypeInfo interface.
+func (ti *jsonType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
+	if val, ok := v.(types.Tuple); ok {
+		convVal, err := ti.ConvertNomsValueToValue(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		res := string(convVal.([]byte))
+		return &res, nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a string`, ti.String(), v.Kind())
+}
+
+// GetTypeIdentifier implements TypeInfo interface.
+func (ti *jsonType) GetTypeIdentifier() Identifier {
+	return JSONTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo interface.
+func (ti *jsonType) GetTypeParams() map[string]string {
+	return nil
+}
+
+// IsValid implements TypeInfo interface.
+func (ti *jsonType) IsValid(v types.Value) bool {
+	if val, ok := v.(types.Tuple); ok {
+		_, err := decodeJSONDocument(val)
+		return err == nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return true
+	}
+	return false
+}
+
+// NomsKind implements TypeInfo interface.
+func (ti *jsonType) NomsKind() types.NomsKind {
+	return types.TupleKind
+}
+
+// ParseValue implements TypeInfo interface.
+func (ti *jsonType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil || *str == "" {
+		return types.NullValue, nil
+	}
+	return ti.ConvertValueToNomsValue(ctx, vrw, *str)
+}
+
+// String implements TypeInfo interface.
+func (ti *jsonType) String() string {
+	return "JSON"
+}
+
+// ToSqlType implements TypeInfo interface.
+func (ti *jsonType) ToSqlType() sql.Type {
+	return sql.JSON
+}
+
+// IsJSONType returns whether the given TypeInfo represents a JSON document.
+func IsJSONType(ti TypeInfo) bool {
+	_, ok := ti.(*jsonType)
+	return ok
+}
+
+func encodeJSONDocument(doc interface{}) (types.Value, error) {
+	root, err := encodeJSONValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTuple(types.Format_Default, root)
+}
+
+func encodeJSONValue(val interface{}) (types.Value, error) {
+	switch val := val.(type) {
+	case nil:
+		return types.NullValue, nil
+	case bool:
+		return types.Bool(val), nil
+	case json.Number:
+		dec, err := decimal.NewFromString(string(val))
+		if err != nil {
+			return nil, err
+		}
+		// the decimal's string representation trims trailing zeros, giving a canonical exponent
+		return types.Decimal(decimal.RequireFromString(dec.String())), nil
+	case string:
+		return types.String(val), nil
+	case []interface{}:
+		vals := make([]types.Value, 0, len(val)+1)
+		vals = append(vals, jsonArrayMarker)
+		for _, elem := range val {
+			v, err := encodeJSONValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, v)
+		}
+		return types.NewTuple(types.Format_Default, vals...)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		vals := make([]types.Value, 0, 2*len(val)+1)
+		vals = append(vals, jsonObjectMarker)
+		for _, k := range keys {
+			v, err := encodeJSONValue(val[k])
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, types.String(k), v)
+		}
+		return types.NewTuple(types.Format_Default, vals...)
+	default:
+		return nil, fmt.Errorf(`unexpected JSON value of type "%T"`, val)
+	}
+}
+
+func decodeJSONDocument(tpl types.Tuple) (interface{}, error) {
+	if tpl.Len() != 1 {
+		return nil, fmt.Errorf("invalid JSON document")
+	}
+	root, err := tpl.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONValue(root)
+}
+
+func decodeJSONValue(val types.Value) (interface{}, error) {
+	switch val := val.(type) {
+	case types.Null:
+		return nil, nil
+	case types.Bool:
+		return bool(val), nil
+	case types.Decimal:
+		return json.Number(decimal.Decimal(val).String()), nil
+	case types.String:
+		return string(val), nil
+	case types.Tuple:
+		vals, err := val.AsSlice()
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) == 0 {
+			return nil, fmt.Errorf("invalid JSON container")
+		}
+
+		switch vals[0] {
+		case jsonArrayMarker:
+			arr := make([]interface{}, 0, len(vals)-1)
+			for _, v := range vals[1:] {
+				elem, err := decodeJSONValue(v)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, elem)
+			}
+			return arr, nil
+		case jsonObjectMarker:
+			if len(vals)%2 != 1 {
+				return nil, fmt.Errorf("invalid JSON object")
+			}
+			obj := make(map[string]interface{}, len(vals)/2)
+			for i := 1; i < len(vals); i += 2 {
+				k, ok := vals[i].(types.String)
+				if !ok {
+					return nil, fmt.Errorf("invalid JSON object key")
+				}
+				v, err := decodeJSONValue(vals[i+1])
+				if err != nil {
+					return nil, err
+				}
+				obj[string(k)] = v
+			}
+			return obj, nil
+		}
+		return nil, fmt.Errorf("invalid JSON container")
+	default:
+		return nil, fmt.Errorf(`unexpected NomsKind "%v" in JSON document`, val.Kind())
+	}
+}
+
+// unmarshalJSON decodes a JSON document, keeping the exact text of its numbers.
+func unmarshalJSON(data []byte, doc *interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(doc); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("unexpected data following JSON document")
+	}
+	return nil
+}
+
+// marshalJSON returns the canonical encoding of a JSON document, with the members of objects sorted by key.
+func marshalJSON(doc interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func mustJSONDocument(t *testing.T, str string) types.Value {
+	val, err := JSONType.ConvertValueToNomsValue(context.Background(), nil, str)
+	require.NoError(t, err)
+	return val
+}
+
+func TestJSONConvertValueToNomsValue(t *testing.T) {
+	tests := []struct {
+		input       interface{}
+		output      string
+		expectedErr bool
+	}{
+		{
+			`{"b": 1, "a": [true, null, "x"]}`,
+			`{"a":[true,null,"x"],"b":1}`,
+			false,
+		},
+		{
+			[]byte(`[1.0, 2.50, {"c": "<d>"}]`),
+			`[1,2.5,{"c":"<d>"}]`,
+			false,
+		},
+		{
+			`"abc"`,
+			`"abc"`,
+			false,
+		},
+		{
+			map[string]interface{}{"k": []interface{}{float64(1), "v"}},
+			`{"k":[1,"v"]}`,
+			false,
+		},
+		{
+			int64(12),
+			`12`,
+			false,
+		},
+		{
+			`{"a": 1`,
+			"",
+			true,
+		},
+		{
+			`abc`,
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, JSONType.String(), test.input), func(t *testing.T) {
+			val, err := JSONType.ConvertValueToNomsValue(context.Background(), nil, test.input)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			output, err := JSONType.ConvertNomsValueToValue(context.Background(), val)
+			require.NoError(t, err)
+			assert.Equal(t, test.output, string(output.([]byte)))
+		})
+	}
+}
+
+func TestJSONCanonicalEncoding(t *testing.T) {
+	// documents which differ only in member order and number formatting are stored identically
+	v1 := mustJSONDocument(t, `{"a": 1, "b": {"c": [1, 2], "d": null}}`)
+	v2 := mustJSONDocument(t, `{"b": {"d": null, "c": [1.0, 2e0]}, "a": 1.00}`)
+	assert.True(t, v1.Equals(v2))
+
+	v3 := mustJSONDocument(t, `{"a": 1, "b": {"c": [2, 1], "d": null}}`)
+	assert.False(t, v1.Equals(v3))
+
+	// JSON null is a document, unlike SQL NULL
+	null, err := JSONType.ConvertValueToNomsValue(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.NullValue, null)
+	assert.False(t, types.IsNull(mustJSONDocument(t, `null`)))
+}
+
+func TestJSONFormatParseRoundTrip(t *testing.T) {
+	docs := []string{`null`, `"abc"`, `[1, 2.5, true]`, `{"a": {"b": [null, "c"]}}`, `{"z": 1, "y": [], "x": {}}`}
+	for _, doc := range docs {
+		t.Run(doc, func(t *testing.T) {
+			val := mustJSONDocument(t, doc)
+			assert.True(t, JSONType.IsValid(val))
+			str, err := JSONType.FormatValue(context.Background(), val)
+			require.NoError(t, err)
+			outVal, err := JSONType.ParseValue(context.Background(), nil, str)
+			require.NoError(t, err)
+			assert.True(t, val.Equals(outVal))
+		})
+	}
+
+	assert.False(t, JSONType.IsValid(types.String(`{}`)))
+	_, err := JSONType.FormatValue(context.Background(), types.String(`{}`))
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -65,7 +66,7 @@ func TestTimeConvertNomsValueToValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v`, test.input), func(t *testing.T) {
-			output, err := TimeType.ConvertNomsValueToValue(test.input)
+			output, err := TimeType.ConvertNomsValueToValue(context.Background(), test.input)
 			if test.expectedErr {
 				assert.Error(t, err)
 			} else {
@@ -121,7 +122,7 @@ func TestTimeConvertValueToNomsValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v`, test.input), func(t *testing.T) {
-			output, err := TimeType.ConvertValueToNomsValue(test.input)
+			output, err := TimeType.ConvertValueToNomsValue(context.Background(), nil, test.input)
 			if !test.expectedErr {
 				require.NoError(t, err)
 				assert.Equal(t, test.output, output)
@@ -172,7 +173,7 @@ func TestTimeFormatValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v`, test.input), func(t *testing.T) {
-			output, err := TimeType.FormatValue(test.input)
+			output, err := TimeType.FormatValue(context.Background(), test.input)
 			if test.expectedErr {
 				assert.Error(t, err)
 			} else {
@@ -223,7 +224,7 @@ func TestTimeParseValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v`, test.input), func(t *testing.T) {
-			output, err := TimeType.ParseValue(&test.input)
+			output, err := TimeType.ParseValue(context.Background(), nil, &test.input)
 			if !test.expectedErr {
 				require.NoError(t, err)
 				assert.Equal(t, test.output, output)
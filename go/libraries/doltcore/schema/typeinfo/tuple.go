@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -30,7 +31,7 @@ var _ TypeInfo = (*tupleType)(nil)
 var TupleType = &tupleType{}
 
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *tupleType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+func (ti *tupleType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
 	if _, ok := v.(types.Null); ok {
 		return nil, nil
 	}
@@ -38,7 +39,7 @@ func (ti *tupleType) ConvertNomsValueToValue(v types.Value) (interface{}, error)
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *tupleType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *tupleType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	if tVal, ok := v.(types.Value); ok {
 		return tVal, nil
 	}
@@ -58,7 +59,7 @@ func (ti *tupleType) Equals(other TypeInfo) bool {
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *tupleType) FormatValue(v types.Value) (*string, error) {
+func (ti *tupleType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
 	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a string`, ti.String(), v.Kind())
 }
 
@@ -87,7 +88,7 @@ func (ti *tupleType) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *tupleType) ParseValue(str *string) (types.Value, error) {
+func (ti *tupleType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	return nil, fmt.Errorf(`"%v" cannot parse strings`, ti.String())
 }
 
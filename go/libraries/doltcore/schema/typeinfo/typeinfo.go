@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -28,13 +29,16 @@ type Identifier string
 const (
 	UnknownTypeIdentifier    Identifier = "unknown"
 	BitTypeIdentifier        Identifier = "bit"
+	BlobStringTypeIdentifier Identifier = "blobstring"
 	BoolTypeIdentifier       Identifier = "bool"
 	DatetimeTypeIdentifier   Identifier = "datetime"
 	DecimalTypeIdentifier    Identifier = "decimal"
 	EnumTypeIdentifier       Identifier = "enum"
 	FloatTypeIdentifier      Identifier = "float"
+	GeometryTypeIdentifier   Identifier = "geometry"
 	InlineBlobTypeIdentifier Identifier = "inlineblob"
 	IntTypeIdentifier        Identifier = "int"
+	JSONTypeIdentifier       Identifier = "json"
 	SetTypeIdentifier        Identifier = "set"
 	TimeTypeIdentifier       Identifier = "time"
 	TupleTypeIdentifier      Identifier = "tuple"
@@ -48,13 +52,16 @@ const (
 var Identifiers = map[Identifier]struct{}{
 	UnknownTypeIdentifier:    {},
 	BitTypeIdentifier:        {},
+	BlobStringTypeIdentifier: {},
 	BoolTypeIdentifier:       {},
 	DatetimeTypeIdentifier:   {},
 	DecimalTypeIdentifier:    {},
 	EnumTypeIdentifier:       {},
 	FloatTypeIdentifier:      {},
+	GeometryTypeIdentifier:   {},
 	InlineBlobTypeIdentifier: {},
 	IntTypeIdentifier:        {},
+	JSONTypeIdentifier:       {},
 	SetTypeIdentifier:        {},
 	TimeTypeIdentifier:       {},
 	TupleTypeIdentifier:      {},
@@ -71,17 +78,18 @@ type TypeInfo interface {
 	// parameter is equivalent to the NomsKind returned by this type info. This is intended for retrieval
 	// from storage, thus we do no validation as we assume the stored value is already validated against
 	// the given type.
-	ConvertNomsValueToValue(v types.Value) (interface{}, error)
+	ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error)
 
 	// ConvertValueToNomsValue converts a go value or Noms value to a Noms value. The type of the Noms
-	// value will be equivalent to the NomsKind returned from NomsKind.
-	ConvertValueToNomsValue(v interface{}) (types.Value, error)
+	// value will be equivalent to the NomsKind returned from NomsKind. Values which are stored out-of-line,
+	// such as blobs, are written to the given ValueReadWriter.
+	ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error)
 
 	// Equals returns whether the given TypeInfo is equivalent to this TypeInfo.
 	Equals(other TypeInfo) bool
 
 	// FormatValue returns the stringified version of the value.
-	FormatValue(v types.Value) (*string, error)
+	FormatValue(ctx context.Context, v types.Value) (*string, error)
 
 	// GetTypeIdentifier returns an identifier for this type used for serialization.
 	GetTypeIdentifier() Identifier
@@ -97,7 +105,7 @@ type TypeInfo interface {
 	NomsKind() types.NomsKind
 
 	// ParseValue parses a string and returns a go value that represents it according to this type.
-	ParseValue(str *string) (types.Value, error)
+	ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error)
 
 	// ToSqlType returns the TypeInfo as a sql.Type. If an exact match is able to be made then that is
 	// the one returned, otherwise the sql.Type is the closest match possible.
@@ -157,12 +165,11 @@ func FromSqlType(sqlType sql.Type) (TypeInfo, error) {
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Text"`)
 		}
-		return &varStringType{stringType}, nil
-	case sqltypes.Blob:
-		//TODO: determine the storage format
-		if fmt.Sprintf("a") != "" { // always evaluates to true, compiler won't complain about unreachable code
-			return nil, fmt.Errorf(`"%v" has not yet been implemented`, sqlType.String())
+		if stringType.MaxByteLength() > sql.Text.MaxByteLength() {
+			return &blobStringType{stringType, true}, nil
 		}
+		return &varStringType{stringType, true}, nil
+	case sqltypes.Blob:
 		stringType, ok := sqlType.(sql.StringType)
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Blob"`)
@@ -173,33 +180,25 @@ func FromSqlType(sqlType sql.Type) (TypeInfo, error) {
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "VarChar"`)
 		}
-		return &varStringType{stringType}, nil
+		return &varStringType{stringType, true}, nil
 	case sqltypes.VarBinary:
-		//TODO: determine the storage format
-		if fmt.Sprintf("a") != "" { // always evaluates to true, compiler won't complain about unreachable code
-			return nil, fmt.Errorf(`"%v" has not yet been implemented`, sqlType.String())
-		}
 		stringType, ok := sqlType.(sql.StringType)
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "VarBinary"`)
 		}
-		return &varBinaryType{stringType}, nil
+		return &inlineBlobType{stringType}, nil
 	case sqltypes.Char:
 		stringType, ok := sqlType.(sql.StringType)
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Char"`)
 		}
-		return &varStringType{stringType}, nil
+		return &varStringType{stringType, true}, nil
 	case sqltypes.Binary:
-		//TODO: determine the storage format
-		if fmt.Sprintf("a") != "" { // always evaluates to true, compiler won't complain about unreachable code
-			return nil, fmt.Errorf(`"%v" has not yet been implemented`, sqlType.String())
-		}
 		stringType, ok := sqlType.(sql.StringType)
 		if !ok {
 			return nil, fmt.Errorf(`expected "StringType" from SQL basetype "Binary"`)
 		}
-		return &varBinaryType{stringType}, nil
+		return &inlineBlobType{stringType}, nil
 	case sqltypes.Bit:
 		bitSQLType, ok := sqlType.(sql.BitType)
 		if !ok {
@@ -218,6 +217,14 @@ func FromSqlType(sqlType sql.Type) (TypeInfo, error) {
 			return nil, fmt.Errorf(`expected "SetTypeIdentifier" from SQL basetype "Set"`)
 		}
 		return &setType{setSQLType}, nil
+	case sqltypes.TypeJSON:
+		return JSONType, nil
+	case sqltypes.Geometry:
+		geometrySQLType, ok := sqlType.(sqlGeometryType)
+		if !ok {
+			return nil, fmt.Errorf(`expected "GeometryTypeIdentifier" from SQL basetype "Geometry"`)
+		}
+		return &geometryType{geometrySQLType}, nil
 	default:
 		return nil, fmt.Errorf(`no type info can be created from SQL base type "%v"`, sqlType.String())
 	}
@@ -228,6 +235,8 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 	switch id {
 	case BitTypeIdentifier:
 		return CreateBitTypeFromParams(params)
+	case BlobStringTypeIdentifier:
+		return CreateBlobStringTypeFromParams(params)
 	case BoolTypeIdentifier:
 		return BoolType, nil
 	case DatetimeTypeIdentifier:
@@ -238,10 +247,14 @@ func FromTypeParams(id Identifier, params map[string]string) (TypeInfo, error) {
 		return CreateEnumTypeFromParams(params)
 	case FloatTypeIdentifier:
 		return CreateFloatTypeFromParams(params)
+	case GeometryTypeIdentifier:
+		return CreateGeometryTypeFromParams(params)
 	case InlineBlobTypeIdentifier:
-		return InlineBlobType, nil
+		return CreateInlineBlobTypeFromParams(params)
 	case IntTypeIdentifier:
 		return CreateIntTypeFromParams(params)
+	case JSONTypeIdentifier:
+		return JSONType, nil
 	case SetTypeIdentifier:
 		return CreateSetTypeFromParams(params)
 	case TimeTypeIdentifier:
@@ -277,7 +290,7 @@ func FromKind(kind types.NomsKind) TypeInfo {
 	case types.NullKind:
 		return UnknownType
 	case types.StringKind:
-		return StringDefaultType
+		return byteWiseStringDefaultType
 	case types.TimestampKind:
 		return DatetimeType
 	case types.TupleKind:
@@ -293,12 +306,12 @@ func FromKind(kind types.NomsKind) TypeInfo {
 
 // Convert takes in a types.Value, as well as the source and destination TypeInfos, and
 // converts the TypeInfo into the applicable types.Value.
-func Convert(v types.Value, srcTi TypeInfo, destTi TypeInfo) (types.Value, error) {
-	str, err := srcTi.FormatValue(v)
+func Convert(ctx context.Context, vrw types.ValueReadWriter, v types.Value, srcTi TypeInfo, destTi TypeInfo) (types.Value, error) {
+	str, err := srcTi.FormatValue(ctx, v)
 	if err != nil {
 		return nil, err
 	}
-	val, err := destTi.ParseValue(str)
+	val, err := destTi.ParseValue(ctx, vrw, str)
 	if err != nil {
 		return nil, err
 	}
@@ -15,15 +15,18 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/geometry"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -69,7 +72,10 @@ func verifyTypeInfoArrays(t *testing.T, tiArrays [][]TypeInfo, vaArrays [][]type
 	// delete any types that should not be tested
 	delete(seenTypeInfos, UnknownTypeIdentifier)
 	delete(seenTypeInfos, TupleTypeIdentifier)
-	//TODO: determine the storage format for VarBinaryType
+	// JSON documents are stored as Tuples and binary and long text values as Blobs, which can't be printed by the
+	// suite, and are tested separately
+	delete(seenTypeInfos, JSONTypeIdentifier)
+	delete(seenTypeInfos, BlobStringTypeIdentifier)
 	delete(seenTypeInfos, VarBinaryTypeIdentifier)
 	for _, tiArray := range tiArrays {
 		// no row should be empty
@@ -111,11 +117,11 @@ func testTypeInfoConvertRoundTrip(t *testing.T, tiArrays [][]TypeInfo, vaArrays
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, val := range vaArrays[rowIndex] {
 						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
-							vInterface, err := ti.ConvertNomsValueToValue(val)
+							vInterface, err := ti.ConvertNomsValueToValue(context.Background(), val)
 							if ti.IsValid(val) {
 								atLeastOneValid = true
 								require.NoError(t, err)
-								outVal, err := ti.ConvertValueToNomsValue(vInterface)
+								outVal, err := ti.ConvertValueToNomsValue(context.Background(), nil, vInterface)
 								require.NoError(t, err)
 								if ti == DateType { // Special case as DateType removes the hh:mm:ss
 									val = types.Timestamp(time.Time(val.(types.Timestamp)).Truncate(24 * time.Hour))
@@ -186,9 +192,9 @@ func testTypeInfoForeignKindHandling(t *testing.T, tiArrays [][]TypeInfo, vaArra
 						for _, val := range vaArray {
 							t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
 								if ti.NomsKind() != val.Kind() {
-									_, err := ti.ConvertNomsValueToValue(val)
+									_, err := ti.ConvertNomsValueToValue(context.Background(), val)
 									assert.Error(t, err)
-									_, err = ti.FormatValue(val)
+									_, err = ti.FormatValue(context.Background(), val)
 									assert.Error(t, err)
 								}
 							})
@@ -209,11 +215,11 @@ func testTypeInfoFormatParseRoundTrip(t *testing.T, tiArrays [][]TypeInfo, vaArr
 				t.Run(ti.String(), func(t *testing.T) {
 					for _, val := range vaArrays[rowIndex] {
 						t.Run(fmt.Sprintf(`types.%v(%v)`, val.Kind().String(), val.HumanReadableString()), func(t *testing.T) {
-							str, err := ti.FormatValue(val)
+							str, err := ti.FormatValue(context.Background(), val)
 							if ti.IsValid(val) {
 								atLeastOneValid = true
 								require.NoError(t, err)
-								outVal, err := ti.ParseValue(str)
+								outVal, err := ti.ParseValue(context.Background(), nil, str)
 								require.NoError(t, err)
 								if ti == DateType { // special case as DateType removes the hh:mm:ss
 									val = types.Timestamp(time.Time(val.(types.Timestamp)).Truncate(24 * time.Hour))
@@ -253,23 +259,23 @@ func testTypeInfoNullHandling(t *testing.T, tiArrays [][]TypeInfo) {
 			for _, ti := range tiArray {
 				t.Run(ti.String(), func(t *testing.T) {
 					t.Run("ConvertNomsValueToValue", func(t *testing.T) {
-						val, err := ti.ConvertNomsValueToValue(types.NullValue)
+						val, err := ti.ConvertNomsValueToValue(context.Background(), types.NullValue)
 						require.NoError(t, err)
 						require.Nil(t, val)
-						val, err = ti.ConvertNomsValueToValue(nil)
+						val, err = ti.ConvertNomsValueToValue(context.Background(), nil)
 						require.NoError(t, err)
 						require.Nil(t, val)
 					})
 					t.Run("ConvertValueToNomsValue", func(t *testing.T) {
-						tVal, err := ti.ConvertValueToNomsValue(nil)
+						tVal, err := ti.ConvertValueToNomsValue(context.Background(), nil, nil)
 						require.NoError(t, err)
 						require.Equal(t, types.NullValue, tVal)
 					})
 					t.Run("FormatValue", func(t *testing.T) {
-						tVal, err := ti.FormatValue(types.NullValue)
+						tVal, err := ti.FormatValue(context.Background(), types.NullValue)
 						require.NoError(t, err)
 						require.Nil(t, tVal)
-						tVal, err = ti.FormatValue(nil)
+						tVal, err = ti.FormatValue(context.Background(), nil)
 						require.NoError(t, err)
 						require.Nil(t, tVal)
 					})
@@ -278,7 +284,7 @@ func testTypeInfoNullHandling(t *testing.T, tiArrays [][]TypeInfo) {
 						require.True(t, ti.IsValid(nil))
 					})
 					t.Run("ParseValue", func(t *testing.T) {
-						tVal, err := ti.ParseValue(nil)
+						tVal, err := ti.ParseValue(context.Background(), nil, nil)
 						require.NoError(t, err)
 						require.Equal(t, types.NullValue, tVal)
 					})
@@ -329,7 +335,9 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 			generateDecimalTypes(t, 16),
 			generateEnumTypes(t, 16),
 			{Float32Type, Float64Type},
-			{InlineBlobType},
+			{GeometryType, PointType, LineStringType, PolygonType},
+			{InlineBlobType, &inlineBlobType{sql.MustCreateBinary(sqltypes.Binary, 2)},
+				&inlineBlobType{sql.MustCreateBinary(sqltypes.VarBinary, 3)}, &inlineBlobType{sql.MustCreateBinary(sqltypes.VarBinary, 255)}},
 			{Int8Type, Int16Type, Int24Type, Int32Type, Int64Type},
 			generateSetTypes(t, 16),
 			{TimeType},
@@ -339,8 +347,8 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 			//	&varBinaryType{sql.TinyBlob}, &varBinaryType{sql.Blob},
 			//	&varBinaryType{sql.MediumBlob}, &varBinaryType{sql.LongBlob}),
 			append(generateVarStringTypes(t, 12),
-				&varStringType{sql.CreateTinyText(sql.Collation_Default)}, &varStringType{sql.CreateText(sql.Collation_Default)},
-				&varStringType{sql.CreateMediumText(sql.Collation_Default)}, &varStringType{sql.CreateLongText(sql.Collation_Default)}),
+				&varStringType{sql.CreateTinyText(sql.Collation_Default), true}, &varStringType{sql.CreateText(sql.Collation_Default), true},
+				&varStringType{sql.CreateMediumText(sql.Collation_Default), true}, &varStringType{sql.CreateLongText(sql.Collation_Default), true}),
 			{YearType},
 		},
 		[][]types.Value{
@@ -356,8 +364,12 @@ func generateTypeInfoArrays(t *testing.T) ([][]TypeInfo, [][]types.Value) {
 				types.Decimal(decimal.RequireFromString("4723245")),
 				types.Decimal(decimal.RequireFromString("-1076416.875")),
 				types.Decimal(decimal.RequireFromString("198728394234798423466321.27349757"))},
-			{types.Uint(1), types.Uint(3), types.Uint(5), types.Uint(7), types.Uint(8)},                                                                                                    //Enum
-			{types.Float(1.0), types.Float(65513.75), types.Float(4293902592), types.Float(4.58e71), types.Float(7.172e285)},                                                               //Float
+			{types.Uint(1), types.Uint(3), types.Uint(5), types.Uint(7), types.Uint(8)},                                      //Enum
+			{types.Float(1.0), types.Float(65513.75), types.Float(4293902592), types.Float(4.58e71), types.Float(7.172e285)}, //Float
+			{types.InlineBlob(geometry.Serialize(geometry.Point{X: 1, Y: 2})), //Geometry
+				types.InlineBlob(geometry.Serialize(geometry.Point{SRID: 4326, X: -71.06, Y: 42.35})),
+				types.InlineBlob(geometry.Serialize(geometry.LineString{Points: []geometry.Point{{X: 0, Y: 0}, {X: 1.5, Y: 2.25}}})),
+				types.InlineBlob(geometry.Serialize(geometry.Polygon{Rings: []geometry.LineString{{Points: []geometry.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 0}}}}}))},
 			{types.InlineBlob{0}, types.InlineBlob{21}, types.InlineBlob{1, 17}, types.InlineBlob{72, 42}, types.InlineBlob{21, 122, 236}},                                                 //InlineBlob
 			{types.Int(20), types.Int(215), types.Int(237493), types.Int(2035753568), types.Int(2384384576063)},                                                                            //Int
 			{types.Uint(1), types.Uint(5), types.Uint(64), types.Uint(42), types.Uint(192)},                                                                                                //Set
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -29,12 +30,12 @@ var _ TypeInfo = (*unknownImpl)(nil)
 var UnknownType TypeInfo = &unknownImpl{}
 
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *unknownImpl) ConvertNomsValueToValue(types.Value) (interface{}, error) {
+func (ti *unknownImpl) ConvertNomsValueToValue(context.Context, types.Value) (interface{}, error) {
 	return nil, fmt.Errorf(`"Unknown" cannot convert any Noms value to a go value`)
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *unknownImpl) ConvertValueToNomsValue(interface{}) (types.Value, error) {
+func (ti *unknownImpl) ConvertValueToNomsValue(context.Context, types.ValueReadWriter, interface{}) (types.Value, error) {
 	return nil, fmt.Errorf(`"Unknown" cannot convert any go value to a Noms value`)
 }
 
@@ -44,7 +45,7 @@ func (ti *unknownImpl) Equals(TypeInfo) bool {
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *unknownImpl) FormatValue(types.Value) (*string, error) {
+func (ti *unknownImpl) FormatValue(context.Context, types.Value) (*string, error) {
 	return nil, fmt.Errorf(`"Unknown" cannot convert any Noms value to a string`)
 }
 
@@ -69,7 +70,7 @@ func (ti *unknownImpl) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *unknownImpl) ParseValue(*string) (types.Value, error) {
+func (ti *unknownImpl) ParseValue(context.Context, types.ValueReadWriter, *string) (types.Value, error) {
 	return nil, fmt.Errorf(`"Unknown" cannot convert any strings to a Noms value`)
 }
 
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -33,7 +34,7 @@ var _ TypeInfo = (*uuidType)(nil)
 var UuidType = &uuidType{sql.MustCreateString(sqltypes.Char, 36, sql.Collation_ascii_bin)}
 
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *uuidType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+func (ti *uuidType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
 	if val, ok := v.(types.UUID); ok {
 		return val.String(), nil
 	}
@@ -44,7 +45,7 @@ func (ti *uuidType) ConvertNomsValueToValue(v types.Value) (interface{}, error)
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *uuidType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *uuidType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	switch val := v.(type) {
 	case nil:
 		return types.NullValue, nil
@@ -71,7 +72,7 @@ func (ti *uuidType) Equals(other TypeInfo) bool {
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *uuidType) FormatValue(v types.Value) (*string, error) {
+func (ti *uuidType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
 	if val, ok := v.(types.UUID); ok {
 		res := val.String()
 		return &res, nil
@@ -109,7 +110,7 @@ func (ti *uuidType) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *uuidType) ParseValue(str *string) (types.Value, error) {
+func (ti *uuidType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	if str == nil || *str == "" {
 		return types.NullValue, nil
 	}
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -35,7 +36,8 @@ const (
 // As a type, this is modeled more after MySQL's story for binary data. There, it's treated
 // as a string that is interpreted as raw bytes, rather than as a bespoke data structure,
 // and thus this is mirrored here in its implementation. This will minimize any differences
-// that could arise.
+// that could arise. BLOB columns use this type, whose bytes are stored out-of-line as a Blob,
+// so that large values are chunked and deduplicated across versions.
 type varBinaryType struct {
 	sqlBinaryType sql.StringType
 }
@@ -75,9 +77,13 @@ func CreateVarBinaryTypeFromParams(params map[string]string) (TypeInfo, error) {
 }
 
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *varBinaryType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
-	if val, ok := v.(types.String); ok {
-		return string(val), nil
+func (ti *varBinaryType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
+	if val, ok := v.(types.Blob); ok {
+		data, err := readBlob(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
 	}
 	if _, ok := v.(types.Null); ok || v == nil {
 		return nil, nil
@@ -86,7 +92,7 @@ func (ti *varBinaryType) ConvertNomsValueToValue(v types.Value) (interface{}, er
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *varBinaryType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *varBinaryType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	if v == nil {
 		return types.NullValue, nil
 	}
@@ -96,7 +102,7 @@ func (ti *varBinaryType) ConvertValueToNomsValue(v interface{}) (types.Value, er
 	}
 	val, ok := strVal.(string)
 	if ok {
-		return types.String(val), nil
+		return writeBlob(ctx, vrw, []byte(val))
 	}
 	return nil, fmt.Errorf(`"%v" cannot convert value "%v" of type "%T" as it is invalid`, ti.String(), v, v)
 }
@@ -114,16 +120,14 @@ func (ti *varBinaryType) Equals(other TypeInfo) bool {
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *varBinaryType) FormatValue(v types.Value) (*string, error) {
-	if val, ok := v.(types.String); ok {
-		res, err := ti.sqlBinaryType.Convert(string(val))
+func (ti *varBinaryType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
+	if val, ok := v.(types.Blob); ok {
+		data, err := readBlob(ctx, val)
 		if err != nil {
 			return nil, err
 		}
-		if resStr, ok := res.(string); ok {
-			return &resStr, nil
-		}
-		return nil, fmt.Errorf(`"%v" has unexpectedly encountered a value of type "%T" from embedded type`, ti.String(), v)
+		res := string(data)
+		return &res, nil
 	}
 	if _, ok := v.(types.Null); ok || v == nil {
 		return nil, nil
@@ -156,12 +160,8 @@ func (ti *varBinaryType) GetTypeParams() map[string]string {
 
 // IsValid implements TypeInfo interface.
 func (ti *varBinaryType) IsValid(v types.Value) bool {
-	if val, ok := v.(types.String); ok {
-		_, err := ti.sqlBinaryType.Convert(string(val))
-		if err != nil {
-			return false
-		}
-		return true
+	if val, ok := v.(types.Blob); ok {
+		return int64(val.Len()) <= ti.sqlBinaryType.MaxByteLength()
 	}
 	if _, ok := v.(types.Null); ok || v == nil {
 		return true
@@ -171,22 +171,15 @@ func (ti *varBinaryType) IsValid(v types.Value) bool {
 
 // NomsKind implements TypeInfo interface.
 func (ti *varBinaryType) NomsKind() types.NomsKind {
-	return types.StringKind
+	return types.BlobKind
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *varBinaryType) ParseValue(str *string) (types.Value, error) {
+func (ti *varBinaryType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	if str == nil {
 		return types.NullValue, nil
 	}
-	strVal, err := ti.sqlBinaryType.Convert(*str)
-	if err != nil {
-		return nil, err
-	}
-	if val, ok := strVal.(string); ok {
-		return types.String(val), nil
-	}
-	return nil, fmt.Errorf(`"%v" cannot convert the string "%v" to a value`, ti.String(), str)
+	return ti.ConvertValueToNomsValue(ctx, vrw, *str)
 }
 
 // String implements TypeInfo interface.
@@ -0,0 +1,81 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestVarBinaryFromSqlType(t *testing.T) {
+	for _, sqlType := range []sql.Type{
+		sql.TinyBlob, sql.Blob, sql.MediumBlob, sql.LongBlob,
+	} {
+		t.Run(sqlType.String(), func(t *testing.T) {
+			ti, err := FromSqlType(sqlType)
+			require.NoError(t, err)
+			assert.Equal(t, VarBinaryTypeIdentifier, ti.GetTypeIdentifier())
+			assert.Equal(t, types.BlobKind, ti.NomsKind())
+			assert.Equal(t, sqlType, ti.ToSqlType())
+
+			fromParams, err := FromTypeParams(ti.GetTypeIdentifier(), ti.GetTypeParams())
+			require.NoError(t, err)
+			assert.True(t, ti.Equals(fromParams))
+		})
+	}
+}
+
+func TestVarBinaryConvertRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+
+	tests := []struct {
+		typ         *varBinaryType
+		input       string
+		expectedErr bool
+	}{
+		{&varBinaryType{sql.LongBlob}, "", false},
+		{&varBinaryType{sql.LongBlob}, string([]byte{0, 1, 2, 255}), false},
+		{&varBinaryType{sql.Blob}, string([]byte{84, 32, 13, 63, 12, 86}), false},
+		{&varBinaryType{sql.TinyBlob}, string(make([]byte, 256)), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typ.String(), func(t *testing.T) {
+			val, err := test.typ.ConvertValueToNomsValue(ctx, vrw, test.input)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, types.BlobKind, val.Kind())
+			assert.True(t, test.typ.IsValid(val))
+
+			out, err := test.typ.ConvertNomsValueToValue(ctx, val)
+			require.NoError(t, err)
+			assert.Equal(t, test.input, out)
+
+			parsed, err := test.typ.ParseValue(ctx, vrw, &test.input)
+			require.NoError(t, err)
+			assert.True(t, val.Equals(parsed))
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -28,6 +29,7 @@ import (
 
 const (
 	varStringTypeParam_Collate     = "collate"
+	varStringTypeParam_Collated    = "collated"
 	varStringTypeParam_Length      = "length"
 	varStringTypeParam_SQL         = "sql"
 	varStringTypeParam_SQL_Char    = "char"
@@ -37,10 +39,17 @@ const (
 
 type varStringType struct {
 	sqlStringType sql.StringType
+	// collated is false for columns created before collations were supported, whose values are compared byte-wise. It
+	// isn't part of the type, so it's left out of Equals.
+	collated bool
 }
 
 var _ TypeInfo = (*varStringType)(nil)
-var StringDefaultType = &varStringType{sql.CreateLongText(sql.Collation_Default)}
+var StringDefaultType = &varStringType{sql.CreateLongText(sql.Collation_Default), true}
+
+// byteWiseStringDefaultType is the type of string columns that only have a kind, such as those of system tables and of
+// schemas written before type info was stored, whose values are compared byte-wise.
+var byteWiseStringDefaultType = &varStringType{sql.CreateLongText(sql.Collation_Default), false}
 
 func CreateVarStringTypeFromParams(params map[string]string) (TypeInfo, error) {
 	var length int64
@@ -74,14 +83,14 @@ func CreateVarStringTypeFromParams(params map[string]string) (TypeInfo, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &varStringType{sqlType}, nil
+		return &varStringType{sqlType, params[varStringTypeParam_Collated] == "true"}, nil
 	} else {
 		return nil, fmt.Errorf(`create varstring type info is missing param "%v"`, varStringTypeParam_Length)
 	}
 }
 
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *varStringType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+func (ti *varStringType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
 	if val, ok := v.(types.String); ok {
 		res := string(val)
 		// As per the MySQL documentation, trailing spaces are removed when retrieved for CHAR types only.
@@ -99,7 +108,7 @@ func (ti *varStringType) ConvertNomsValueToValue(v types.Value) (interface{}, er
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *varStringType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *varStringType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	if v == nil {
 		return types.NullValue, nil
 	}
@@ -128,9 +137,9 @@ func (ti *varStringType) Equals(other TypeInfo) bool {
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *varStringType) FormatValue(v types.Value) (*string, error) {
+func (ti *varStringType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
 	if val, ok := v.(types.String); ok {
-		res, err := ti.ConvertNomsValueToValue(val)
+		res, err := ti.ConvertNomsValueToValue(ctx, val)
 		if err != nil {
 			return nil, err
 		}
@@ -156,6 +165,9 @@ func (ti *varStringType) GetTypeParams() map[string]string {
 		varStringTypeParam_Collate: ti.sqlStringType.Collation().String(),
 		varStringTypeParam_Length:  strconv.FormatInt(ti.sqlStringType.MaxCharacterLength(), 10),
 	}
+	if ti.collated {
+		typeParams[varStringTypeParam_Collated] = "true"
+	}
 	switch ti.sqlStringType.Type() {
 	case sqltypes.Char:
 		typeParams[varStringTypeParam_SQL] = varStringTypeParam_SQL_Char
@@ -190,11 +202,11 @@ func (ti *varStringType) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *varStringType) ParseValue(str *string) (types.Value, error) {
+func (ti *varStringType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	if str == nil {
 		return types.NullValue, nil
 	}
-	return ti.ConvertValueToNomsValue(*str)
+	return ti.ConvertValueToNomsValue(ctx, vrw, *str)
 }
 
 // String implements TypeInfo interface.
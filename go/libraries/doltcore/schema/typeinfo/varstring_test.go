@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -52,7 +53,7 @@ func TestVarStringConvertNomsValueToValue(t *testing.T) {
 			false,
 		},
 		{
-			&varStringType{sql.CreateLongText(sql.Collation_Default)},
+			&varStringType{sql.CreateLongText(sql.Collation_Default), true},
 			"  This is a sentence.  ",
 			"  This is a sentence.  ",
 			false,
@@ -61,7 +62,7 @@ func TestVarStringConvertNomsValueToValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), test.input), func(t *testing.T) {
-			output, err := test.typ.ConvertNomsValueToValue(test.input)
+			output, err := test.typ.ConvertNomsValueToValue(context.Background(), test.input)
 			if test.expectedErr {
 				assert.Error(t, err)
 			} else {
@@ -98,7 +99,7 @@ func TestVarStringConvertValueToNomsValue(t *testing.T) {
 			false,
 		},
 		{
-			&varStringType{sql.CreateLongText(sql.Collation_Default)},
+			&varStringType{sql.CreateLongText(sql.Collation_Default), true},
 			float32(3724.75),
 			"3724.75",
 			false,
@@ -125,7 +126,7 @@ func TestVarStringConvertValueToNomsValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), test.input), func(t *testing.T) {
-			output, err := test.typ.ConvertValueToNomsValue(test.input)
+			output, err := test.typ.ConvertValueToNomsValue(context.Background(), nil, test.input)
 			if !test.expectedErr {
 				require.NoError(t, err)
 				assert.Equal(t, test.output, output)
@@ -162,7 +163,7 @@ func TestVarStringFormatValue(t *testing.T) {
 			false,
 		},
 		{
-			&varStringType{sql.CreateLongText(sql.Collation_Default)},
+			&varStringType{sql.CreateLongText(sql.Collation_Default), true},
 			"  This is a sentence.  ",
 			"  This is a sentence.  ",
 			false,
@@ -171,7 +172,7 @@ func TestVarStringFormatValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), test.input), func(t *testing.T) {
-			output, err := test.typ.FormatValue(test.input)
+			output, err := test.typ.FormatValue(context.Background(), test.input)
 			if test.expectedErr {
 				assert.Error(t, err)
 			} else {
@@ -208,7 +209,7 @@ func TestVarStringParseValue(t *testing.T) {
 			false,
 		},
 		{
-			&varStringType{sql.CreateLongText(sql.Collation_Default)},
+			&varStringType{sql.CreateLongText(sql.Collation_Default), true},
 			"  This is a sentence.  ",
 			"  This is a sentence.  ",
 			false,
@@ -229,7 +230,7 @@ func TestVarStringParseValue(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf(`%v %v`, test.typ.String(), test.input), func(t *testing.T) {
-			output, err := test.typ.ParseValue(&test.input)
+			output, err := test.typ.ParseValue(context.Background(), nil, &test.input)
 			if !test.expectedErr {
 				require.NoError(t, err)
 				assert.Equal(t, test.output, output)
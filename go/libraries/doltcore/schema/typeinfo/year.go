@@ -15,6 +15,7 @@
 package typeinfo
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -34,7 +35,7 @@ var _ TypeInfo = (*yearType)(nil)
 var YearType = &yearType{sql.Year}
 
 // ConvertNomsValueToValue implements TypeInfo interface.
-func (ti *yearType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+func (ti *yearType) ConvertNomsValueToValue(ctx context.Context, v types.Value) (interface{}, error) {
 	if val, ok := v.(types.Int); ok {
 		return int16(val), nil
 	}
@@ -45,7 +46,7 @@ func (ti *yearType) ConvertNomsValueToValue(v types.Value) (interface{}, error)
 }
 
 // ConvertValueToNomsValue implements TypeInfo interface.
-func (ti *yearType) ConvertValueToNomsValue(v interface{}) (types.Value, error) {
+func (ti *yearType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
 	if v == nil {
 		return types.NullValue, nil
 	}
@@ -70,9 +71,9 @@ func (ti *yearType) Equals(other TypeInfo) bool {
 }
 
 // FormatValue implements TypeInfo interface.
-func (ti *yearType) FormatValue(v types.Value) (*string, error) {
+func (ti *yearType) FormatValue(ctx context.Context, v types.Value) (*string, error) {
 	if val, ok := v.(types.Int); ok {
-		convVal, err := ti.ConvertNomsValueToValue(val)
+		convVal, err := ti.ConvertNomsValueToValue(ctx, val)
 		if err != nil {
 			return nil, err
 		}
@@ -120,7 +121,7 @@ func (ti *yearType) NomsKind() types.NomsKind {
 }
 
 // ParseValue implements TypeInfo interface.
-func (ti *yearType) ParseValue(str *string) (types.Value, error) {
+func (ti *yearType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
 	if str == nil || *str == "" {
 		return types.NullValue, nil
 	}
@@ -0,0 +1,55 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+func TestAsOfViewsAndSystemTables(t *testing.T) {
+	ts := newTestSession(t)
+	ts.query("CREATE TABLE a (pk INT PRIMARY KEY, c1 INT)")
+	ts.query("INSERT INTO a VALUES (1, 1)")
+	ts.query("CREATE VIEW v AS SELECT c1 * 10 AS c FROM a")
+	first := ts.commit("add a and v")
+	require.NoError(t, ts.dEnv.DoltDB.NewTagAtCommit(context.Background(), ref.NewTagRef("v1"), first, doltdb.NewTagMeta("Bill Billerson", "bill@example.com", "v1")))
+
+	ts.query("INSERT INTO a VALUES (2, 2)")
+	ts.commit("add row")
+	ts.query("DROP VIEW v")
+	ts.query("INSERT INTO a VALUES (3, 3)")
+	ts.commit("drop v")
+
+	assert.Equal(t, []sql.Row{{int64(1)}}, ts.query("SELECT count(*) FROM a AS OF 'HEAD~2'"))
+	assert.Equal(t, []sql.Row{{int64(1)}}, ts.query("SELECT count(*) FROM a AS OF 'v1'"))
+
+	// views that only exist in an earlier commit use their definition from that commit
+	assert.Equal(t, []sql.Row{{int64(10)}}, ts.query("SELECT c FROM v AS OF 'v1'"))
+	assert.Equal(t, []sql.Row{{int64(10)}, {int64(20)}}, ts.query("SELECT v.c FROM v AS OF 'HEAD~' ORDER BY c"))
+
+	// system tables are generated as of the commit
+	assert.Equal(t, []sql.Row{{int64(2)}}, ts.query("SELECT count(*) FROM dolt_log AS OF 'HEAD~2'"))
+	assert.Equal(t, []sql.Row{{int64(3)}}, ts.query("SELECT count(*) FROM dolt_history_a AS OF 'HEAD^'"))
+	assert.Equal(t, []sql.Row{{int64(2)}}, ts.query("SELECT count(*) FROM dolt_diff_a AS OF 'HEAD~'"))
+	assert.Equal(t, []sql.Row{{"v"}}, ts.query("SELECT name FROM dolt_schemas AS OF 'HEAD~'"))
+	assert.Empty(t, ts.query("SELECT name FROM dolt_schemas"))
+}
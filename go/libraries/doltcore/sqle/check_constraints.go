@@ -0,0 +1,291 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
+)
+
+// checkDef is a CHECK clause of a statement. An empty name means the name is generated.
+type checkDef struct {
+	name       string
+	expression string
+	enforced   bool
+}
+
+// parseCheckClause parses the CHECK clause whose CHECK keyword is at toks[i]. It returns the clause and the indexes of
+// its first and last tokens, including any CONSTRAINT prefix and ENFORCED suffix.
+func parseCheckClause(query string, toks []ddlToken, i int) (def checkDef, first, last int, err error) {
+	first = i
+	if i >= 1 && toks[i-1].typ == sqlparser.CONSTRAINT {
+		first = i - 1
+	} else if i >= 2 && toks[i-2].typ == sqlparser.CONSTRAINT {
+		def.name = toks[i-1].val
+		first = i - 2
+	}
+
+	if i+1 >= len(toks) || toks[i+1].typ != '(' {
+		return checkDef{}, 0, 0, fmt.Errorf("expected '(' after CHECK")
+	}
+	last = matchParen(toks, i+1)
+	if last < 0 {
+		return checkDef{}, 0, 0, fmt.Errorf("unbalanced parentheses in CHECK clause")
+	}
+	def.expression = ddlText(query, toks, i+2, last-1)
+	def.enforced = true
+
+	if isEnforcedToken(toks, last+1) {
+		last++
+	} else if last+2 < len(toks) && toks[last+1].typ == sqlparser.NOT && isEnforcedToken(toks, last+2) {
+		def.enforced = false
+		last += 2
+	}
+
+	return def, first, last, nil
+}
+
+func isEnforcedToken(toks []ddlToken, i int) bool {
+	return i < len(toks) && toks[i].typ == sqlparser.ID && !toks[i].quoted && strings.ToLower(toks[i].val) == "enforced"
+}
+
+// parseAlterTableChecks parses the ALTER TABLE statement given if it adds, alters or drops a check constraint of the
+// named table. The table's alteration starts at toks[i].
+func parseAlterTableChecks(query string, toks []ddlToken, dbName, tblName string, i int) (extendedDDLFunc, bool, error) {
+	spec := toks[i:]
+
+	switch {
+	case spec[0].typ == sqlparser.ADD:
+		for j := i + 1; j < len(toks) && j <= i+3; j++ {
+			if toks[j].typ != sqlparser.CHECK {
+				continue
+			}
+			def, first, last, err := parseCheckClause(query, toks, j)
+			if err != nil {
+				return nil, true, err
+			}
+			if first != i+1 || last != len(toks)-1 {
+				return nil, true, fmt.Errorf("unexpected tokens after CHECK constraint")
+			}
+			return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+				db, err := ddlDatabase(ctx, e, dbName)
+				if err != nil {
+					return true, err
+				}
+				return true, addChecks(ctx, db, tblName, []checkDef{def})
+			}, true, nil
+		}
+	case spec[0].typ == sqlparser.DROP && len(spec) == 3 && spec[1].typ == sqlparser.CHECK:
+		return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+			db, err := ddlDatabase(ctx, e, dbName)
+			if err != nil {
+				return true, err
+			}
+			return true, dropCheck(ctx, db, tblName, spec[2].val)
+		}, true, nil
+	case spec[0].typ == sqlparser.DROP && len(spec) == 3 && spec[1].typ == sqlparser.CONSTRAINT:
+		return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+			// other kinds of constraints are dropped by the engine
+			db, err := ddlDatabase(ctx, e, dbName)
+			if err != nil {
+				return false, nil
+			}
+			if has, err := hasCheck(ctx, db, tblName, spec[2].val); err != nil || !has {
+				return false, nil
+			}
+			return true, dropCheck(ctx, db, tblName, spec[2].val)
+		}, true, nil
+	case spec[0].typ == sqlparser.ALTER && len(spec) >= 4 && (spec[1].typ == sqlparser.CHECK || spec[1].typ == sqlparser.CONSTRAINT):
+		enforced := true
+		if len(spec) == 5 && spec[3].typ == sqlparser.NOT && isEnforcedToken(spec, 4) {
+			enforced = false
+		} else if len(spec) != 4 || !isEnforcedToken(spec, 3) {
+			return nil, false, nil
+		}
+		return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+			db, err := ddlDatabase(ctx, e, dbName)
+			if err != nil {
+				return true, err
+			}
+			return true, alterCheckEnforcement(ctx, db, tblName, spec[2].val, enforced)
+		}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// updateCheckTable applies the function given to the schema of the named table, and writes the table back with the
+// updated schema. The function returns the check constraint that must be verified against the rows of the table, if
+// any.
+func updateCheckTable(ctx *sql.Context, db Database, tblName string, update func(tbl *doltdb.Table, sch schema.Schema) ([]schema.Check, error)) error {
+	if doltdb.HasDoltPrefix(tblName) {
+		return ErrSystemTableAlter.New(tblName)
+	}
+
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return err
+	}
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrTableNotFound.New(tblName)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	toVerify, err := update(tbl, sch)
+	if err != nil {
+		return err
+	}
+
+	tbl, err = tbl.UpdateSchema(ctx, sch)
+	if err != nil {
+		return err
+	}
+
+	if len(toVerify) > 0 {
+		violations, err := merge.CheckViolations(ctx, tbl)
+		if err != nil {
+			return err
+		}
+		for _, violation := range violations {
+			for _, check := range toVerify {
+				if violation.Check.Name() == check.Name() {
+					return expreval.ErrCheckConstraintViolated.New(check.Name())
+				}
+			}
+		}
+	}
+
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return err
+	}
+	return db.SetRoot(ctx, root)
+}
+
+// addChecks adds the check constraints given to the named table. Enforced check constraints must be satisfied by the
+// existing rows of the table.
+func addChecks(ctx *sql.Context, db Database, tblName string, defs []checkDef) error {
+	return updateCheckTable(ctx, db, tblName, func(tbl *doltdb.Table, sch schema.Schema) ([]schema.Check, error) {
+		var added []schema.Check
+		for _, def := range defs {
+			name := def.name
+			if name == "" {
+				name = generateCheckName(sch, tblName)
+			}
+			if _, err := expreval.ResolveExpression(sch, def.expression); err != nil {
+				return nil, expreval.ErrInvalidCheckExpression.New(name, err.Error())
+			}
+			check, err := sch.Checks().AddCheck(name, def.expression, def.enforced)
+			if err != nil {
+				return nil, err
+			}
+			if check.Enforced() {
+				added = append(added, check)
+			}
+		}
+		return added, nil
+	})
+}
+
+// generateCheckName returns the name MySQL would give to an unnamed check constraint on the table given.
+func generateCheckName(sch schema.Schema, tblName string) string {
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_chk_%d", tblName, i)
+		if !sch.Checks().Contains(name) {
+			return name
+		}
+	}
+}
+
+func hasCheck(ctx *sql.Context, db Database, tblName, checkName string) (bool, error) {
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return false, err
+	}
+	tbl, _, ok, err := root.GetTableInsensitive(ctx, tblName)
+	if err != nil || !ok {
+		return false, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+	return sch.Checks().Contains(checkName), nil
+}
+
+func dropCheck(ctx *sql.Context, db Database, tblName, checkName string) error {
+	return updateCheckTable(ctx, db, tblName, func(tbl *doltdb.Table, sch schema.Schema) ([]schema.Check, error) {
+		_, err := sch.Checks().RemoveCheck(checkName)
+		return nil, err
+	})
+}
+
+func alterCheckEnforcement(ctx *sql.Context, db Database, tblName, checkName string, enforced bool) error {
+	return updateCheckTable(ctx, db, tblName, func(tbl *doltdb.Table, sch schema.Schema) ([]schema.Check, error) {
+		check, ok := sch.Checks().GetByNameCaseInsensitive(checkName)
+		if !ok {
+			return nil, fmt.Errorf("`%s` does not exist as a check constraint for this table", checkName)
+		}
+		check = schema.NewCheck(check.Name(), check.Expression(), enforced)
+		sch.Checks().AddChecks(check)
+		if enforced {
+			return []schema.Check{check}, nil
+		}
+		return nil, nil
+	})
+}
+
+// checkColumnNotReferenced returns an error if the named column is used by a check constraint or a generated column of
+// the schema given, and so can't be dropped or renamed.
+func checkColumnNotReferenced(sch schema.Schema, colName string) error {
+	for _, check := range sch.Checks().AllChecks() {
+		refs, err := expreval.CheckReferencesColumn(check, colName)
+		if err != nil {
+			return err
+		}
+		if refs {
+			return fmt.Errorf("Check constraint '%s' uses column '%s', hence column cannot be dropped or renamed.", check.Name(), colName)
+		}
+	}
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if !col.IsGenerated() || strings.EqualFold(col.Name, colName) {
+			continue
+		}
+		refs, err := expreval.ExpressionReferencesColumn(col.Generated, colName)
+		if err != nil {
+			return err
+		}
+		if refs {
+			return fmt.Errorf("Column '%s' has a generated column dependency.", colName)
+		}
+	}
+	return nil
+}
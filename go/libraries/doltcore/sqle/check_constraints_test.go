@@ -0,0 +1,59 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
+)
+
+func TestCheckConstraints(t *testing.T) {
+	ts := newTestSession(t)
+	checkNames := func() []string {
+		var names []string
+		for _, check := range ts.tableSchema("t").Checks().AllChecks() {
+			names = append(names, check.Name())
+		}
+		return names
+	}
+
+	require.NoError(t, ts.exec("CREATE TABLE t (pk INT PRIMARY KEY, a INT CHECK (a > 0), b INT, CONSTRAINT b_lt_a CHECK (b < a))"))
+	assert.Equal(t, []string{"t_chk_1", "b_lt_a"}, checkNames())
+
+	require.NoError(t, ts.exec("INSERT INTO t VALUES (1, 5, 1), (2, 3, NULL)"))
+	err := ts.exec("INSERT INTO t VALUES (3, -1, -2)")
+	assert.True(t, expreval.ErrCheckConstraintViolated.Is(err), "%v", err)
+	err = ts.exec("UPDATE t SET b = 10 WHERE pk = 1")
+	assert.True(t, expreval.ErrCheckConstraintViolated.Is(err), "%v", err)
+	assert.Equal(t, []sql.Row{{int32(1), int32(5), int32(1)}, {int32(2), int32(3), nil}}, ts.query("SELECT * FROM t ORDER BY pk"))
+
+	// existing rows must satisfy new check constraints
+	assert.Error(t, ts.exec("ALTER TABLE t ADD CONSTRAINT a_big CHECK (a > 4)"))
+	require.NoError(t, ts.exec("ALTER TABLE t ADD CONSTRAINT a_big CHECK (a > 4) NOT ENFORCED"))
+	assert.Error(t, ts.exec("ALTER TABLE t ALTER CHECK a_big ENFORCED"))
+	require.NoError(t, ts.exec("ALTER TABLE t DROP CHECK a_big"))
+	assert.Equal(t, []string{"t_chk_1", "b_lt_a"}, checkNames())
+
+	// columns used by check constraints can't be dropped
+	assert.Error(t, ts.exec("ALTER TABLE t DROP COLUMN b"))
+	require.NoError(t, ts.exec("ALTER TABLE t DROP CONSTRAINT b_lt_a"))
+	require.NoError(t, ts.exec("ALTER TABLE t DROP COLUMN b"))
+	assert.Equal(t, []string{"t_chk_1"}, checkNames())
+}
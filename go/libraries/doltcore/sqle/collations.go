@@ -0,0 +1,225 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// NewAnalyzerBuilder returns a builder of analyzers for the catalog given, with the rules that dolt tables rely on.
+func NewAnalyzerBuilder(c *sql.Catalog) *analyzer.Builder {
+	return analyzer.NewBuilder(c).AddPostValidationRule("apply_collations", applyCollations)
+}
+
+// NewDefaultEngine returns an engine with a new catalog, whose analyzer has the rules that dolt tables rely on.
+func NewDefaultEngine() *sqle.Engine {
+	c := sql.NewCatalog()
+	return sqle.New(c, NewAnalyzerBuilder(c).Build(), nil)
+}
+
+// applyCollations makes comparisons and sorts of collated string columns use the collations of the columns. The
+// engine compares strings byte-wise.
+func applyCollations(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *analyzer.Scope) (sql.Node, error) {
+	schemas := make(map[string]schema.Schema)
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.TableAlias:
+			if sch, ok := doltSchemaOf(n.Child); ok {
+				schemas[strings.ToLower(n.Name())] = sch
+			}
+		default:
+			if sch, ok := doltSchemaOf(n); ok {
+				schemas[strings.ToLower(n.(sql.Nameable).Name())] = sch
+			}
+		}
+		return true
+	})
+	if len(schemas) == 0 {
+		return n, nil
+	}
+
+	collationOf := func(e sql.Expression) (sql.Collation, bool) {
+		gf, ok := e.(*expression.GetField)
+		if !ok {
+			return "", false
+		}
+		sch, ok := schemas[strings.ToLower(gf.Table())]
+		if !ok {
+			return "", false
+		}
+		col, ok := sch.GetAllCols().GetByNameCaseInsensitive(gf.Name())
+		if !ok || !schema.UsesCollationKeys(col) {
+			return "", false
+		}
+		collation, _ := typeinfo.CollationOf(col.TypeInfo)
+		return collation, true
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		if s, ok := n.(*plan.Sort); ok {
+			fields := make([]plan.SortField, len(s.SortFields))
+			changed := false
+			for i, sf := range s.SortFields {
+				fields[i] = sf
+				if collation, ok := collationOf(sf.Column); ok {
+					fields[i].Column = newCollatedExpression(sf.Column, collation)
+					changed = true
+				}
+			}
+			if changed {
+				n = plan.NewSort(fields, s.Child)
+			}
+		}
+
+		return plan.TransformExpressions(n, func(e sql.Expression) (sql.Expression, error) {
+			switch e := e.(type) {
+			case *expression.Equals, *expression.LessThan, *expression.LessThanOrEqual, *expression.GreaterThan, *expression.GreaterThanOrEqual:
+				children := e.Children()
+				collation, ok := collationOf(children[0])
+				if !ok {
+					if collation, ok = collationOf(children[1]); !ok {
+						return e, nil
+					}
+				}
+				if !isTextOrCollated(children[0]) || !isTextOrCollated(children[1]) {
+					return e, nil
+				}
+				return e.WithChildren(newCollatedExpression(children[0], collation), newCollatedExpression(children[1], collation))
+			case *expression.InTuple:
+				collation, ok := collationOf(e.Left())
+				if !ok {
+					return e, nil
+				}
+				tuple, ok := e.Right().(expression.Tuple)
+				if !ok {
+					return e, nil
+				}
+				for _, el := range tuple {
+					if !isTextOrCollated(el) {
+						return e, nil
+					}
+				}
+				return e.WithChildren(newCollatedExpression(e.Left(), collation), e.Right())
+			default:
+				return e, nil
+			}
+		})
+	})
+}
+
+// doltSchemaOf returns the dolt schema of the table resolved by the node given, if it's a dolt table.
+func doltSchemaOf(n sql.Node) (schema.Schema, bool) {
+	var tbl sql.Table
+	switch n := n.(type) {
+	case *plan.ResolvedTable:
+		tbl = n.Table
+	case *plan.IndexedTableAccess:
+		tbl = n.ResolvedTable.Table
+	default:
+		return nil, false
+	}
+	dt, ok := tbl.(doltSchemaTable)
+	if !ok {
+		return nil, false
+	}
+	return dt.doltSchema(), true
+}
+
+func isTextOrCollated(e sql.Expression) bool {
+	_, ok := e.Type().(collatedStringType)
+	return ok || sql.IsText(e.Type())
+}
+
+// collatedStringType is a string type whose values are compared using its collation.
+type collatedStringType struct {
+	sql.StringType
+}
+
+// Compare implements sql.Type.
+func (t collatedStringType) Compare(a interface{}, b interface{}) (int, error) {
+	if a == nil || b == nil {
+		return t.StringType.Compare(a, b)
+	}
+	as, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+	return typeinfo.CompareCollated(t.Collation(), as.(string), bs.(string)), nil
+}
+
+// Promote implements sql.Type.
+func (t collatedStringType) Promote() sql.Type {
+	return t
+}
+
+// collatedExpression is an expression whose values are compared using a collation. The values of its child are
+// converted to strings.
+type collatedExpression struct {
+	expression.UnaryExpression
+	typ collatedStringType
+}
+
+var _ sql.Expression = (*collatedExpression)(nil)
+
+func newCollatedExpression(e sql.Expression, collation sql.Collation) sql.Expression {
+	if ce, ok := e.(*collatedExpression); ok {
+		e = ce.Child
+	}
+	return &collatedExpression{
+		UnaryExpression: expression.UnaryExpression{Child: e},
+		typ:             collatedStringType{sql.CreateLongText(collation)},
+	}
+}
+
+// Eval implements sql.Expression.
+func (ce *collatedExpression) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := ce.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	return ce.typ.Convert(val)
+}
+
+// Type implements sql.Expression.
+func (ce *collatedExpression) Type() sql.Type {
+	return ce.typ
+}
+
+// String implements sql.Expression.
+func (ce *collatedExpression) String() string {
+	return fmt.Sprintf("%s COLLATE %s", ce.Child, ce.typ.Collation())
+}
+
+// WithChildren implements sql.Expression.
+func (ce *collatedExpression) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(ce, len(children), 1)
+	}
+	return newCollatedExpression(children[0], ce.typ.Collation()), nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+// CommitHook is run after a branch of a Database is created or moved through SQL, e.g. by writing the hash returned
+// by COMMIT() to the dolt_branches table. Commits which no branch points to are not seen by hooks. Hooks are run
+// synchronously as part of the statement updating the branch, so long running work should be done asynchronously.
+type CommitHook interface {
+	// Execute is called with the name of the database, the branch updated, and the commit it now points to.
+	Execute(ctx context.Context, dbName string, branch ref.BranchRef, cm *doltdb.Commit) error
+}
+
+// ReplicationStatusReporter is implemented by commit hooks which replicate commits elsewhere, and whose progress is
+// shown in the dolt_replication_status system table.
+type ReplicationStatusReporter interface {
+	// ReplicationStatus returns the status of replication of the database named.
+	ReplicationStatus(dbName string) []dtables.ReplicationStatus
+}
+
+func (db Database) replicationStatus() []dtables.ReplicationStatus {
+	var statuses []dtables.ReplicationStatus
+	for _, hook := range db.hooks {
+		if reporter, ok := hook.(ReplicationStatusReporter); ok {
+			statuses = append(statuses, reporter.ReplicationStatus(db.name)...)
+		}
+	}
+
+	return statuses
+}
+
+// executeCommitHooks runs the hooks of this Database for the branch given, which has been moved to the commit given.
+func (db Database) executeCommitHooks(ctx *sql.Context, branch ref.BranchRef, cm *doltdb.Commit) error {
+	return DSessFromSess(ctx.Session).ExecuteCommitHooks(ctx, db.name, branch, cm)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+type testCommitHook struct {
+	branches []string
+	commits  []string
+}
+
+func (h *testCommitHook) Execute(_ context.Context, _ string, branch ref.BranchRef, cm *doltdb.Commit) error {
+	hash, err := cm.HashOf()
+	if err != nil {
+		return err
+	}
+
+	h.branches = append(h.branches, branch.GetPath())
+	h.commits = append(h.commits, hash.String())
+	return nil
+}
+
+func (h *testCommitHook) ReplicationStatus(_ string) []dtables.ReplicationStatus {
+	return []dtables.ReplicationStatus{
+		{Remote: "origin", Branch: "master", LastPushedCommit: "abc", LastPushedAt: time.Unix(0, 0).UTC(), Lag: 2, State: dtables.ReplicationPending},
+		{Remote: "backup", Branch: "master", Lag: 3, LastError: "unreachable", State: dtables.ReplicationFailed},
+	}
+}
+
+func TestCommitHooks(t *testing.T) {
+	ctx := context.Background()
+	hook := &testCommitHook{}
+	ts := newTestSession(t, hook)
+	ddb := ts.dEnv.DoltDB
+
+	// a commit isn't seen by hooks until a branch points to it, as with those made by COMMIT()
+	root, err := ts.dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	parent, err := ddb.ResolveRef(ctx, ref.NewBranchRef("master"))
+	require.NoError(t, err)
+	rootHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := doltdb.NewCommitMeta("Bill Billerson", "bill@example.com", "empty commit")
+	require.NoError(t, err)
+	cm, err := ddb.WriteDanglingCommit(ctx, rootHash, []*doltdb.Commit{parent}, meta)
+	require.NoError(t, err)
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+	cmHash := h.String()
+	assert.Empty(t, hook.branches)
+
+	ts.query(fmt.Sprintf("INSERT INTO dolt_branches (name, hash) VALUES ('feature', '%s')", cmHash))
+	ts.query(fmt.Sprintf("UPDATE dolt_branches SET hash = '%s' WHERE name = 'master'", cmHash))
+	assert.Equal(t, []string{"feature", "master"}, hook.branches)
+	assert.Equal(t, []string{cmHash, cmHash}, hook.commits)
+
+	rows := ts.query("SELECT * FROM dolt_replication_status ORDER BY remote")
+	expected := []sql.Row{
+		{"backup", "master", nil, nil, uint64(3), "unreachable", "failed"},
+		{"origin", "master", "abc", time.Unix(0, 0).UTC(), uint64(2), nil, "pending"},
+	}
+	assert.Equal(t, expected, rows)
+}
@@ -19,6 +19,7 @@ import (
 	"io"
 	"testing"
 
+	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/envtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
@@ -98,6 +100,93 @@ func executeModify(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValu
 	return db.GetRoot(sqlCtx)
 }
 
+// testSession runs statements against a database of a new test environment in a single session. Unlike
+// executeSelect and executeModify, the state of the session, such as its working root and head, carries over from one
+// statement to the next.
+type testSession struct {
+	t      *testing.T
+	dEnv   *env.DoltEnv
+	db     Database
+	engine *sqle.Engine
+	ctx    *sql.Context
+}
+
+// newTestSession creates a testSession on a new test environment whose database runs the commit hooks given.
+func newTestSession(t *testing.T, hooks ...CommitHook) *testSession {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+
+	db := NewDatabase("dolt", dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter()).WithCommitHooks(hooks...)
+	engine, sqlCtx, err := NewTestEngine(ctx, db, root)
+	require.NoError(t, err)
+
+	return &testSession{t: t, dEnv: dEnv, db: db, engine: engine, ctx: sqlCtx}
+}
+
+// exec runs the statement given, including statements run through ExecuteExtendedDDL, and returns its error.
+func (ts *testSession) exec(query string) error {
+	handled, err := ExecuteExtendedDDL(ts.ctx, ts.engine, query)
+	if handled || err != nil {
+		return err
+	}
+
+	_, iter, err := ts.engine.Query(ts.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	_, err = sql.RowIterToRows(iter)
+	if err != nil {
+		_ = iter.Close()
+	}
+
+	return err
+}
+
+// query runs the query given and returns its rows, failing the test if it can't be run.
+func (ts *testSession) query(query string) []sql.Row {
+	_, iter, err := ts.engine.Query(ts.ctx, query)
+	require.NoError(ts.t, err, query)
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(ts.t, err, query)
+	return rows
+}
+
+// commit commits the session's working root to master, as a client would with COMMIT() and dolt_branches, and makes
+// the commit the session's head.
+func (ts *testSession) commit(msg string) *doltdb.Commit {
+	ctx := context.Background()
+	master := ref.NewBranchRef("master")
+	parent, err := ts.dEnv.DoltDB.ResolveRef(ctx, master)
+	require.NoError(ts.t, err)
+
+	working, ok := DSessFromSess(ts.ctx.Session).GetRoot(ts.db.Name())
+	require.True(ts.t, ok)
+	h, err := ts.dEnv.DoltDB.WriteRootValue(ctx, working)
+	require.NoError(ts.t, err)
+
+	meta, err := doltdb.NewCommitMeta("Bill Billerson", "bill@example.com", msg)
+	require.NoError(ts.t, err)
+	cm, err := ts.dEnv.DoltDB.WriteDanglingCommit(ctx, h, []*doltdb.Commit{parent}, meta)
+	require.NoError(ts.t, err)
+	require.NoError(ts.t, ts.dEnv.DoltDB.FastForward(ctx, master, cm))
+
+	h, err = cm.HashOf()
+	require.NoError(ts.t, err)
+	require.NoError(ts.t, ts.ctx.Session.Set(ts.ctx, ts.db.HeadKey(), sql.Text, h.String()))
+	return cm
+}
+
+// tableSchema returns the schema of the table named, failing the test if it doesn't exist.
+func (ts *testSession) tableSchema(tblName string) schema.Schema {
+	tbl, ok, err := ts.db.GetTableInsensitive(ts.ctx, tblName)
+	require.NoError(ts.t, err)
+	require.True(ts.t, ok, tblName)
+	return tbl.(*AlterableDoltTable).doltSchema()
+}
+
 // Returns the dolt rows given transformed to sql rows. Exactly the columns in the schema provided are present in the
 // final output rows, even if the input rows contain different columns. The tag numbers for columns in the row and
 // schema given must match.
@@ -105,7 +194,7 @@ func ToSqlRows(sch schema.Schema, rs ...row.Row) []sql.Row {
 	sqlRows := make([]sql.Row, len(rs))
 	compressedSch := CompressSchema(sch)
 	for i := range rs {
-		sqlRows[i], _ = sqlutil.DoltRowToSqlRow(CompressRow(sch, rs[i]), compressedSch)
+		sqlRows[i], _ = sqlutil.DoltRowToSqlRow(context.Background(), CompressRow(sch, rs[i]), compressedSch)
 	}
 	return sqlRows
 }
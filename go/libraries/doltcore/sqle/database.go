@@ -46,6 +46,7 @@ type commitBehavior int8
 var ErrInvalidTableName = errors.NewKind("Invalid table name %s. Table names must match the regular expression " + doltdb.TableNameRegexStr)
 var ErrReservedTableName = errors.NewKind("Invalid table name %s. Table names beginning with `dolt_` are reserved for internal use")
 var ErrSystemTableAlter = errors.NewKind("Cannot alter table %s: system tables cannot be dropped or altered")
+var ErrReadOnlyDatabase = errors.NewKind("Database %s is read-only")
 
 const (
 	batched commitBehavior = iota
@@ -107,6 +108,14 @@ func (tc *tableCache) Put(tableName string, root *doltdb.RootValue, tbl sql.Tabl
 	tablesForRoot[tableName] = tbl
 }
 
+// Clear removes the tables cached for the root value given.
+func (tc *tableCache) Clear(root *doltdb.RootValue) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.tables, root)
+}
+
 func (tc *tableCache) AllForRoot(root *doltdb.RootValue) (map[string]sql.Table, bool) {
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
@@ -138,6 +147,11 @@ type Database struct {
 	rsw       env.RepoStateWriter
 	batchMode commitBehavior
 	tc        *tableCache
+	readOnly  bool
+	hooks     []CommitHook
+	txLock    *sync.Mutex
+	dEnv      *env.DoltEnv
+	branches  *branchStates
 }
 
 var _ SqlDatabase = Database{}
@@ -149,6 +163,7 @@ var _ sql.TriggerDatabase = Database{}
 
 // NewDatabase returns a new dolt database to use in queries.
 func NewDatabase(name string, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw env.RepoStateWriter) Database {
+	txLock := &sync.Mutex{}
 	return Database{
 		name:      name,
 		ddb:       ddb,
@@ -156,12 +171,15 @@ func NewDatabase(name string, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw e
 		rsw:       rsw,
 		batchMode: single,
 		tc:        &tableCache{&sync.Mutex{}, make(map[*doltdb.RootValue]map[string]sql.Table)},
+		txLock:    txLock,
+		branches:  newBranchStates(ddb, rsr, rsw, txLock),
 	}
 }
 
 // NewBatchedDatabase returns a new dolt database executing in batch insert mode. Integrators must call Flush() to
 // commit any outstanding edits.
 func NewBatchedDatabase(name string, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw env.RepoStateWriter) Database {
+	txLock := &sync.Mutex{}
 	return Database{
 		name:      name,
 		ddb:       ddb,
@@ -169,14 +187,54 @@ func NewBatchedDatabase(name string, ddb *doltdb.DoltDB, rsr env.RepoStateReader
 		rsw:       rsw,
 		batchMode: batched,
 		tc:        &tableCache{&sync.Mutex{}, make(map[*doltdb.RootValue]map[string]sql.Table)},
+		txLock:    txLock,
+		branches:  newBranchStates(ddb, rsr, rsw, txLock),
 	}
 }
 
+// NewReadOnlyDatabase returns a new dolt database which rejects any change made to its root value through SQL. The
+// root value served to sessions may still be changed by updating the working hash of the repo state given, see
+// SyncRootWithRepoState.
+func NewReadOnlyDatabase(name string, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw env.RepoStateWriter) Database {
+	db := NewDatabase(name, ddb, rsr, rsw)
+	db.readOnly = true
+	return db
+}
+
 // Name returns the name of this database, set at creation time.
 func (db Database) Name() string {
 	return db.name
 }
 
+// WithCommitHooks returns a copy of this Database which runs the hooks given after every commit made through SQL.
+func (db Database) WithCommitHooks(hooks ...CommitHook) Database {
+	db.hooks = append(append([]CommitHook(nil), db.hooks...), hooks...)
+	return db
+}
+
+// CommitHooks returns the hooks run after every commit made to this Database through SQL.
+func (db Database) CommitHooks() []CommitHook {
+	return db.hooks
+}
+
+// WithRemotes returns a copy of this Database which can fetch from, pull from and push to the remotes configured in
+// the environment given.
+func (db Database) WithRemotes(dEnv *env.DoltEnv) Database {
+	db.dEnv = dEnv
+	return db
+}
+
+// RemotesEnv returns the environment whose remotes this Database can fetch from, pull from and push to, or nil if it
+// has none.
+func (db Database) RemotesEnv() *env.DoltEnv {
+	return db.dEnv
+}
+
+// IsReadOnly returns whether this Database rejects changes to its root value
+func (db Database) IsReadOnly() bool {
+	return db.readOnly
+}
+
 // GetDoltDB gets the underlying DoltDB of the Database
 func (db Database) GetDoltDB() *doltdb.DoltDB {
 	return db.ddb
@@ -205,15 +263,24 @@ func (db Database) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Ta
 }
 
 func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.RootValue, tblName string) (dt sql.Table, found bool, err error) {
-	lwrName := strings.ToLower(tblName)
-
 	head, _, err := DSessFromSess(ctx.Session).GetParentCommit(ctx, db.name)
 	if err != nil {
 		return nil, false, err
 	}
 
+	return db.getTableInsensitive(ctx, head, root, tblName)
+}
+
+// getTableInsensitive returns the table with the name given in the root given, including the system tables, which
+// are generated from the head commit and root given.
+func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, root *doltdb.RootValue, tblName string) (dt sql.Table, found bool, err error) {
+	lwrName := strings.ToLower(tblName)
+
 	// NOTE: system tables are not suitable for caching
 	switch {
+	case lwrName == doltdb.DiffSummaryTableName:
+		found = true
+		dt = dtables.NewDiffSummaryTable(ctx, db.ddb, root)
 	case strings.HasPrefix(lwrName, doltdb.DoltDiffTablePrefix):
 		suffix := tblName[len(doltdb.DoltDiffTablePrefix):]
 		found = true
@@ -245,11 +312,27 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 	case doltdb.TableOfTablesInConflictName:
 		dt, found = dtables.NewTableOfTablesInConflict(ctx, db.ddb, root), true
 	case doltdb.BranchesTableName:
-		dt, found = dtables.NewBranchesTable(ctx, db.ddb), true
+		dt, found = dtables.NewBranchesTable(ctx, db.ddb, db.executeCommitHooks), true
 	case doltdb.CommitsTableName:
 		dt, found = dtables.NewCommitsTable(ctx, db.ddb), true
 	case doltdb.CommitAncestorsTableName:
 		dt, found = dtables.NewCommitAncestorsTable(ctx, db.ddb), true
+	case doltdb.ReplicationStatusTableName:
+		dt, found = dtables.NewReplicationStatusTable(ctx, db.replicationStatus()), true
+	case doltdb.StatusTableName:
+		dt, err = db.newStatusTable(ctx, head, root)
+		found = err == nil
+	case doltdb.SchemaHistoryTableName:
+		dt, found = dtables.NewSchemaHistoryTable(ctx, db.ddb, head, createTableStmt), true
+	case doltdb.SchemaDiffTableName:
+		dt, found = dtables.NewSchemaDiffTable(ctx, db.ddb, root, createTableStmt), true
+	case doltdb.ConstraintViolationsTableName:
+		dt, found = dtables.NewConstraintViolationsTable(ctx, root), true
+	case doltdb.RemoteUpdatesTableName:
+		dt, found = dtables.NewRemoteUpdatesTable(ctx, DSessFromSess(ctx.Session).GetRemoteUpdates(db.name)), true
+	}
+	if err != nil {
+		return nil, false, err
 	}
 	if found {
 		return dt, found, nil
@@ -258,43 +341,83 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 	return db.getTable(ctx, root, tblName)
 }
 
-// GetTableInsensitiveAsOf implements sql.VersionedDatabase
+// newStatusTable returns the dolt_status table for the head commit and working root given, and the session's staged
+// root.
+func (db Database) newStatusTable(ctx *sql.Context, head *doltdb.Commit, working *doltdb.RootValue) (sql.Table, error) {
+	headRoot, err := head.GetRootValue()
+	if err != nil {
+		return nil, err
+	}
+
+	staged, ok := DSessFromSess(ctx.Session).GetStagedRoot(db.name)
+	if !ok {
+		staged = headRoot
+	}
+
+	return dtables.NewStatusTable(ctx, headRoot, staged, working), nil
+}
+
+// createTableStmt returns the CREATE TABLE statement for the table with the name given in the root given.
+func createTableStmt(ctx *sql.Context, root *doltdb.RootValue, tblName string) (string, error) {
+	sqlCtx, engine, _ := PrepareCreateTableStmt(ctx, NewUserSpaceDatabase(root))
+	return GetCreateTableStmt(sqlCtx, engine, tblName)
+}
+
+// GetTableInsensitiveAsOf implements sql.VersionedDatabase. System tables are generated as of the commit the
+// expression given refers to, and views that only exist in that commit are evaluated using their definition from the
+// commit.
 func (db Database) GetTableInsensitiveAsOf(ctx *sql.Context, tableName string, asOf interface{}) (sql.Table, bool, error) {
-	root, err := db.rootAsOf(ctx, asOf)
+	cm, err := db.commitAsOf(ctx, asOf)
 	if err != nil {
 		return nil, false, err
-	} else if root == nil {
+	} else if cm == nil {
 		return nil, false, nil
 	}
 
-	return db.getTable(ctx, root, tableName)
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return nil, false, err
+	}
+
+	dt, found, err := db.getTableInsensitive(ctx, cm, root, tableName)
+	if err != nil || found {
+		return dt, found, err
+	}
+
+	return newViewAsOfTable(ctx, root, tableName)
 }
 
 // rootAsOf returns the root of the DB as of the expression given, which may be nil in the case that it refers to an
 // expression before the first commit.
 func (db Database) rootAsOf(ctx *sql.Context, asOf interface{}) (*doltdb.RootValue, error) {
-	switch x := asOf.(type) {
-	case string:
-		return db.getRootForCommitRef(ctx, x)
-	case time.Time:
-		return db.getRootForTime(ctx, x)
-	default:
-		panic(fmt.Sprintf("unsupported AS OF type %T", asOf))
+	cm, err := db.commitAsOf(ctx, asOf)
+	if err != nil || cm == nil {
+		return nil, err
 	}
+
+	return cm.GetRootValue()
 }
 
-func (db Database) getRootForTime(ctx *sql.Context, asOf time.Time) (*doltdb.RootValue, error) {
-	cs, err := doltdb.NewCommitSpec("HEAD")
+// commitAsOf returns the commit the expression given refers to, which may be nil in the case that it refers to an
+// expression before the first commit. Commit specs relative to HEAD, and times, are resolved from the session's head.
+func (db Database) commitAsOf(ctx *sql.Context, asOf interface{}) (*doltdb.Commit, error) {
+	head, _, err := DSessFromSess(ctx.Session).GetParentCommit(ctx, db.name)
 	if err != nil {
 		return nil, err
 	}
 
-	cm, err := db.ddb.Resolve(ctx, cs, db.rsr.CWBHeadRef())
-	if err != nil {
-		return nil, err
+	switch x := asOf.(type) {
+	case string:
+		return db.getCommitForRef(ctx, head, x)
+	case time.Time:
+		return db.getCommitForTime(ctx, head, x)
+	default:
+		panic(fmt.Sprintf("unsupported AS OF type %T", asOf))
 	}
+}
 
-	hash, err := cm.HashOf()
+func (db Database) getCommitForTime(ctx *sql.Context, head *doltdb.Commit, asOf time.Time) (*doltdb.Commit, error) {
+	hash, err := head.HashOf()
 	if err != nil {
 		return nil, err
 	}
@@ -318,30 +441,20 @@ func (db Database) getRootForTime(ctx *sql.Context, asOf time.Time) (*doltdb.Roo
 		}
 
 		if meta.Time().Equal(asOf) || meta.Time().Before(asOf) {
-			return curr.GetRootValue()
+			return curr, nil
 		}
 	}
 
 	return nil, nil
 }
 
-func (db Database) getRootForCommitRef(ctx *sql.Context, commitRef string) (*doltdb.RootValue, error) {
+func (db Database) getCommitForRef(ctx *sql.Context, head *doltdb.Commit, commitRef string) (*doltdb.Commit, error) {
 	cs, err := doltdb.NewCommitSpec(commitRef)
 	if err != nil {
 		return nil, err
 	}
 
-	cm, err := db.ddb.Resolve(ctx, cs, db.rsr.CWBHeadRef())
-	if err != nil {
-		return nil, err
-	}
-
-	root, err := cm.GetRootValue()
-	if err != nil {
-		return nil, err
-	}
-
-	return root, nil
+	return db.ddb.ResolveFromHead(ctx, cs, head)
 }
 
 // GetTableNamesAsOf implements sql.VersionedDatabase
@@ -505,6 +618,24 @@ func (db Database) GetRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
 // Set a new root value for the database. Can be used if the dolt working
 // set value changes outside of the basic SQL execution engine.
 func (db Database) SetRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
+	dsess := DSessFromSess(ctx.Session)
+	if db.readOnly || dsess.dbDatas[db.name].readOnly {
+		h, err := newRoot.HashOf()
+
+		if err != nil {
+			return err
+		}
+
+		if currRoot, ok := dsess.dbRoots[db.name]; ok && currRoot.hashStr != h.String() {
+			return ErrReadOnlyDatabase.New(db.name)
+		}
+	}
+
+	return db.setRoot(ctx, newRoot)
+}
+
+// setRoot sets a new root value for the database without checking whether the database is read-only.
+func (db Database) setRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
 	h, err := newRoot.HashOf()
 
 	if err != nil {
@@ -513,6 +644,7 @@ func (db Database) SetRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
 
 	hashStr := h.String()
 	key := db.WorkingKey()
+	dsess := DSessFromSess(ctx.Session)
 
 	err = ctx.Session.Set(ctx, key, hashType, hashStr)
 
@@ -520,7 +652,6 @@ func (db Database) SetRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
 		return err
 	}
 
-	dsess := DSessFromSess(ctx.Session)
 	dsess.dbRoots[db.name] = dbRoot{hashStr, newRoot}
 
 	err = dsess.dbEditors[db.name].SetRoot(ctx, newRoot)
@@ -532,15 +663,52 @@ func (db Database) SetRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
 }
 
 // LoadRootFromRepoState loads the root value from the repo state's working hash, then calls SetRoot with the loaded
-// root value.
+// root value. The repo state is that of the branch the session is working on.
 func (db Database) LoadRootFromRepoState(ctx *sql.Context) error {
-	workingHash := db.rsr.WorkingHash()
+	workingHash := db.sessionRepoState(ctx).WorkingHash()
 	root, err := db.ddb.ReadRootValue(ctx, workingHash)
 	if err != nil {
 		return err
 	}
 
-	return db.SetRoot(ctx, root)
+	return db.setRoot(ctx, root)
+}
+
+// SyncRootWithRepoState loads the head commit and the root value from the repo state if the working hash differs
+// from the root value the session is currently using for this database.
+func (db Database) SyncRootWithRepoState(ctx *sql.Context) error {
+	dsess := DSessFromSess(ctx.Session)
+	rsr := db.sessionRepoState(ctx)
+	if currRoot, ok := dsess.dbRoots[db.name]; ok && currRoot.hashStr == rsr.WorkingHash().String() {
+		return nil
+	}
+
+	cm, err := db.ddb.Resolve(ctx, rsr.CWBHeadSpec(), rsr.CWBHeadRef())
+	if err != nil {
+		return err
+	}
+
+	h, err := cm.HashOf()
+	if err != nil {
+		return err
+	}
+
+	err = dsess.Set(ctx, db.HeadKey(), sql.Text, h.String())
+	if err != nil {
+		return err
+	}
+
+	return db.LoadRootFromRepoState(ctx)
+}
+
+// sessionRepoState returns the repo state of the branch the session of the context given is working on in this
+// Database, which differs from the Database's own if the session switched branches with DOLT_CHECKOUT.
+func (db Database) sessionRepoState(ctx *sql.Context) env.RepoStateReader {
+	if dbd, ok := DSessFromSess(ctx.Session).dbDatas[db.name]; ok && dbd.rsr != nil {
+		return dbd.rsr
+	}
+
+	return db.rsr
 }
 
 // DropTable drops the table with the name given
@@ -707,18 +875,42 @@ func (db Database) Flush(ctx *sql.Context) error {
 // it can exist in a sql session later. Returns sql.ErrExistingView if a view
 // with that name already exists.
 func (db Database) CreateView(ctx *sql.Context, name string, definition string) error {
-	return db.addFragToSchemasTable(ctx, "view", name, definition, sql.ErrExistingView.New(name))
+	return db.addFragToSchemasTable(ctx, viewFragment, name, definition, sql.ErrExistingView.New(name))
 }
 
 // DropView implements sql.ViewDropper. Removes a view from persistence in the
 // dolt database. Returns sql.ErrNonExistingView if the view did not
 // exist.
 func (db Database) DropView(ctx *sql.Context, name string) error {
-	return db.dropFragFromSchemasTable(ctx, "view", name, sql.ErrNonExistingView.New(name))
+	return db.dropFragFromSchemasTable(ctx, viewFragment, name, sql.ErrNonExistingView.New(name))
 }
 
 // GetTriggers implements sql.TriggerDatabase.
 func (db Database) GetTriggers(ctx *sql.Context) ([]sql.TriggerDefinition, error) {
+	frags, err := db.getSchemaFragments(ctx, triggerFragment)
+	if err != nil {
+		return nil, err
+	}
+
+	var triggers []sql.TriggerDefinition
+	for _, frag := range frags {
+		triggers = append(triggers, sql.TriggerDefinition{
+			Name:            frag.name,
+			CreateStatement: frag.fragment,
+		})
+	}
+
+	return triggers, nil
+}
+
+// schemaFragment is a row of the dolt_schemas table
+type schemaFragment struct {
+	name     string
+	fragment string
+}
+
+// getSchemaFragments returns the fragments of the type given stored in the dolt_schemas table.
+func (db Database) getSchemaFragments(ctx *sql.Context, fragType string) ([]schemaFragment, error) {
 	sqlTbl, ok, err := db.GetTableInsensitive(ctx, doltdb.SchemasTableName)
 	if err != nil {
 		return nil, err
@@ -745,26 +937,26 @@ func (db Database) GetTriggers(ctx *sql.Context) ([]sql.TriggerDefinition, error
 	if err != nil {
 		return nil, err
 	}
-	var triggers []sql.TriggerDefinition
+	var frags []schemaFragment
 	err = rowData.Iter(ctx, func(key, val types.Value) (stop bool, err error) {
 		dRow, err := row.FromNoms(tbl.sch, key.(types.Tuple), val.(types.Tuple))
 		if err != nil {
 			return true, err
 		}
-		if typeColVal, ok := dRow.GetColVal(typeCol.Tag); ok && typeColVal.Equals(types.String("trigger")) {
+		if typeColVal, ok := dRow.GetColVal(typeCol.Tag); ok && typeColVal.Equals(types.String(fragType)) {
 			name, ok := dRow.GetColVal(nameCol.Tag)
 			if !ok {
 				taggedVals, _ := row.GetTaggedVals(dRow)
-				return true, fmt.Errorf("missing `%s` value for trigger row: (%s)", doltdb.SchemasTablesNameCol, taggedVals)
+				return true, fmt.Errorf("missing `%s` value for %s row: (%s)", doltdb.SchemasTablesNameCol, fragType, taggedVals)
 			}
-			createStmt, ok := dRow.GetColVal(fragCol.Tag)
+			fragment, ok := dRow.GetColVal(fragCol.Tag)
 			if !ok {
 				taggedVals, _ := row.GetTaggedVals(dRow)
-				return true, fmt.Errorf("missing `%s` value for trigger row: (%s)", doltdb.SchemasTablesFragmentCol, taggedVals)
+				return true, fmt.Errorf("missing `%s` value for %s row: (%s)", doltdb.SchemasTablesFragmentCol, fragType, taggedVals)
 			}
-			triggers = append(triggers, sql.TriggerDefinition{
-				Name:            string(name.(types.String)),
-				CreateStatement: string(createStmt.(types.String)),
+			frags = append(frags, schemaFragment{
+				name:     string(name.(types.String)),
+				fragment: string(fragment.(types.String)),
 			})
 		}
 		return false, nil
@@ -772,13 +964,13 @@ func (db Database) GetTriggers(ctx *sql.Context) ([]sql.TriggerDefinition, error
 	if err != nil {
 		return nil, err
 	}
-	return triggers, nil
+	return frags, nil
 }
 
 // CreateTrigger implements sql.TriggerDatabase.
 func (db Database) CreateTrigger(ctx *sql.Context, definition sql.TriggerDefinition) error {
 	return db.addFragToSchemasTable(ctx,
-		"trigger",
+		triggerFragment,
 		definition.Name,
 		definition.CreateStatement,
 		fmt.Errorf("triggers `%s` already exists", definition.Name), //TODO: add a sql error and return that instead
@@ -788,7 +980,49 @@ func (db Database) CreateTrigger(ctx *sql.Context, definition sql.TriggerDefinit
 // DropTrigger implements sql.TriggerDatabase.
 func (db Database) DropTrigger(ctx *sql.Context, name string) error {
 	//TODO: add a sql error and use that as the param error instead
-	return db.dropFragFromSchemasTable(ctx, "trigger", name, sql.ErrTriggerDoesNotExist.New(name))
+	return db.dropFragFromSchemasTable(ctx, triggerFragment, name, sql.ErrTriggerDoesNotExist.New(name))
+}
+
+// ProcedureDefinition is a stored procedure persisted in the dolt_schemas table, where it is versioned, branched and
+// merged along with the data like views and triggers are.
+type ProcedureDefinition struct {
+	Name            string
+	CreateStatement string
+}
+
+// GetProcedures returns the stored procedures persisted in the database.
+func (db Database) GetProcedures(ctx *sql.Context) ([]ProcedureDefinition, error) {
+	frags, err := db.getSchemaFragments(ctx, procedureFragment)
+	if err != nil {
+		return nil, err
+	}
+
+	var procedures []ProcedureDefinition
+	for _, frag := range frags {
+		procedures = append(procedures, ProcedureDefinition{
+			Name:            frag.name,
+			CreateStatement: frag.fragment,
+		})
+	}
+
+	return procedures, nil
+}
+
+// CreateProcedure persists a stored procedure in the dolt_schemas table. Returns ErrProcedureExists if a procedure
+// with that name already exists.
+func (db Database) CreateProcedure(ctx *sql.Context, definition ProcedureDefinition) error {
+	return db.addFragToSchemasTable(ctx,
+		procedureFragment,
+		strings.ToLower(definition.Name),
+		definition.CreateStatement,
+		ErrProcedureExists.New(definition.Name),
+	)
+}
+
+// DropProcedure removes a stored procedure from the dolt_schemas table. Returns ErrProcedureDoesNotExist if the
+// procedure did not exist.
+func (db Database) DropProcedure(ctx *sql.Context, name string) error {
+	return db.dropFragFromSchemasTable(ctx, procedureFragment, strings.ToLower(name), ErrProcedureDoesNotExist.New(name))
 }
 
 func (db Database) addFragToSchemasTable(ctx *sql.Context, fragType, name, definition string, existingErr error) (retErr error) {
@@ -896,7 +1130,7 @@ func RegisterSchemaFragments(ctx *sql.Context, db Database, root *doltdb.RootVal
 
 	r, err := iter.Next()
 	for err == nil {
-		if r[0] == "view" {
+		if r[0] == viewFragment {
 			name := r[1].(string)
 			definition := r[2].(string)
 			cv, err := parse.Parse(ctx, fmt.Sprintf("create view %s as %s", sqlfmt.QuoteIdentifier(name), definition))
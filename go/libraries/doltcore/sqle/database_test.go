@@ -34,3 +34,31 @@ func TestIsKeyFuncs(t *testing.T) {
 	testKeyFunc(t, IsHeadKey, "dolt_working", false, "")
 	testKeyFunc(t, IsWorkingKey, "dolt_working", true, "dolt")
 }
+
+func TestSplitRevisionDbName(t *testing.T) {
+	tests := []struct {
+		dbName   string
+		base     string
+		revision string
+		isCommit bool
+		ok       bool
+	}{
+		{"dolt", "", "", false, false},
+		{"dolt/", "", "", false, false},
+		{"/feature", "", "", false, false},
+		{"dolt/feature", "dolt", "feature", false, true},
+		{"dolt/feature/nested", "dolt", "feature/nested", false, true},
+		{"dolt@HEAD~2", "dolt", "HEAD~2", true, true},
+		{"dolt@ns2kl8u5ltlvbf7s9b3fmr1kalvtm4ts", "dolt", "ns2kl8u5ltlvbf7s9b3fmr1kalvtm4ts", true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dbName, func(t *testing.T) {
+			base, revision, isCommit, ok := SplitRevisionDbName(test.dbName)
+			assert.Equal(t, test.base, base)
+			assert.Equal(t, test.revision, revision)
+			assert.Equal(t, test.isCommit, isCommit)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
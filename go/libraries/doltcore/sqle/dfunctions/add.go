@@ -0,0 +1,132 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const (
+	addFuncName = "dolt_add"
+
+	addAllParameter = "-A"
+	addDotParameter = "."
+)
+
+// DoltAddFunc stages tables for the next commit made with COMMIT(). DOLT_ADD('table', ...) stages the tables given,
+// and DOLT_ADD('.') or DOLT_ADD('-A') stages every table. Staged tables are tracked by the session, and are discarded
+// when the session's head changes. Returns the hash of the staged root.
+type DoltAddFunc struct {
+	args []sql.Expression
+}
+
+// NewDoltAddFunc creates a new DoltAddFunc expression.
+func NewDoltAddFunc(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(addFuncName, "1 or more", 0)
+	}
+
+	return DoltAddFunc{args}, nil
+}
+
+// Eval implements the Expression interface.
+func (af DoltAddFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	tbls, err := evalStringArgs(ctx, row, af.args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+
+	working, ok := dSess.GetRoot(dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	staged, ok := dSess.GetStagedRoot(dbName)
+	if !ok {
+		parent, _, err := dSess.GetParentCommit(ctx, dbName)
+		if err != nil {
+			return nil, err
+		}
+
+		staged, err = parent.GetRootValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tbls) == 1 && (tbls[0] == addAllParameter || tbls[0] == addDotParameter) {
+		tbls, err = doltdb.UnionTableNames(ctx, staged, working)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newStaged, newWorking, err := actions.StageTablesInRoots(ctx, tbls, staged, working)
+	if err != nil {
+		return nil, err
+	}
+
+	if newWorking != working {
+		err = dSess.SetWorkingRoot(ctx, dbName, newWorking)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dSess.SetStagedRoot(dbName, newStaged)
+
+	h, err := newStaged.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// Resolved implements the Expression interface.
+func (af DoltAddFunc) Resolved() bool {
+	return argsResolved(af.args)
+}
+
+// String implements the Stringer interface.
+func (af DoltAddFunc) String() string {
+	return funcString(addFuncName, af.args)
+}
+
+// IsNullable implements the Expression interface.
+func (af DoltAddFunc) IsNullable() bool {
+	return false
+}
+
+// Children implements the Expression interface.
+func (af DoltAddFunc) Children() []sql.Expression {
+	return af.args
+}
+
+// WithChildren implements the Expression interface.
+func (af DoltAddFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltAddFunc(children...)
+}
+
+// Type implements the Expression interface.
+func (af DoltAddFunc) Type() sql.Type {
+	return sql.Text
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// evalStringArgs evaluates the arguments of a function which only takes strings.
+func evalStringArgs(ctx *sql.Context, row sql.Row, args []sql.Expression) ([]string, error) {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		val, err := arg.Eval(ctx, row)
+
+		if err != nil {
+			return nil, err
+		}
+
+		str, ok := val.(string)
+
+		if !ok {
+			return nil, sql.ErrInvalidChildType.New(arg.String(), arg.Type(), sql.Text.Type())
+		}
+
+		strs[i] = str
+	}
+
+	return strs, nil
+}
+
+// argsResolved returns whether all of the arguments of a function are resolved.
+func argsResolved(args []sql.Expression) bool {
+	for _, arg := range args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// funcString returns the string representation of a call to the function named with the arguments given.
+func funcString(name string, args []sql.Expression) string {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		strs[i] = arg.String()
+	}
+
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(name), strings.Join(strs, ", "))
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const (
+	branchFuncName = "dolt_branch"
+
+	branchDeleteParameter      = "-d"
+	branchForceDeleteParameter = "-D"
+)
+
+// DoltBranchFunc creates and deletes branches. DOLT_BRANCH('name') creates a branch at the session's head commit,
+// DOLT_BRANCH('name', 'start-point') creates a branch at the commit given, and DOLT_BRANCH('-d', 'name') deletes a
+// branch, or DOLT_BRANCH('-D', 'name') if it isn't merged into master. Returns the hash of the branch's commit.
+type DoltBranchFunc struct {
+	args []sql.Expression
+}
+
+// NewDoltBranchFunc creates a new DoltBranchFunc expression.
+func NewDoltBranchFunc(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(branchFuncName, "1 or 2", len(args))
+	}
+
+	return DoltBranchFunc{args}, nil
+}
+
+// Eval implements the Expression interface.
+func (bf DoltBranchFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := evalStringArgs(ctx, row, bf.args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+
+	ddb, ok := dSess.GetDoltDB(dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	headRef, _ := dSess.GetHeadRef(dbName)
+
+	if args[0] == branchDeleteParameter || args[0] == branchForceDeleteParameter {
+		if len(args) != 2 {
+			return nil, sql.ErrInvalidArgumentNumber.New(branchFuncName, 2, len(args))
+		}
+
+		branch := ref.NewBranchRef(args[1])
+		if headRef != nil && ref.Equals(headRef, branch) {
+			return nil, actions.ErrCOBranchDelete
+		}
+
+		dEnv, hasEnv := dSess.GetRemotesEnv(dbName)
+		if hasEnv && ref.Equals(dEnv.RepoState.CWBHeadRef(), branch) {
+			return nil, actions.ErrCOBranchDelete
+		}
+
+		cm, err := ddb.ResolveRef(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		err = actions.DeleteBranchOnDB(ctx, ddb, branch, actions.DeleteOptions{Force: args[0] == branchForceDeleteParameter})
+		if err != nil {
+			return nil, err
+		}
+
+		dSess.DiscardBranchWorkingSet(dbName, branch)
+
+		return h.String(), nil
+	}
+
+	startPoint := ""
+	if len(args) == 2 {
+		startPoint = args[1]
+	} else {
+		_, h, err := dSess.GetParentCommit(ctx, dbName)
+		if err != nil {
+			return nil, err
+		}
+
+		startPoint = h.String()
+	}
+
+	err = actions.CreateBranchOnDB(ctx, ddb, args[0], startPoint, false, headRef)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := ref.NewBranchRef(args[0])
+	cm, err := ddb.ResolveRef(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dSess.ExecuteCommitHooks(ctx, dbName, branch, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// Resolved implements the Expression interface.
+func (bf DoltBranchFunc) Resolved() bool {
+	return argsResolved(bf.args)
+}
+
+// String implements the Stringer interface.
+func (bf DoltBranchFunc) String() string {
+	return funcString(branchFuncName, bf.args)
+}
+
+// IsNullable implements the Expression interface.
+func (bf DoltBranchFunc) IsNullable() bool {
+	return false
+}
+
+// Children implements the Expression interface.
+func (bf DoltBranchFunc) Children() []sql.Expression {
+	return bf.args
+}
+
+// WithChildren implements the Expression interface.
+func (bf DoltBranchFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltBranchFunc(children...)
+}
+
+// Type implements the Expression interface.
+func (bf DoltBranchFunc) Type() sql.Type {
+	return sql.Text
+}
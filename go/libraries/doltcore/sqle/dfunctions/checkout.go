@@ -0,0 +1,200 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"errors"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const (
+	checkoutFuncName = "dolt_checkout"
+
+	checkoutNewBranchParameter = "-b"
+)
+
+var errUncommittedChanges = errors.New("cannot switch branches with uncommitted changes; commit or reset them first")
+
+// DoltCheckoutFunc switches the session to a branch, or discards the changes made to tables. DOLT_CHECKOUT('branch')
+// switches the session's head to the branch given, DOLT_CHECKOUT('-b', 'branch') creates a branch at the session's
+// head commit and switches to it, and DOLT_CHECKOUT('table', ...) restores the tables given to their staged versions.
+// Returns the hash of the session's head commit.
+type DoltCheckoutFunc struct {
+	args []sql.Expression
+}
+
+// NewDoltCheckoutFunc creates a new DoltCheckoutFunc expression.
+func NewDoltCheckoutFunc(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(checkoutFuncName, "1 or more", 0)
+	}
+
+	return DoltCheckoutFunc{args}, nil
+}
+
+// Eval implements the Expression interface.
+func (cf DoltCheckoutFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := evalStringArgs(ctx, row, cf.args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+
+	ddb, ok := dSess.GetDoltDB(dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	if args[0] == checkoutNewBranchParameter {
+		if len(args) != 2 {
+			return nil, sql.ErrInvalidArgumentNumber.New(checkoutFuncName, 2, len(args))
+		}
+
+		_, h, err := dSess.GetParentCommit(ctx, dbName)
+		if err != nil {
+			return nil, err
+		}
+
+		headRef, _ := dSess.GetHeadRef(dbName)
+		err = actions.CreateBranchOnDB(ctx, ddb, args[1], h.String(), false, headRef)
+		if err != nil {
+			return nil, err
+		}
+
+		branch := ref.NewBranchRef(args[1])
+		cm, err := ddb.ResolveRef(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		err = dSess.ExecuteCommitHooks(ctx, dbName, branch, cm)
+		if err != nil {
+			return nil, err
+		}
+
+		return switchBranch(ctx, dSess, dbName, branch)
+	}
+
+	if len(args) == 1 {
+		branch := ref.NewBranchRef(args[0])
+		isBranch, err := ddb.HasRef(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		if isBranch {
+			dirty, err := dSess.HasUncommittedChanges(ctx, dbName)
+			if err != nil {
+				return nil, err
+			} else if dirty {
+				return nil, errUncommittedChanges
+			}
+
+			return switchBranch(ctx, dSess, dbName, branch)
+		}
+	}
+
+	return checkoutTables(ctx, dSess, dbName, args)
+}
+
+// switchBranch switches the session's head to the branch given, and returns the hash of the branch's head commit.
+func switchBranch(ctx *sql.Context, dSess *sqle.DoltSession, dbName string, branch ref.BranchRef) (interface{}, error) {
+	cm, err := dSess.SwitchBranch(ctx, dbName, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// checkoutTables replaces the tables given in the session's working root with their staged versions, or the versions
+// in the session's head commit if nothing is staged.
+func checkoutTables(ctx *sql.Context, dSess *sqle.DoltSession, dbName string, tbls []string) (interface{}, error) {
+	parent, h, err := dSess.GetParentCommit(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	staged, ok := dSess.GetStagedRoot(dbName)
+	if !ok {
+		staged, err = parent.GetRootValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	working, ok := dSess.GetRoot(dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	err = actions.ValidateTables(ctx, tbls, staged, working)
+	if err != nil {
+		return nil, err
+	}
+
+	working, err = actions.MoveTablesBetweenRoots(ctx, tbls, staged, working)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dSess.SetWorkingRoot(ctx, dbName, working)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// Resolved implements the Expression interface.
+func (cf DoltCheckoutFunc) Resolved() bool {
+	return argsResolved(cf.args)
+}
+
+// String implements the Stringer interface.
+func (cf DoltCheckoutFunc) String() string {
+	return funcString(checkoutFuncName, cf.args)
+}
+
+// IsNullable implements the Expression interface.
+func (cf DoltCheckoutFunc) IsNullable() bool {
+	return false
+}
+
+// Children implements the Expression interface.
+func (cf DoltCheckoutFunc) Children() []sql.Expression {
+	return cf.args
+}
+
+// WithChildren implements the Expression interface.
+func (cf DoltCheckoutFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltCheckoutFunc(children...)
+}
+
+// Type implements the Expression interface.
+func (cf DoltCheckoutFunc) Type() sql.Type {
+	return sql.Text
+}
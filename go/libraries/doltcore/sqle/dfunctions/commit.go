@@ -62,7 +62,12 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	root, ok := dSess.GetRoot(dbName)
+	// commit the staged tables if any have been staged, and every table otherwise
+	root, ok := dSess.GetStagedRoot(dbName)
+
+	if !ok {
+		root, ok = dSess.GetRoot(dbName)
+	}
 
 	if !ok {
 		return nil, fmt.Errorf("unknown database '%s'", dbName)
@@ -90,7 +95,12 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	cm, err := ddb.WriteDanglingCommit(ctx, h, []*doltdb.Commit{parent}, meta)
+	parents := []*doltdb.Commit{parent}
+	if mergeParent, ok := dSess.GetMergeParent(dbName); ok {
+		parents = append(parents, mergeParent)
+	}
+
+	cm, err := ddb.WriteDanglingCommit(ctx, h, parents, meta)
 
 	if err != nil {
 		return nil, err
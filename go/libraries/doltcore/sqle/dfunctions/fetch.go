@@ -0,0 +1,102 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const fetchFuncName = "dolt_fetch"
+
+// DoltFetchFunc fetches branches from a remote of the current database into their remote tracking branches.
+// DOLT_FETCH() fetches every branch from the default remote, DOLT_FETCH('remote', 'branch', ...) fetches the branches
+// given from the remote named, and DOLT_FETCH('-f', ...) overwrites remote tracking branches which can't be fast
+// forwarded. Returns the number of remote tracking branches updated, which are listed by the dolt_remote_updates
+// system table.
+type DoltFetchFunc struct {
+	args []sql.Expression
+}
+
+// NewDoltFetchFunc creates a new DoltFetchFunc expression.
+func NewDoltFetchFunc(args ...sql.Expression) (sql.Expression, error) {
+	return DoltFetchFunc{args}, nil
+}
+
+// Eval implements the Expression interface.
+func (ff DoltFetchFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := evalStringArgs(ctx, row, ff.args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+	dSess.SetRemoteUpdates(dbName, nil)
+
+	dEnv, err := getRemotesEnv(dSess, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, args := parseForceFlag(args)
+	remote, branches, err := remoteFromArgs(dEnv, args)
+	if err != nil {
+		return nil, err
+	}
+
+	updates, err := fetchRemote(ctx, dEnv, mode, remote, branches)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteUpdates, err := toRemoteUpdates(ctx, dEnv.DoltDB, mode, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	dSess.SetRemoteUpdates(dbName, remoteUpdates)
+	return countUpdated(remoteUpdates), nil
+}
+
+// Resolved implements the Expression interface.
+func (ff DoltFetchFunc) Resolved() bool {
+	return argsResolved(ff.args)
+}
+
+// String implements the Stringer interface.
+func (ff DoltFetchFunc) String() string {
+	return funcString(fetchFuncName, ff.args)
+}
+
+// IsNullable implements the Expression interface.
+func (ff DoltFetchFunc) IsNullable() bool {
+	return false
+}
+
+// Children implements the Expression interface.
+func (ff DoltFetchFunc) Children() []sql.Expression {
+	return ff.args
+}
+
+// WithChildren implements the Expression interface.
+func (ff DoltFetchFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltFetchFunc(children...)
+}
+
+// Type implements the Expression interface.
+func (ff DoltFetchFunc) Type() sql.Type {
+	return sql.Int64
+}
@@ -16,10 +16,17 @@ package dfunctions
 
 import "github.com/dolthub/go-mysql-server/sql"
 
-var DoltFunctions = []sql.Function{
+var DoltFunctions = append([]sql.Function{
 	sql.Function1{Name: HashOfFuncName, Fn: NewHashOf},
 	sql.Function1{Name: CommitFuncName, Fn: NewCommitFunc},
 	sql.Function1{Name: MergeFuncName, Fn: NewMergeFunc},
 	sql.Function1{Name: resetFuncName, Fn: NewDoltResetFunc},
 	sql.Function0{Name: VersionFuncName, Fn: NewVersion},
-}
+	sql.Function0{Name: verifyFuncName, Fn: NewDoltVerifyFunc},
+	sql.FunctionN{Name: branchFuncName, Fn: NewDoltBranchFunc},
+	sql.FunctionN{Name: checkoutFuncName, Fn: NewDoltCheckoutFunc},
+	sql.FunctionN{Name: addFuncName, Fn: NewDoltAddFunc},
+	sql.FunctionN{Name: fetchFuncName, Fn: NewDoltFetchFunc},
+	sql.FunctionN{Name: pullFuncName, Fn: NewDoltPullFunc},
+	sql.FunctionN{Name: pushFuncName, Fn: NewDoltPushFunc},
+}, SpatialFunctions...)
@@ -17,6 +17,7 @@ package dfunctions
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -78,11 +79,15 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	mergeRoot, _, err := merge.MergeCommits(ctx, parent, cm)
+	mergeRoot, tblToStats, err := merge.MergeCommits(ctx, parent, cm)
 	if err == merge.ErrFastForward {
 		return cmh.String(), nil
 	}
 
+	if violated := merge.TablesWithCheckViolations(tblToStats); len(violated) > 0 {
+		return nil, fmt.Errorf("merge violates check constraints on table(s) %s", strings.Join(violated, ", "))
+	}
+
 	h, err := ddb.WriteRootValue(ctx, mergeRoot)
 	if err != nil {
 		return nil, err
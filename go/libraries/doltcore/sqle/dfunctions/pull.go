@@ -0,0 +1,220 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+const pullFuncName = "dolt_pull"
+
+// DoltPullFunc fetches from a remote of the current database and merges the remote tracking branch of the session's
+// branch into the session. DOLT_PULL() pulls from the default remote, and DOLT_PULL('remote') from the remote named.
+// If the session's head can be fast forwarded to the remote branch it is moved there. Otherwise the remote branch is
+// merged into the session's working root, any conflicts can be found in dolt_conflicts, and the remote branch becomes
+// the second parent of the next commit made with COMMIT(). Returns the number of remote tracking branches updated
+// plus 1 if the session's branch was fast forwarded or merged. The updates are listed by the dolt_remote_updates
+// system table, followed by a row for the session's branch whose status describes the merge.
+type DoltPullFunc struct {
+	args []sql.Expression
+}
+
+// NewDoltPullFunc creates a new DoltPullFunc expression.
+func NewDoltPullFunc(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) > 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(pullFuncName, "0 or 1", len(args))
+	}
+
+	return DoltPullFunc{args}, nil
+}
+
+// Eval implements the Expression interface.
+func (pf DoltPullFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := evalStringArgs(ctx, row, pf.args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+	dSess.SetRemoteUpdates(dbName, nil)
+
+	dEnv, err := getRemotesEnv(dSess, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, args, err := remoteFromArgs(dEnv, args)
+	if err != nil {
+		return nil, err
+	} else if len(args) > 0 {
+		return nil, fmt.Errorf("unknown remote '%s'", args[0])
+	}
+
+	headRef, ok := dSess.GetHeadRef(dbName)
+	if !ok || headRef.GetType() != ref.BranchRefType {
+		return nil, errNoSessionBranch
+	}
+
+	dirty, err := dSess.HasUncommittedChanges(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if dirty {
+		return nil, errors.New("cannot pull with uncommitted changes")
+	}
+
+	updates, err := fetchRemote(ctx, dEnv, ref.FastForwardOnly, remote, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteUpdates, err := toRemoteUpdates(ctx, dEnv.DoltDB, ref.FastForwardOnly, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	refSpecs, verr := dEnv.GetRefSpecs(remote.Name)
+	if verr != nil {
+		return nil, verr
+	}
+
+	var trackingRef ref.DoltRef
+	for _, rs := range refSpecs {
+		if trackingRef = rs.DestRef(headRef); trackingRef != nil {
+			break
+		}
+	}
+
+	if trackingRef == nil {
+		return nil, fmt.Errorf("branch '%s' has no remote tracking branch for remote '%s'", headRef.GetPath(), remote.Name)
+	}
+
+	ddb, ok := dSess.GetDoltDB(dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	theirs, err := ddb.ResolveRef(ctx, trackingRef)
+	if err == doltdb.ErrBranchNotFound {
+		return nil, fmt.Errorf("branch '%s' does not exist on remote '%s'", headRef.GetPath(), remote.Name)
+	} else if err != nil {
+		return nil, err
+	}
+
+	mergeUpdate, err := mergeIntoSession(ctx, dSess, dbName, headRef, theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteUpdates = append(remoteUpdates, mergeUpdate)
+	dSess.SetRemoteUpdates(dbName, remoteUpdates)
+	return countUpdated(remoteUpdates), nil
+}
+
+// mergeIntoSession merges the commit given into the session's head for the database given, returning the remote
+// update of the session's branch describing the result.
+func mergeIntoSession(ctx *sql.Context, dSess *sqle.DoltSession, dbName string, headRef ref.DoltRef, theirs *doltdb.Commit) (dtables.RemoteUpdate, error) {
+	h, err := theirs.HashOf()
+	if err != nil {
+		return dtables.RemoteUpdate{}, err
+	}
+
+	ours, oursHash, err := dSess.GetParentCommit(ctx, dbName)
+	if err != nil {
+		return dtables.RemoteUpdate{}, err
+	}
+
+	update := dtables.RemoteUpdate{Ref: headRef.GetPath(), OldHash: oursHash.String(), NewHash: h.String()}
+
+	canFF, err := ours.CanFastForwardTo(ctx, theirs)
+	if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
+		update.NewHash, update.Status = update.OldHash, dtables.RemoteUpdateUpToDate
+		return update, nil
+	} else if err != nil {
+		return dtables.RemoteUpdate{}, err
+	}
+
+	if canFF {
+		err = dSess.Set(ctx, dbName+sqle.HeadKeySuffix, sql.Text, h.String())
+		if err != nil {
+			return dtables.RemoteUpdate{}, err
+		}
+
+		update.Status = dtables.RemoteUpdateFastForward
+		return update, nil
+	}
+
+	mergeRoot, _, err := merge.MergeCommits(ctx, ours, theirs)
+	if err != nil {
+		return dtables.RemoteUpdate{}, err
+	}
+
+	err = dSess.SetWorkingRoot(ctx, dbName, mergeRoot)
+	if err != nil {
+		return dtables.RemoteUpdate{}, err
+	}
+
+	dSess.SetMergeParent(dbName, theirs)
+
+	conflicts, err := mergeRoot.TablesInConflict(ctx)
+	if err != nil {
+		return dtables.RemoteUpdate{}, err
+	}
+
+	update.Status = dtables.RemoteUpdateMerged
+	if len(conflicts) > 0 {
+		update.Status = dtables.RemoteUpdateConflicts
+	}
+
+	return update, nil
+}
+
+// Resolved implements the Expression interface.
+func (pf DoltPullFunc) Resolved() bool {
+	return argsResolved(pf.args)
+}
+
+// String implements the Stringer interface.
+func (pf DoltPullFunc) String() string {
+	return funcString(pullFuncName, pf.args)
+}
+
+// IsNullable implements the Expression interface.
+func (pf DoltPullFunc) IsNullable() bool {
+	return false
+}
+
+// Children implements the Expression interface.
+func (pf DoltPullFunc) Children() []sql.Expression {
+	return pf.args
+}
+
+// WithChildren implements the Expression interface.
+func (pf DoltPullFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltPullFunc(children...)
+}
+
+// Type implements the Expression interface.
+func (pf DoltPullFunc) Type() sql.Type {
+	return sql.Int64
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const pushFuncName = "dolt_push"
+
+// DoltPushFunc pushes the session's head commit to a branch of a remote of the current database. DOLT_PUSH() pushes
+// to the session's branch of the default remote, DOLT_PUSH('remote') or DOLT_PUSH('remote', 'branch') to the remote
+// and branch given, and DOLT_PUSH('-f', ...) overwrites the remote branch if it can't be fast forwarded. Returns 1 if
+// the remote branch was updated and 0 if it was up to date, and the update is shown by the dolt_remote_updates system
+// table.
+type DoltPushFunc struct {
+	args []sql.Expression
+}
+
+// NewDoltPushFunc creates a new DoltPushFunc expression.
+func NewDoltPushFunc(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(pushFuncName, "0 to 3", len(args))
+	}
+
+	return DoltPushFunc{args}, nil
+}
+
+// Eval implements the Expression interface.
+func (pf DoltPushFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := evalStringArgs(ctx, row, pf.args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+	dSess.SetRemoteUpdates(dbName, nil)
+
+	dEnv, err := getRemotesEnv(dSess, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, args := parseForceFlag(args)
+	remote, args, err := remoteFromArgs(dEnv, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var branch ref.BranchRef
+	switch len(args) {
+	case 0:
+		headRef, ok := dSess.GetHeadRef(dbName)
+		if !ok || headRef.GetType() != ref.BranchRefType {
+			return nil, errNoSessionBranch
+		}
+		branch = ref.NewBranchRef(headRef.GetPath())
+	case 1:
+		branch = ref.NewBranchRef(args[0])
+	default:
+		return nil, fmt.Errorf("unknown remote '%s'", args[0])
+	}
+
+	cm, h, err := dSess.GetParentCommit(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	destDB, err := getRemoteDB(ctx, dEnv, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	update := actions.RefUpdate{Ref: ref.NewRemoteRef(remote.Name, branch.GetPath()), NewHash: h}
+	update.OldHash, err = branchHash(ctx, destDB, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.OldHash != update.NewHash {
+		err = actions.PushToRemoteBranch(ctx, dEnv, mode, branch, update.Ref.(ref.RemoteRef), destDB, cm)
+		if errors.Is(err, actions.ErrCantFF) {
+			return nil, fmt.Errorf("push to %s rejected: the remote branch contains commits the session's head does not; pull them first", update.Ref.GetPath())
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	remoteUpdates, err := toRemoteUpdates(ctx, destDB, mode, []actions.RefUpdate{update})
+	if err != nil {
+		return nil, err
+	}
+
+	dSess.SetRemoteUpdates(dbName, remoteUpdates)
+	return countUpdated(remoteUpdates), nil
+}
+
+// branchHash returns the hash of the commit at the head of the branch given, or an empty hash if it doesn't exist.
+func branchHash(ctx *sql.Context, ddb *doltdb.DoltDB, branch ref.BranchRef) (hash.Hash, error) {
+	hasRef, err := ddb.HasRef(ctx, branch)
+	if err != nil || !hasRef {
+		return hash.Hash{}, err
+	}
+
+	cm, err := ddb.ResolveRef(ctx, branch)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	return cm.HashOf()
+}
+
+// Resolved implements the Expression interface.
+func (pf DoltPushFunc) Resolved() bool {
+	return argsResolved(pf.args)
+}
+
+// String implements the Stringer interface.
+func (pf DoltPushFunc) String() string {
+	return funcString(pushFuncName, pf.args)
+}
+
+// IsNullable implements the Expression interface.
+func (pf DoltPushFunc) IsNullable() bool {
+	return false
+}
+
+// Children implements the Expression interface.
+func (pf DoltPushFunc) Children() []sql.Expression {
+	return pf.args
+}
+
+// WithChildren implements the Expression interface.
+func (pf DoltPushFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltPushFunc(children...)
+}
+
+// Type implements the Expression interface.
+func (pf DoltPushFunc) Type() sql.Type {
+	return sql.Int64
+}
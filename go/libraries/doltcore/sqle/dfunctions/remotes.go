@@ -0,0 +1,189 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dfunctions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	forceParameter     = "-f"
+	longForceParameter = "--force"
+)
+
+var errNoSessionBranch = errors.New("the session is not on a branch")
+
+// getRemotesEnv returns the environment holding the remotes of the database given.
+func getRemotesEnv(dSess *sqle.DoltSession, dbName string) (*env.DoltEnv, error) {
+	dEnv, ok := dSess.GetRemotesEnv(dbName)
+	if !ok {
+		return nil, fmt.Errorf("remotes are not available for database '%s'", dbName)
+	}
+
+	return dEnv, nil
+}
+
+// parseForceFlag returns the ref update mode given by the leading -f or --force flag of the arguments given, if any,
+// along with the remaining arguments.
+func parseForceFlag(args []string) (ref.RefUpdateMode, []string) {
+	if len(args) > 0 && (args[0] == forceParameter || args[0] == longForceParameter) {
+		return ref.ForceUpdate, args[1:]
+	}
+
+	return ref.FastForwardOnly, args
+}
+
+// remoteFromArgs returns the remote named by the first of the arguments given, along with the remaining arguments.
+// The default remote is returned if the first argument doesn't name a remote.
+func remoteFromArgs(dEnv *env.DoltEnv, args []string) (env.Remote, []string, error) {
+	if len(args) > 0 {
+		if remote, ok := dEnv.RepoState.Remotes[args[0]]; ok {
+			return remote, args[1:], nil
+		}
+	}
+
+	remote, verr := dEnv.GetDefaultRemote()
+	if verr != nil {
+		return env.NoRemote, nil, verr
+	}
+
+	return remote, args, nil
+}
+
+// fetchRemote fetches the branches given from the remote given into its remote tracking branches, or every branch
+// matched by the remote's refspecs if none are given.
+func fetchRemote(ctx context.Context, dEnv *env.DoltEnv, mode ref.RefUpdateMode, remote env.Remote, branches []string) ([]actions.RefUpdate, error) {
+	var refSpecs []ref.RemoteRefSpec
+	if len(branches) == 0 {
+		var verr error
+		refSpecs, verr = dEnv.GetRefSpecs(remote.Name)
+		if verr != nil {
+			return nil, verr
+		}
+	}
+
+	for _, branch := range branches {
+		rs, err := ref.ParseRefSpecForRemote(remote.Name, fmt.Sprintf("refs/heads/%[1]s:refs/remotes/%[2]s/%[1]s", branch, remote.Name))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid branch name", branch)
+		}
+
+		rrs, ok := rs.(ref.RemoteRefSpec)
+		if !ok {
+			return nil, fmt.Errorf("'%s' is not a valid branch name", branch)
+		}
+
+		refSpecs = append(refSpecs, rrs)
+	}
+
+	srcDB, err := getRemoteDB(ctx, dEnv, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	return actions.FetchRefSpecs(ctx, dEnv, mode, srcDB, refSpecs)
+}
+
+func getRemoteDB(ctx context.Context, dEnv *env.DoltEnv, remote env.Remote) (*doltdb.DoltDB, error) {
+	remoteDB, err := remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote db for '%s': %w", remote.Name, err)
+	}
+
+	return remoteDB, nil
+}
+
+// toRemoteUpdates returns the remote updates describing the ref updates given, made in the mode given. The commits
+// of forced updates are resolved in the database given to tell whether they were fast forwards.
+func toRemoteUpdates(ctx context.Context, ddb *doltdb.DoltDB, mode ref.RefUpdateMode, updates []actions.RefUpdate) ([]dtables.RemoteUpdate, error) {
+	remoteUpdates := make([]dtables.RemoteUpdate, len(updates))
+	for i, update := range updates {
+		status, err := refUpdateStatus(ctx, ddb, mode, update)
+		if err != nil {
+			return nil, err
+		}
+
+		remoteUpdates[i] = dtables.RemoteUpdate{Ref: update.Ref.GetPath(), NewHash: update.NewHash.String(), Status: status}
+		if !update.OldHash.IsEmpty() {
+			remoteUpdates[i].OldHash = update.OldHash.String()
+		}
+	}
+
+	return remoteUpdates, nil
+}
+
+// refUpdateStatus returns the dtables.RemoteUpdate status of the ref update given, made in the mode given.
+func refUpdateStatus(ctx context.Context, ddb *doltdb.DoltDB, mode ref.RefUpdateMode, update actions.RefUpdate) (string, error) {
+	switch {
+	case update.OldHash.IsEmpty():
+		return dtables.RemoteUpdateCreated, nil
+	case update.OldHash == update.NewHash:
+		return dtables.RemoteUpdateUpToDate, nil
+	case mode == ref.FastForwardOnly:
+		return dtables.RemoteUpdateFastForward, nil
+	}
+
+	oldCm, err := resolveHash(ctx, ddb, update.OldHash)
+	if err != nil {
+		return "", err
+	}
+
+	newCm, err := resolveHash(ctx, ddb, update.NewHash)
+	if err != nil {
+		return "", err
+	}
+
+	canFF, err := oldCm.CanFastForwardTo(ctx, newCm)
+	if err == doltdb.ErrIsAhead || err == doltdb.ErrNoCommonAncestor {
+		return dtables.RemoteUpdateForced, nil
+	} else if err != nil {
+		return "", err
+	} else if !canFF {
+		return dtables.RemoteUpdateForced, nil
+	}
+
+	return dtables.RemoteUpdateFastForward, nil
+}
+
+func resolveHash(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(h.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return ddb.Resolve(ctx, cs, nil)
+}
+
+// countUpdated returns the number of the remote updates given which moved or created a ref, which is the result of
+// DOLT_FETCH, DOLT_PULL and DOLT_PUSH.
+func countUpdated(updates []dtables.RemoteUpdate) int64 {
+	var n int64
+	for _, update := range updates {
+		if update.Status != dtables.RemoteUpdateUpToDate {
+			n++
+		}
+	}
+
+	return n
+}
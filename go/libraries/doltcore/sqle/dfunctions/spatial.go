@@ -0,0 +1,225 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/geometry"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// SpatialFunctions are the ST_* functions for working with the values of spatial columns.
+var SpatialFunctions = []sql.Function{
+	newGeomFromTextFunc("st_geomfromtext", geometry.GeometryTypeName),
+	newGeomFromTextFunc("st_geometryfromtext", geometry.GeometryTypeName),
+	newGeomFromTextFunc("st_pointfromtext", geometry.PointTypeName),
+	newGeomFromTextFunc("st_linefromtext", geometry.LineStringTypeName),
+	newGeomFromTextFunc("st_linestringfromtext", geometry.LineStringTypeName),
+	newGeomFromTextFunc("st_polyfromtext", geometry.PolygonTypeName),
+	newGeomFromTextFunc("st_polygonfromtext", geometry.PolygonTypeName),
+	newGeomFromWKBFunc("st_geomfromwkb"),
+	newGeomFromWKBFunc("st_geometryfromwkb"),
+	newGeometryAccessor("st_astext", sql.LongText, func(g geometry.Geometry) (interface{}, error) {
+		return geometry.ToWKT(g), nil
+	}),
+	newGeometryAccessor("st_aswkt", sql.LongText, func(g geometry.Geometry) (interface{}, error) {
+		return geometry.ToWKT(g), nil
+	}),
+	newGeometryAccessor("st_asbinary", sql.LongBlob, func(g geometry.Geometry) (interface{}, error) {
+		return string(geometry.ToWKB(g)), nil
+	}),
+	newGeometryAccessor("st_aswkb", sql.LongBlob, func(g geometry.Geometry) (interface{}, error) {
+		return string(geometry.ToWKB(g)), nil
+	}),
+	newGeometryAccessor("st_asgeojson", sql.LongText, func(g geometry.Geometry) (interface{}, error) {
+		data, err := json.Marshal(geometry.ToGeoJSON(g))
+		return string(data), err
+	}),
+	newGeometryAccessor("st_geometrytype", sql.LongText, func(g geometry.Geometry) (interface{}, error) {
+		return g.GeometryType(), nil
+	}),
+	newGeometryAccessor("st_srid", sql.Uint32, func(g geometry.Geometry) (interface{}, error) {
+		return g.GetSRID(), nil
+	}),
+	newGeometryAccessor("st_x", sql.Float64, func(g geometry.Geometry) (interface{}, error) {
+		p, ok := g.(geometry.Point)
+		if !ok {
+			return nil, fmt.Errorf("ST_X expects a POINT but was given a %s", g.GeometryType())
+		}
+		return p.X, nil
+	}),
+	newGeometryAccessor("st_y", sql.Float64, func(g geometry.Geometry) (interface{}, error) {
+		p, ok := g.(geometry.Point)
+		if !ok {
+			return nil, fmt.Errorf("ST_Y expects a POINT but was given a %s", g.GeometryType())
+		}
+		return p.Y, nil
+	}),
+}
+
+func newGeomFromTextFunc(name, typeName string) sql.Function {
+	ti, _ := typeinfo.GeometryTypeFromName(typeName)
+	return newSpatialFunc(name, 1, 2, ti.ToSqlType(), func(vals []interface{}) (interface{}, error) {
+		text, err := sql.LongText.Convert(vals[0])
+		if err != nil {
+			return nil, err
+		}
+		srid, err := spatialSRIDArg(vals)
+		if err != nil {
+			return nil, err
+		}
+		g, err := geometry.FromWKT(text.(string), srid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GIS data provided to function %s: %v", name, err)
+		}
+		return ti.ToSqlType().Convert(g)
+	})
+}
+
+func newGeomFromWKBFunc(name string) sql.Function {
+	return newSpatialFunc(name, 1, 2, typeinfo.GeometryType.ToSqlType(), func(vals []interface{}) (interface{}, error) {
+		data, err := sql.LongBlob.Convert(vals[0])
+		if err != nil {
+			return nil, err
+		}
+		srid, err := spatialSRIDArg(vals)
+		if err != nil {
+			return nil, err
+		}
+		g, err := geometry.FromWKB([]byte(data.(string)), srid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GIS data provided to function %s: %v", name, err)
+		}
+		return g, nil
+	})
+}
+
+func newGeometryAccessor(name string, typ sql.Type, fn func(g geometry.Geometry) (interface{}, error)) sql.Function {
+	return newSpatialFunc(name, 1, 1, typ, func(vals []interface{}) (interface{}, error) {
+		g, err := typeinfo.GeometryType.ToSqlType().Convert(vals[0])
+		if err != nil {
+			return nil, err
+		}
+		return fn(g.(geometry.Geometry))
+	})
+}
+
+// newSpatialFunc returns a function that takes between minArgs and maxArgs arguments, and evaluates the non-NULL
+// values of its arguments with the function given.
+func newSpatialFunc(name string, minArgs, maxArgs int, typ sql.Type, eval func(vals []interface{}) (interface{}, error)) sql.Function {
+	// expressions share their definition, so that the analyzer sees copies of an expression as equal
+	def := &spatialFuncDef{name: name, typ: typ, eval: eval}
+	return sql.FunctionN{Name: name, Fn: func(args ...sql.Expression) (sql.Expression, error) {
+		if len(args) < minArgs || len(args) > maxArgs {
+			expected := fmt.Sprint(minArgs)
+			if minArgs != maxArgs {
+				expected = fmt.Sprintf("%d or %d", minArgs, maxArgs)
+			}
+			return nil, sql.ErrInvalidArgumentNumber.New(name, expected, len(args))
+		}
+		return &SpatialFunc{def: def, args: args}, nil
+	}}
+}
+
+// spatialSRIDArg returns the SRID given as the optional second argument of a function, or 0 if there is none.
+func spatialSRIDArg(vals []interface{}) (uint32, error) {
+	if len(vals) < 2 {
+		return 0, nil
+	}
+	srid, err := sql.Uint32.Convert(vals[1])
+	if err != nil {
+		return 0, err
+	}
+	return srid.(uint32), nil
+}
+
+// SpatialFunc is an ST_* function. It returns NULL when any of its arguments are NULL.
+type SpatialFunc struct {
+	def  *spatialFuncDef
+	args []sql.Expression
+}
+
+type spatialFuncDef struct {
+	name string
+	typ  sql.Type
+	eval func(vals []interface{}) (interface{}, error)
+}
+
+var _ sql.Expression = (*SpatialFunc)(nil)
+
+// Children implements the Expression interface.
+func (f *SpatialFunc) Children() []sql.Expression {
+	return f.args
+}
+
+// Eval implements the Expression interface.
+func (f *SpatialFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	vals := make([]interface{}, len(f.args))
+	for i, arg := range f.args {
+		val, err := arg.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		vals[i] = val
+	}
+	return f.def.eval(vals)
+}
+
+// IsNullable implements the Expression interface.
+func (f *SpatialFunc) IsNullable() bool {
+	return true
+}
+
+// Resolved implements the Expression interface.
+func (f *SpatialFunc) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface.
+func (f *SpatialFunc) String() string {
+	args := make([]string, len(f.args))
+	for i, arg := range f.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(f.def.name), strings.Join(args, ", "))
+}
+
+// Type implements the Expression interface.
+func (f *SpatialFunc) Type() sql.Type {
+	return f.def.typ
+}
+
+// WithChildren implements the Expression interface.
+func (f *SpatialFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(f.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), len(f.args))
+	}
+	nf := *f
+	nf.args = children
+	return &nf, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const verifyFuncName = "dolt_verify"
+
+// DoltVerifyFunc checks the data of the session's working root against the constraints of its tables, like dolt
+// verify. Returns the number of violations found, which are listed by the dolt_constraint_violations system table.
+type DoltVerifyFunc struct{}
+
+// NewDoltVerifyFunc creates a new DoltVerifyFunc expression.
+func NewDoltVerifyFunc() sql.Expression {
+	return DoltVerifyFunc{}
+}
+
+// Eval implements the Expression interface.
+func (vf DoltVerifyFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := sqle.DSessFromSess(ctx.Session)
+
+	working, ok := dSess.GetRoot(dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	violations, err := merge.VerifyRoot(ctx, working)
+	if err != nil {
+		return nil, err
+	}
+
+	return int64(len(violations)), nil
+}
+
+// Resolved implements the Expression interface.
+func (vf DoltVerifyFunc) Resolved() bool {
+	return true
+}
+
+// String implements the Stringer interface.
+func (vf DoltVerifyFunc) String() string {
+	return "DOLT_VERIFY()"
+}
+
+// IsNullable implements the Expression interface.
+func (vf DoltVerifyFunc) IsNullable() bool {
+	return false
+}
+
+// Children implements the Expression interface.
+func (vf DoltVerifyFunc) Children() []sql.Expression {
+	return nil
+}
+
+// WithChildren implements the Expression interface.
+func (vf DoltVerifyFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(vf, len(children), 0)
+	}
+	return NewDoltVerifyFunc(), nil
+}
+
+// Type implements the Expression interface.
+func (vf DoltVerifyFunc) Type() sql.Type {
+	return sql.Int64
+}
@@ -0,0 +1,39 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSummaryTable(t *testing.T) {
+	ts := newTestSession(t)
+	ts.query("CREATE TABLE a (pk INT PRIMARY KEY, c1 INT)")
+	ts.query("INSERT INTO a VALUES (1, 1), (2, 2), (3, 3)")
+
+	summaryQuery := "SELECT table_name, rows_added, rows_modified, rows_deleted, cells_modified, schema_changed FROM dolt_diff_summary WHERE from_commit = 'master' AND to_commit = 'working'"
+	assert.Equal(t, []sql.Row{{"a", uint64(3), uint64(0), uint64(0), uint64(0), true}}, ts.query(summaryQuery))
+
+	ts.commit("add a")
+	ts.query("UPDATE a SET c1 = 10 WHERE pk = 1")
+	ts.query("DELETE FROM a WHERE pk = 2")
+	ts.query("INSERT INTO a VALUES (4, 4)")
+	assert.Equal(t, []sql.Row{{"a", uint64(1), uint64(1), uint64(1), uint64(1), false}}, ts.query(summaryQuery))
+
+	assert.Error(t, ts.exec("SELECT * FROM dolt_diff_summary WHERE to_commit = 'working'"))
+}
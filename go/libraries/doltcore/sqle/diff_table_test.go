@@ -0,0 +1,71 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sqle
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTableKeyFilters(t *testing.T) {
+	ts := newTestSession(t)
+	ts.query("CREATE TABLE a (pk INT PRIMARY KEY, c1 INT)")
+	ts.query("INSERT INTO a VALUES (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6), (7, 7), (8, 8), (9, 9)")
+	ts.commit("add a")
+	ts.query("UPDATE a SET c1 = c1 * 10 WHERE pk % 2 = 0")
+	ts.query("DELETE FROM a WHERE pk IN (3, 7)")
+	ts.query("INSERT INTO a VALUES (10, 10), (11, 11)")
+	ts.commit("change a")
+	ts.query("UPDATE a SET c1 = 0 WHERE pk IN (5, 10)")
+
+	filters := []string{
+		"to_pk = 5",
+		"from_pk = 3",
+		"to_pk = 3",
+		"to_pk > 7",
+		"to_pk < 4",
+		"to_pk >= 4 AND to_pk < 9",
+		"from_pk <= 2 OR from_pk = 9",
+		"to_pk = 2 OR to_pk = 10",
+		"to_pk > 20",
+	}
+
+	for _, filter := range filters {
+		t.Run(filter, func(t *testing.T) {
+			cols := "to_pk, to_c1, from_pk, from_c1, diff_type, to_commit, from_commit"
+
+			// adding 0 to the key columns keeps the filter from being pushed down
+			unpushed := strings.NewReplacer("to_pk", "to_pk + 0", "from_pk", "from_pk + 0").Replace(filter)
+			expected := ts.query(fmt.Sprintf("SELECT %s FROM dolt_diff_a WHERE %s ORDER BY to_commit, to_pk, from_pk", cols, unpushed))
+			actual := ts.query(fmt.Sprintf("SELECT %s FROM dolt_diff_a WHERE %s ORDER BY to_commit, to_pk, from_pk", cols, filter))
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	assert.Equal(t, []sql.Row{{int32(5), int32(0), int32(5), int32(5)}}, ts.query("SELECT to_pk, to_c1, from_pk, from_c1 FROM dolt_diff_a WHERE to_pk = 5 AND to_commit = 'WORKING'"))
+
+	plan := ts.query("EXPLAIN SELECT * FROM dolt_diff_a WHERE to_pk = 5 AND to_commit = 'WORKING' AND to_c1 = 0")
+	var planStr strings.Builder
+	for _, r := range plan {
+		planStr.WriteString(r[0].(string))
+		planStr.WriteString("\n")
+	}
+	assert.Contains(t, planStr.String(), "Filtered table access on")
+	assert.Contains(t, planStr.String(), "dolt_diff_a.to_pk = 5")
+	assert.Contains(t, planStr.String(), "dolt_diff_a.to_commit")
+}
@@ -15,6 +15,7 @@
 package sqle
 
 import (
+	"context"
 	"errors"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -50,7 +51,7 @@ type doltIndex struct {
 	comment      string
 }
 
-//TODO: have queries using IS NULL make use of indexes
+// TODO: have queries using IS NULL make use of indexes
 var _ DoltIndex = (*doltIndex)(nil)
 
 // AscendGreaterOrEqual implements sql.AscendIndex
@@ -255,11 +256,11 @@ func (di *doltIndex) keysToTuple(keys []interface{}) (types.Tuple, error) {
 	}
 	var vals []types.Value
 	for i, col := range di.cols {
-		val, err := col.TypeInfo.ConvertValueToNomsValue(keys[i])
+		val, err := col.TypeInfo.ConvertValueToNomsValue(context.Background(), di.table.ValueReadWriter(), keys[i])
 		if err != nil {
 			return types.EmptyTuple(nbf), err
 		}
-		vals = append(vals, types.Uint(col.Tag), val)
+		vals = append(vals, types.Uint(col.Tag), schema.IndexKeyValue(col, val))
 	}
 	return types.NewTuple(nbf, vals...)
 }
@@ -17,22 +17,55 @@ package sqle
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
+// ErrSerializationFailure is returned when a transaction can't be committed because another transaction changed the
+// same rows of the database since it started.
+var ErrSerializationFailure = errors.NewKind("serialization failure: %s; try restarting transaction")
+
+// ErrUncommittedTransaction is returned when switching branches in a database the session's explicit transaction has
+// changed.
+var ErrUncommittedTransaction = errors.NewKind("database %s has changes in the current transaction; commit the transaction before switching branches")
+
+// ErrSavepointDoesNotExist is returned when rolling back to or releasing a savepoint which doesn't exist.
+var ErrSavepointDoesNotExist = errors.NewKind("SAVEPOINT %s does not exist")
+
 type dbRoot struct {
 	hashStr string
 	root    *doltdb.RootValue
 }
 
+// savepoint is a snapshot of the session's roots, which can be restored with ROLLBACK TO SAVEPOINT.
+type savepoint struct {
+	name  string
+	roots map[string]dbRoot
+}
+
 type dbData struct {
-	ddb *doltdb.DoltDB
-	rsw env.RepoStateWriter
+	ddb    *doltdb.DoltDB
+	rsr    env.RepoStateReader
+	rsw    env.RepoStateWriter
+	hooks  []CommitHook
+	txLock *sync.Mutex
+	tc     *tableCache
+	dEnv   *env.DoltEnv
+	// branches holds the states of the database's branches, which the session can switch to with SwitchBranch.
+	branches *branchStates
+	// readOnly is true if the session switched to a commit of the database with SwitchToCommit.
+	readOnly bool
 }
 
 var _ sql.Session = &DoltSession{}
@@ -44,6 +77,23 @@ type DoltSession struct {
 	dbDatas   map[string]dbData
 	dbEditors map[string]*doltdb.TableEditSession
 
+	// txRoots holds the working root of each database at the start of the session's current transaction, which is
+	// the base of the merge done when the transaction commits.
+	txRoots    map[string]dbRoot
+	explicitTx bool
+	savepoints []savepoint
+
+	// stagedRoots holds the root staged for the next commit to each database, if any tables have been staged.
+	stagedRoots map[string]*doltdb.RootValue
+	// mergeParents holds the commit merged into the working root of each database by a merge which has not yet been
+	// committed, which becomes the second parent of the next commit.
+	mergeParents map[string]*doltdb.Commit
+	// remoteUpdates holds the refs updated by the session's last fetch, pull or push of each database.
+	remoteUpdates map[string][]dtables.RemoteUpdate
+	// sqlModeSet is whether the session has set sql_mode, which can't be told from the value of sql_mode when it's set
+	// to the engine's default.
+	sqlModeSet bool
+
 	Username string
 	Email    string
 }
@@ -51,12 +101,16 @@ type DoltSession struct {
 // DefaultDoltSession creates a DoltSession object with default values
 func DefaultDoltSession() *DoltSession {
 	sess := &DoltSession{
-		Session:   sql.NewBaseSession(),
-		dbRoots:   make(map[string]dbRoot),
-		dbDatas:   make(map[string]dbData),
-		dbEditors: make(map[string]*doltdb.TableEditSession),
-		Username:  "",
-		Email:     "",
+		Session:       sql.NewBaseSession(),
+		dbRoots:       make(map[string]dbRoot),
+		dbDatas:       make(map[string]dbData),
+		dbEditors:     make(map[string]*doltdb.TableEditSession),
+		txRoots:       make(map[string]dbRoot),
+		stagedRoots:   make(map[string]*doltdb.RootValue),
+		mergeParents:  make(map[string]*doltdb.Commit),
+		remoteUpdates: make(map[string][]dtables.RemoteUpdate),
+		Username:      "",
+		Email:         "",
 	}
 	return sess
 }
@@ -67,11 +121,22 @@ func NewDoltSession(ctx context.Context, sqlSess sql.Session, username, email st
 	dbDatas := make(map[string]dbData)
 	dbEditors := make(map[string]*doltdb.TableEditSession)
 	for _, db := range dbs {
-		dbDatas[db.Name()] = dbData{rsw: db.rsw, ddb: db.ddb}
+		dbDatas[db.Name()] = dbData{rsr: db.rsr, rsw: db.rsw, ddb: db.ddb, hooks: db.hooks, txLock: db.txLock, tc: db.tc, dEnv: db.dEnv, branches: db.branches}
 		dbEditors[db.Name()] = doltdb.CreateTableEditSession(nil, doltdb.TableEditSessionProps{})
 	}
 
-	sess := &DoltSession{sqlSess, dbRoots, dbDatas, dbEditors, username, email}
+	sess := &DoltSession{
+		Session:       sqlSess,
+		dbRoots:       dbRoots,
+		dbDatas:       dbDatas,
+		dbEditors:     dbEditors,
+		txRoots:       make(map[string]dbRoot),
+		stagedRoots:   make(map[string]*doltdb.RootValue),
+		mergeParents:  make(map[string]*doltdb.Commit),
+		remoteUpdates: make(map[string][]dtables.RemoteUpdate),
+		Username:      username,
+		Email:         email,
+	}
 	for _, db := range dbs {
 		err := sess.AddDB(ctx, db)
 
@@ -88,26 +153,376 @@ func DSessFromSess(sess sql.Session) *DoltSession {
 	return sess.(*DoltSession)
 }
 
+// StartTransaction starts a new transaction in every database of the session which has no changes waiting to be
+// committed, loading the database's current working root. Databases with uncommitted changes keep their transaction,
+// so that their changes are not lost.
+func (sess *DoltSession) StartTransaction(ctx context.Context) error {
+	for dbName, dbd := range sess.dbDatas {
+		if dbd.rsr == nil {
+			continue
+		}
+
+		if txRoot, ok := sess.txRoots[dbName]; ok {
+			if currRoot, ok := sess.dbRoots[dbName]; ok && currRoot.hashStr != txRoot.hashStr {
+				continue
+			}
+		}
+
+		workingHash := dbd.rsr.WorkingHash()
+
+		if currRoot, ok := sess.dbRoots[dbName]; !ok || currRoot.hashStr != workingHash.String() {
+			root, err := dbd.ddb.ReadRootValue(ctx, workingHash)
+
+			if err != nil {
+				return err
+			}
+
+			err = sess.setRoot(ctx, dbName, root)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		sess.txRoots[dbName] = sess.dbRoots[dbName]
+	}
+
+	return nil
+}
+
+// BeginTransaction starts an explicit transaction, as done by START TRANSACTION or BEGIN. Any explicit transaction
+// already in progress is committed first. Changes made during an explicit transaction are not written to the working
+// set until EndTransaction is called.
+func (sess *DoltSession) BeginTransaction(ctx *sql.Context) error {
+	if sess.explicitTx {
+		err := sess.EndTransaction(ctx)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err := sess.StartTransaction(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	sess.explicitTx = true
+	sess.savepoints = nil
+	return nil
+}
+
+// EndTransaction ends the session's explicit transaction, as done by COMMIT, and commits it.
+func (sess *DoltSession) EndTransaction(ctx *sql.Context) error {
+	sess.explicitTx = false
+	return sess.CommitTransaction(ctx)
+}
+
+// InTransaction returns whether the session is in an explicit transaction.
+func (sess *DoltSession) InTransaction() bool {
+	return sess.explicitTx
+}
+
+// CommitTransaction writes the session's roots to the working sets of every database changed by the session's
+// transaction. If another session changed a working set since this session's transaction started, the two sets of
+// changes are merged, and ErrSerializationFailure is returned if they conflict, in which case no database is changed
+// and the transaction is rolled back. Changes made during an explicit transaction are only written when the
+// transaction ends.
 func (sess *DoltSession) CommitTransaction(ctx *sql.Context) error {
+	if sess.explicitTx {
+		return nil
+	}
+
+	sess.savepoints = nil
+
 	currentDb := sess.GetCurrentDatabase()
 	if currentDb == "" {
 		return sql.ErrNoDatabaseSelected.New()
 	}
 
-	dbRoot, ok := sess.dbRoots[currentDb]
-	if !ok {
+	if _, ok := sess.dbRoots[currentDb]; !ok {
 		return sql.ErrDatabaseNotFound.New(currentDb)
 	}
 
-	dbData := sess.dbDatas[currentDb]
+	dbNames := make([]string, 0, len(sess.dbRoots))
+	for dbName := range sess.dbRoots {
+		dbNames = append(dbNames, dbName)
+	}
+
+	// every database is locked, in a fixed order, so that the changes to all of them are committed together
+	sort.Strings(dbNames)
+	for _, dbName := range dbNames {
+		if _, inTx := sess.txRoots[dbName]; inTx && sess.dbDatas[dbName].txLock != nil {
+			txLock := sess.dbDatas[dbName].txLock
+			txLock.Lock()
+			defer txLock.Unlock()
+		}
+	}
+
+	roots := make(map[string]*doltdb.RootValue, len(dbNames))
+	for _, dbName := range dbNames {
+		root, err := sess.transactionRoot(ctx, dbName)
+		if err != nil {
+			if ErrSerializationFailure.Is(err) {
+				// roll back, so that the transaction is restarted from the latest working sets
+				rollbackErr := sess.RollbackTransaction(ctx)
+				if rollbackErr != nil {
+					return rollbackErr
+				}
+			}
+
+			return err
+		}
+
+		roots[dbName] = root
+	}
+
+	for _, dbName := range dbNames {
+		dbData := sess.dbDatas[dbName]
+		h, err := dbData.ddb.WriteRootValue(ctx, roots[dbName])
+		if err != nil {
+			return err
+		}
+
+		if h != dbData.rsr.WorkingHash() {
+			err = dbData.rsw.SetWorkingHash(ctx, h)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = sess.setRoot(ctx, dbName, roots[dbName])
+		if err != nil {
+			return err
+		}
+
+		if _, inTx := sess.txRoots[dbName]; inTx {
+			sess.txRoots[dbName] = sess.dbRoots[dbName]
+		}
+	}
+
+	return nil
+}
+
+// transactionRoot returns the root to write to the working set of the database given when the session's transaction
+// commits. This is the session's root merged with any changes committed by other sessions since the transaction
+// started, or the latest working root if the session hasn't changed the database. The database must be locked.
+func (sess *DoltSession) transactionRoot(ctx context.Context, dbName string) (*doltdb.RootValue, error) {
+	dbRoot := sess.dbRoots[dbName]
+	dbData := sess.dbDatas[dbName]
+
+	txRoot, inTx := sess.txRoots[dbName]
+	if !inTx || dbData.txLock == nil {
+		return dbRoot.root, nil
+	}
+
+	workingHash := dbData.rsr.WorkingHash()
+
+	switch {
+	case dbRoot.hashStr == txRoot.hashStr:
+		// nothing to commit, so catch up with any changes committed by other sessions
+		return dbData.ddb.ReadRootValue(ctx, workingHash)
+	case workingHash.String() != txRoot.hashStr:
+		workingRoot, err := dbData.ddb.ReadRootValue(ctx, workingHash)
+		if err != nil {
+			return nil, err
+		}
+
+		return mergeTransaction(ctx, dbRoot.root, workingRoot, txRoot.root)
+	default:
+		return dbRoot.root, nil
+	}
+}
+
+// RollbackTransaction discards the changes made by the session's current transaction in every database, and ends
+// the session's explicit transaction.
+func (sess *DoltSession) RollbackTransaction(ctx context.Context) error {
+	sess.explicitTx = false
+	sess.savepoints = nil
+
+	for dbName, txRoot := range sess.txRoots {
+		if currRoot, ok := sess.dbRoots[dbName]; ok && currRoot.hashStr == txRoot.hashStr {
+			continue
+		}
+
+		err := sess.rollbackRoot(ctx, dbName, txRoot)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollbackRoot sets the session's root for the database given back to the root given. Tables cached for the root are
+// discarded, since writes made since may have modified them.
+func (sess *DoltSession) rollbackRoot(ctx context.Context, dbName string, root dbRoot) error {
+	if tc := sess.dbDatas[dbName].tc; tc != nil {
+		tc.Clear(root.root)
+	}
+
+	return sess.setRoot(ctx, dbName, root.root)
+}
+
+// CreateSavepoint snapshots the session's root for every database under the name given, replacing any savepoint with
+// the same name. Pending table edits are flushed first, so that they are part of the snapshot.
+func (sess *DoltSession) CreateSavepoint(ctx context.Context, name string) error {
+	roots := make(map[string]dbRoot, len(sess.dbRoots))
+	for dbName, currRoot := range sess.dbRoots {
+		if editor, ok := sess.dbEditors[dbName]; ok {
+			flushed, err := editor.Flush(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			if flushed != nil && flushed != currRoot.root {
+				err = sess.setRoot(ctx, dbName, flushed)
+
+				if err != nil {
+					return err
+				}
+
+				currRoot = sess.dbRoots[dbName]
+			}
+		}
+
+		roots[dbName] = currRoot
+	}
+
+	if i := sess.savepointIndex(name); i >= 0 {
+		sess.savepoints = append(sess.savepoints[:i], sess.savepoints[i+1:]...)
+	}
+
+	sess.savepoints = append(sess.savepoints, savepoint{name, roots})
+	return nil
+}
+
+// RollbackToSavepoint restores the session's roots to the savepoint with the name given. Savepoints created after it
+// are removed, but the savepoint itself is kept.
+func (sess *DoltSession) RollbackToSavepoint(ctx context.Context, name string) error {
+	i := sess.savepointIndex(name)
+
+	if i < 0 {
+		return ErrSavepointDoesNotExist.New(name)
+	}
+
+	for dbName, spRoot := range sess.savepoints[i].roots {
+		if currRoot, ok := sess.dbRoots[dbName]; ok && currRoot.hashStr == spRoot.hashStr {
+			continue
+		}
+
+		err := sess.rollbackRoot(ctx, dbName, spRoot)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	sess.savepoints = sess.savepoints[:i+1]
+	return nil
+}
+
+// ReleaseSavepoint removes the savepoint with the name given, and every savepoint created after it, without changing
+// the session's roots.
+func (sess *DoltSession) ReleaseSavepoint(name string) error {
+	i := sess.savepointIndex(name)
+
+	if i < 0 {
+		return ErrSavepointDoesNotExist.New(name)
+	}
+
+	sess.savepoints = sess.savepoints[:i]
+	return nil
+}
+
+// savepointIndex returns the index of the savepoint with the name given, or -1 if there isn't one. Savepoint names
+// are case-insensitive.
+func (sess *DoltSession) savepointIndex(name string) int {
+	for i, sp := range sess.savepoints {
+		if strings.EqualFold(sp.name, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// mergeTransaction does a three-way merge of the root of a transaction with the working root of its database, given
+// the working root at the start of the transaction. Returns ErrSerializationFailure if the merge has conflicts, and
+// any other error of the merge as is.
+func mergeTransaction(ctx context.Context, txRoot, workingRoot, startRoot *doltdb.RootValue) (*doltdb.RootValue, error) {
+	mergedRoot, stats, err := merge.MergeRoots(ctx, txRoot, workingRoot, startRoot)
+
+	if merge.IsConflictError(err) {
+		return nil, ErrSerializationFailure.Wrap(err, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+
+	var conflicted []string
+	for tblName, tblStats := range stats {
+		if tblStats.Conflicts > 0 {
+			conflicted = append(conflicted, tblName)
+		}
+	}
+
+	if len(conflicted) > 0 {
+		sort.Strings(conflicted)
+		return nil, ErrSerializationFailure.New("conflicting changes were committed to table(s) " + strings.Join(conflicted, ", "))
+	}
+
+	if violated := merge.TablesWithCheckViolations(stats); len(violated) > 0 {
+		return nil, ErrSerializationFailure.New("committed changes violate check constraints on table(s) " + strings.Join(violated, ", "))
+	}
+
+	return mergedRoot, nil
+}
+
+// setRoot sets the session's root for the database given.
+func (sess *DoltSession) setRoot(ctx context.Context, dbName string, root *doltdb.RootValue) error {
+	h, err := root.HashOf()
 
-	root := dbRoot.root
-	h, err := dbData.ddb.WriteRootValue(ctx, root)
 	if err != nil {
 		return err
 	}
 
-	return dbData.rsw.SetWorkingHash(ctx, h)
+	hashStr := h.String()
+	err = sess.Session.Set(ctx, dbName+WorkingKeySuffix, hashType, hashStr)
+
+	if err != nil {
+		return err
+	}
+
+	sess.dbRoots[dbName] = dbRoot{hashStr, root}
+
+	if editor, ok := sess.dbEditors[dbName]; ok {
+		return editor.SetRoot(ctx, root)
+	}
+
+	return nil
+}
+
+// ExecuteCommitHooks runs the commit hooks of the database given for a branch which has been created or moved to the
+// commit given through SQL.
+func (sess *DoltSession) ExecuteCommitHooks(ctx context.Context, dbName string, branch ref.BranchRef, cm *doltdb.Commit) error {
+	dbd, ok := sess.dbDatas[dbName]
+
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	for _, hook := range dbd.hooks {
+		err := hook.Execute(ctx, dbName, branch, cm)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // GetDoltDB returns the *DoltDB for a given database by name
@@ -121,6 +536,18 @@ func (sess *DoltSession) GetDoltDB(dbName string) (*doltdb.DoltDB, bool) {
 	return d.ddb, true
 }
 
+// GetRemotesEnv returns the environment whose remotes the database given can fetch from, pull from and push to.
+// Returns false if the database is unknown or has no remotes available.
+func (sess *DoltSession) GetRemotesEnv(dbName string) (*env.DoltEnv, bool) {
+	d, ok := sess.dbDatas[dbName]
+
+	if !ok || d.dEnv == nil {
+		return nil, false
+	}
+
+	return d.dEnv, true
+}
+
 // GetRoot returns the current *RootValue for a given database associated with the session
 func (sess *DoltSession) GetRoot(dbName string) (*doltdb.RootValue, bool) {
 	dbRoot, ok := sess.dbRoots[dbName]
@@ -132,6 +559,263 @@ func (sess *DoltSession) GetRoot(dbName string) (*doltdb.RootValue, bool) {
 	return dbRoot.root, true
 }
 
+// GetHeadRef returns the branch the session is working on in the database given, which is the branch commits made
+// through the session are attributed to.
+func (sess *DoltSession) GetHeadRef(dbName string) (ref.DoltRef, bool) {
+	dbd, ok := sess.dbDatas[dbName]
+
+	if !ok || dbd.rsr == nil {
+		return nil, false
+	}
+
+	return dbd.rsr.CWBHeadRef(), true
+}
+
+// SwitchBranch switches the session's head for the database given to the branch given, and loads the branch's
+// working root. The working set of the branch is shared with every other session working on it, and changes to it are
+// committed to it like those to the branch checked out in the database's repo state. The working sets of other
+// branches than that one are kept in memory by the database, so they last only as long as it's served. Changes to the
+// current branch are committed to its working set first, unless they were made in an explicit transaction, in which
+// case an error is returned.
+func (sess *DoltSession) SwitchBranch(ctx *sql.Context, dbName string, branch ref.BranchRef) (*doltdb.Commit, error) {
+	dbd, ok := sess.dbDatas[dbName]
+
+	if !ok || dbd.rsr == nil {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	state, err := dbd.branches.get(ctx, branch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := dbd.ddb.ResolveRef(ctx, branch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cm, sess.switchHead(ctx, dbName, state, cm, false)
+}
+
+// DiscardBranchWorkingSet discards the working set kept for the branch given of the database given, such as when the
+// branch is deleted, so that a branch created later with the same name starts from its own head.
+func (sess *DoltSession) DiscardBranchWorkingSet(dbName string, branch ref.BranchRef) {
+	if dbd, ok := sess.dbDatas[dbName]; ok && dbd.branches != nil {
+		dbd.branches.remove(branch)
+	}
+}
+
+// SwitchToCommit switches the session's head for the database given to the commit given, whose root the session
+// reads but can't change. Changes to the current branch are committed to its working set first, as by SwitchBranch.
+func (sess *DoltSession) SwitchToCommit(ctx *sql.Context, dbName string, cs *doltdb.CommitSpec) (*doltdb.Commit, error) {
+	dbd, ok := sess.dbDatas[dbName]
+
+	if !ok || dbd.rsr == nil {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	cm, err := dbd.ddb.Resolve(ctx, cs, dbd.rsr.CWBHeadRef())
+
+	if err != nil {
+		return nil, err
+	}
+
+	cmHash, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rootHash, err := root.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cmSpec, err := doltdb.NewCommitSpec(cmHash.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	rs := env.NewMemoryRepoState(dbd.rsr.CWBHeadRef(), cmSpec, rootHash)
+	return cm, sess.switchHead(ctx, dbName, branchState{rsr: rs, rsw: rs}, cm, true)
+}
+
+// switchHead switches the session's head for the database given to the commit given, and its working root to the
+// working root of the state given, after committing any changes to the current one.
+func (sess *DoltSession) switchHead(ctx *sql.Context, dbName string, state branchState, cm *doltdb.Commit, readOnly bool) error {
+	var err error
+	txRoot, inTx := sess.txRoots[dbName]
+	if inTx && txRoot.hashStr != sess.dbRoots[dbName].hashStr {
+		if sess.explicitTx {
+			return ErrUncommittedTransaction.New(dbName)
+		}
+
+		err = sess.CommitTransaction(ctx)
+	} else if !inTx {
+		err = sess.SaveWorkingRoot(ctx, dbName)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	dbd := sess.dbDatas[dbName]
+	root, err := dbd.ddb.ReadRootValue(ctx, state.rsr.WorkingHash())
+
+	if err != nil {
+		return err
+	}
+
+	dbd.rsr, dbd.rsw, dbd.txLock, dbd.readOnly = state.rsr, state.rsw, state.txLock, readOnly
+	sess.dbDatas[dbName] = dbd
+	delete(sess.stagedRoots, dbName)
+	delete(sess.mergeParents, dbName)
+
+	err = sess.Set(ctx, dbName+HeadKeySuffix, sql.Text, h.String())
+
+	if err != nil {
+		return err
+	}
+
+	err = sess.setRoot(ctx, dbName, root)
+
+	if err != nil {
+		return err
+	}
+
+	if inTx {
+		sess.txRoots[dbName] = sess.dbRoots[dbName]
+	}
+
+	return nil
+}
+
+// SaveWorkingRoot writes the session's working root for the database given to the working set of the branch the
+// session is working on. Unlike CommitTransaction, changes made to the working set by other sessions aren't merged,
+// so this is only used outside of transactions.
+func (sess *DoltSession) SaveWorkingRoot(ctx context.Context, dbName string) error {
+	dbRoot, ok := sess.dbRoots[dbName]
+
+	if !ok {
+		return nil
+	}
+
+	dbd := sess.dbDatas[dbName]
+
+	if dbRoot.hashStr == dbd.rsr.WorkingHash().String() {
+		return nil
+	}
+
+	h, err := dbd.ddb.WriteRootValue(ctx, dbRoot.root)
+
+	if err != nil {
+		return err
+	}
+
+	return dbd.rsw.SetWorkingHash(ctx, h)
+}
+
+// GetStagedRoot returns the root staged for the next commit to the database given. Returns false if no tables have
+// been staged since the session's head was last set.
+func (sess *DoltSession) GetStagedRoot(dbName string) (*doltdb.RootValue, bool) {
+	root, ok := sess.stagedRoots[dbName]
+	return root, ok
+}
+
+// SetStagedRoot sets the root staged for the next commit to the database given.
+func (sess *DoltSession) SetStagedRoot(dbName string, root *doltdb.RootValue) {
+	sess.stagedRoots[dbName] = root
+}
+
+// GetMergeParent returns the commit merged into the session's working root for the database given, if the merge has
+// not yet been committed.
+func (sess *DoltSession) GetMergeParent(dbName string) (*doltdb.Commit, bool) {
+	cm, ok := sess.mergeParents[dbName]
+	return cm, ok
+}
+
+// SetMergeParent records that the commit given has been merged into the session's working root for the database
+// given. The commit becomes the second parent of the next commit made, and is forgotten when the session's head
+// changes.
+func (sess *DoltSession) SetMergeParent(dbName string, cm *doltdb.Commit) {
+	sess.mergeParents[dbName] = cm
+}
+
+// GetRemoteUpdates returns the refs updated by the session's last fetch, pull or push of the database given.
+func (sess *DoltSession) GetRemoteUpdates(dbName string) []dtables.RemoteUpdate {
+	return sess.remoteUpdates[dbName]
+}
+
+// SetRemoteUpdates records the refs updated by a fetch, pull or push of the database given, replacing those of the
+// last one.
+func (sess *DoltSession) SetRemoteUpdates(dbName string, updates []dtables.RemoteUpdate) {
+	sess.remoteUpdates[dbName] = updates
+}
+
+// SetWorkingRoot sets the session's working root for the database given.
+func (sess *DoltSession) SetWorkingRoot(ctx context.Context, dbName string, root *doltdb.RootValue) error {
+	if _, ok := sess.dbDatas[dbName]; !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	return sess.setRoot(ctx, dbName, root)
+}
+
+// HasUncommittedChanges returns whether the working root of the database given differs from the root of the
+// session's head commit.
+func (sess *DoltSession) HasUncommittedChanges(ctx context.Context, dbName string) (bool, error) {
+	dbRoot, ok := sess.dbRoots[dbName]
+
+	if !ok {
+		return false, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	parent, _, err := sess.GetParentCommit(ctx, dbName)
+
+	if err != nil {
+		return false, err
+	}
+
+	parentRoot, err := parent.GetRootValue()
+
+	if err != nil {
+		return false, err
+	}
+
+	parentHash, err := parentRoot.HashOf()
+
+	if err != nil {
+		return false, err
+	} else if parentHash.String() == dbRoot.hashStr {
+		return false, nil
+	}
+
+	// roots with the same tables may still differ, e.g. in the schemas they remember for dropped tables
+	added, modified, removed, err := dbRoot.root.TableDiff(ctx, parentRoot)
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(added)+len(modified)+len(removed) > 0, nil
+}
+
 // GetParentCommit returns the parent commit of the current session.
 func (sess *DoltSession) GetParentCommit(ctx context.Context, dbName string) (*doltdb.Commit, hash.Hash, error) {
 	dbd, dbFound := sess.dbDatas[dbName]
@@ -215,6 +899,8 @@ func (sess *DoltSession) Set(ctx context.Context, key string, typ sql.Type, valu
 		}
 
 		sess.dbRoots[dbName] = dbRoot{hashStr, root}
+		delete(sess.stagedRoots, dbName)
+		delete(sess.mergeParents, dbName)
 
 		err = sess.dbEditors[dbName].SetRoot(ctx, root)
 		if err != nil {
@@ -243,7 +929,12 @@ func (sess *DoltSession) Set(ctx context.Context, key string, typ sql.Type, valu
 		}
 	}
 
-	return sess.Session.Set(ctx, key, typ, value)
+	err := sess.Session.Set(ctx, key, typ, value)
+	if err == nil && strings.EqualFold(key, "sql_mode") {
+		sess.sqlModeSet = true
+	}
+
+	return err
 }
 
 func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
@@ -252,7 +943,7 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 	rsw := db.GetStateWriter()
 	ddb := db.GetDoltDB()
 
-	sess.dbDatas[db.Name()] = dbData{rsw: rsw, ddb: ddb}
+	sess.dbDatas[db.Name()] = dbData{rsr: rsr, rsw: rsw, ddb: ddb, hooks: db.CommitHooks(), txLock: db.txLock, tc: db.tc, dEnv: db.RemotesEnv(), branches: db.branches}
 
 	sess.dbEditors[db.Name()] = doltdb.CreateTableEditSession(nil, doltdb.TableEditSessionProps{})
 
@@ -0,0 +1,95 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+)
+
+// createTestEnvWithTable creates an environment whose working set has an empty table t.
+func createTestEnvWithTable(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	root, err = ExecuteSql(dEnv, root, "CREATE TABLE t (pk INT PRIMARY KEY, v INT)")
+	require.NoError(t, err)
+	require.NoError(t, dEnv.UpdateWorkingRoot(ctx, root))
+	return dEnv
+}
+
+func TestCommitTransactionMultipleDatabases(t *testing.T) {
+	ctx := context.Background()
+	dEnv1 := createTestEnvWithTable(t)
+	dEnv2 := createTestEnvWithTable(t)
+	db1 := NewDatabase("db1", dEnv1.DoltDB, dEnv1.RepoState, dEnv1.RepoStateWriter())
+	db2 := NewDatabase("db2", dEnv2.DoltDB, dEnv2.RepoState, dEnv2.RepoStateWriter())
+
+	engine := NewDefaultEngine()
+	engine.AddDatabase(db1)
+	engine.AddDatabase(db2)
+	sess, err := NewDoltSession(ctx, sql.NewBaseSession(), "Bill Billerson", "bill@example.com", db1, db2)
+	require.NoError(t, err)
+	sqlCtx := sql.NewContext(ctx, sql.WithSession(sess), sql.WithIndexRegistry(sql.NewIndexRegistry()), sql.WithViewRegistry(sql.NewViewRegistry())).WithCurrentDB("db1")
+
+	exec := func(query string) {
+		_, iter, err := engine.Query(sqlCtx, query)
+		require.NoError(t, err, query)
+		require.NoError(t, drainIter(iter), query)
+	}
+	count := func(dEnv *env.DoltEnv) []sql.Row {
+		root, err := dEnv.WorkingRoot(ctx)
+		require.NoError(t, err)
+		rows, err := ExecuteSelect(dEnv, dEnv.DoltDB, root, "SELECT COUNT(*) FROM t")
+		require.NoError(t, err)
+		return rows
+	}
+
+	// changes to every database are written, not only to the current one
+	require.NoError(t, sess.BeginTransaction(sqlCtx))
+	exec("INSERT INTO db1.t VALUES (1, 1)")
+	exec("INSERT INTO db2.t VALUES (1, 1), (2, 2)")
+	assert.Equal(t, []sql.Row{{int64(0)}}, count(dEnv2))
+	require.NoError(t, sess.EndTransaction(sqlCtx))
+	assert.Equal(t, []sql.Row{{int64(1)}}, count(dEnv1))
+	assert.Equal(t, []sql.Row{{int64(2)}}, count(dEnv2))
+
+	// a conflict in one database fails the transaction, and nothing is written to any database
+	require.NoError(t, sess.BeginTransaction(sqlCtx))
+	exec("INSERT INTO db1.t VALUES (2, 2)")
+	exec("INSERT INTO db2.t VALUES (3, 3)")
+	root, err := dEnv2.WorkingRoot(ctx)
+	require.NoError(t, err)
+	root, err = ExecuteSql(dEnv2, root, "INSERT INTO t VALUES (3, 4)")
+	require.NoError(t, err)
+	require.NoError(t, dEnv2.UpdateWorkingRoot(ctx, root))
+
+	err = sess.EndTransaction(sqlCtx)
+	assert.True(t, ErrSerializationFailure.Is(err), "unexpected error: %v", err)
+	assert.Equal(t, []sql.Row{{int64(1)}}, count(dEnv1))
+	sessRoot, ok := sess.GetRoot("db1")
+	require.True(t, ok)
+	rows, err := ExecuteSelect(dEnv1, dEnv1.DoltDB, sessRoot, "SELECT COUNT(*) FROM t")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int64(1)}}, rows)
+}
@@ -31,14 +31,18 @@ var _ sql.DeletableTable = (*BranchesTable)(nil)
 var _ sql.InsertableTable = (*BranchesTable)(nil)
 var _ sql.ReplaceableTable = (*BranchesTable)(nil)
 
+// BranchUpdateFunc is called after a branch is created or moved to the commit given.
+type BranchUpdateFunc func(ctx *sql.Context, branch ref.BranchRef, cm *doltdb.Commit) error
+
 // BranchesTable is a sql.Table implementation that implements a system table which shows the dolt branches
 type BranchesTable struct {
-	ddb *doltdb.DoltDB
+	ddb      *doltdb.DoltDB
+	onUpdate BranchUpdateFunc
 }
 
-// NewBranchesTable creates a BranchesTable
-func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB) sql.Table {
-	return &BranchesTable{ddb}
+// NewBranchesTable creates a BranchesTable. onUpdate is called for every branch written to the table, and may be nil.
+func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB, onUpdate BranchUpdateFunc) sql.Table {
+	return &BranchesTable{ddb, onUpdate}
 }
 
 // Name is a sql.Table interface function which returns the name of the table which is defined by the constant
@@ -214,7 +218,13 @@ func (bWr branchWriter) Insert(ctx *sql.Context, r sql.Row) error {
 	}
 
 	branchRef := ref.NewBranchRef(branchName)
-	return ddb.NewBranchAtCommit(ctx, branchRef, cm)
+	err = ddb.NewBranchAtCommit(ctx, branchRef, cm)
+
+	if err != nil || bWr.bt.onUpdate == nil {
+		return err
+	}
+
+	return bWr.bt.onUpdate(ctx, branchRef, cm)
 }
 
 // Update the given row. Provides both the old and new rows.
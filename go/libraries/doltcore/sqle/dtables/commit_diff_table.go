@@ -37,6 +37,9 @@ import (
 var ErrExactlyOneToCommit = errors.New("dolt_commit_diff_* tables must be filtered to a single 'to_commit'")
 var ErrExactlyOneFromCommit = errors.New("dolt_commit_diff_* tables must be filtered to a single 'from_commit'")
 
+var ErrExactlyOneToCommitFilter = errors.New("table must be filtered to a single 'to_commit'")
+var ErrExactlyOneFromCommitFilter = errors.New("table must be filtered to a single 'from_commit'")
+
 var _ sql.Table = (*CommitDiffTable)(nil)
 
 type CommitDiffTable struct {
@@ -256,6 +259,12 @@ func (dt *CommitDiffTable) Partitions(ctx *sql.Context) (sql.PartitionIter, erro
 }
 
 func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expression.Equals) (*doltdb.RootValue, string, *types.Timestamp, error) {
+	return rootForCommitFilter(ctx, dt.ddb, dt.workingRoot, eqFilter)
+}
+
+// rootForCommitFilter returns the root value of the commit an equality filter on a commit column is comparing to,
+// along with the commit's name and date. The name "working" refers to the working root given, which has no date.
+func rootForCommitFilter(ctx *sql.Context, ddb *doltdb.DoltDB, workingRoot *doltdb.RootValue, eqFilter *expression.Equals) (*doltdb.RootValue, string, *types.Timestamp, error) {
 	gf, nonGF := eqFilter.Left(), eqFilter.Right()
 	if _, ok := gf.(*expression.GetField); !ok {
 		nonGF, gf = eqFilter.Left(), eqFilter.Right()
@@ -276,7 +285,7 @@ func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expressi
 	var root *doltdb.RootValue
 	var commitTime *types.Timestamp
 	if strings.ToLower(hashStr) == "working" {
-		root = dt.workingRoot
+		root = workingRoot
 	} else {
 		cs, err := doltdb.NewCommitSpec(hashStr)
 
@@ -284,7 +293,7 @@ func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expressi
 			return nil, "", nil, err
 		}
 
-		cm, err := dt.ddb.Resolve(ctx, cs, nil)
+		cm, err := ddb.Resolve(ctx, cs, nil)
 
 		if err != nil {
 			return nil, "", nil, err
@@ -309,6 +318,88 @@ func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expressi
 	return root, hashStr, commitTime, nil
 }
 
+// commitRangeFilters handles the filters of a table which must be filtered to a single from_commit and a single
+// to_commit.
+type commitRangeFilters struct {
+	fromCommitFilter  *expression.Equals
+	toCommitFilter    *expression.Equals
+	requiredFilterErr error
+}
+
+// checkFilters returns an error if the table wasn't filtered to a single from_commit and a single to_commit
+func (cf *commitRangeFilters) checkFilters() error {
+	if cf.requiredFilterErr != nil {
+		return cf.requiredFilterErr
+	} else if cf.toCommitFilter == nil {
+		return ErrExactlyOneToCommitFilter
+	} else if cf.fromCommitFilter == nil {
+		return ErrExactlyOneFromCommitFilter
+	}
+
+	return nil
+}
+
+// roots returns the root values of the commits the table was filtered to, and the names of the commits.
+func (cf *commitRangeFilters) roots(ctx *sql.Context, ddb *doltdb.DoltDB, workingRoot *doltdb.RootValue) (fromRoot, toRoot *doltdb.RootValue, fromName, toName string, err error) {
+	fromRoot, fromName, _, err = rootForCommitFilter(ctx, ddb, workingRoot, cf.fromCommitFilter)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	toRoot, toName, _, err = rootForCommitFilter(ctx, ddb, workingRoot, cf.toCommitFilter)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	return fromRoot, toRoot, fromName, toName, nil
+}
+
+// HandledFilters returns the list of filters that will be handled by the table itself
+func (cf *commitRangeFilters) HandledFilters(filters []sql.Expression) []sql.Expression {
+	var commitFilters []sql.Expression
+	for _, filter := range filters {
+		eqFilter, isEquality := filter.(*expression.Equals)
+		if !isEquality {
+			continue
+		}
+
+		for _, e := range []sql.Expression{eqFilter.Left(), eqFilter.Right()} {
+			gf, ok := e.(*expression.GetField)
+			if !ok {
+				continue
+			}
+
+			switch strings.ToLower(gf.Name()) {
+			case toCommit:
+				if cf.toCommitFilter != nil {
+					cf.requiredFilterErr = ErrExactlyOneToCommitFilter
+				}
+
+				cf.toCommitFilter = eqFilter
+				commitFilters = append(commitFilters, filter)
+			case fromCommit:
+				if cf.fromCommitFilter != nil {
+					cf.requiredFilterErr = ErrExactlyOneFromCommitFilter
+				}
+
+				cf.fromCommitFilter = eqFilter
+				commitFilters = append(commitFilters, filter)
+			}
+		}
+	}
+
+	return commitFilters
+}
+
+// Filters returns the list of filters that are applied to this table.
+func (cf *commitRangeFilters) Filters() []sql.Expression {
+	if cf.toCommitFilter == nil || cf.fromCommitFilter == nil {
+		return nil
+	}
+
+	return []sql.Expression{cf.fromCommitFilter, cf.toCommitFilter}
+}
+
 // HandledFilters returns the list of filters that will be handled by the table itself
 func (dt *CommitDiffTable) HandledFilters(filters []sql.Expression) []sql.Expression {
 	var commitFilters []sql.Expression
@@ -362,5 +453,5 @@ func (dt *CommitDiffTable) WithFilters(filters []sql.Expression) sql.Table {
 
 func (dt *CommitDiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
 	dp := part.(diffPartition)
-	return dp.getRowIter(ctx, dt.ddb, dt.ss, dt.joiner)
+	return dp.getRowIter(ctx, dt.ddb, dt.ss, dt.joiner, nil, 0, nil)
 }
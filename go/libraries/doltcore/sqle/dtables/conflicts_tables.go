@@ -116,7 +116,7 @@ func (itr conflictRowIter) Next() (sql.Row, error) {
 		return nil, err
 	}
 
-	return sqlutil.DoltRowToSqlRow(cnf, itr.rd.GetSchema())
+	return sqlutil.DoltRowToSqlRow(itr.ctx, cnf, itr.rd.GetSchema())
 }
 
 // Close the iterator.
@@ -137,7 +137,7 @@ type conflictDeleter struct {
 // Close is called.
 func (cd *conflictDeleter) Delete(ctx *sql.Context, r sql.Row) error {
 	cnfSch := cd.ct.rd.GetSchema()
-	cnfRow, err := sqlutil.SqlRowToDoltRow(cd.ct.tbl.Format(), r, cnfSch)
+	cnfRow, err := sqlutil.SqlRowToDoltRow(ctx, cd.ct.tbl.ValueReadWriter(), r, cnfSch)
 
 	if err != nil {
 		return err
@@ -0,0 +1,79 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var _ sql.Table = (*ConstraintViolationsTable)(nil)
+
+// ConstraintViolationsTable is a sql.Table implementation that implements a system table which shows the data of the
+// working root that violates the constraints of its tables, like dolt verify
+type ConstraintViolationsTable struct {
+	root *doltdb.RootValue
+}
+
+// NewConstraintViolationsTable creates a ConstraintViolationsTable for the root given
+func NewConstraintViolationsTable(_ *sql.Context, root *doltdb.RootValue) sql.Table {
+	return &ConstraintViolationsTable{root: root}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ConstraintViolationsTableName
+func (cvt *ConstraintViolationsTable) Name() string {
+	return doltdb.ConstraintViolationsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ConstraintViolationsTableName
+func (cvt *ConstraintViolationsTable) String() string {
+	return doltdb.ConstraintViolationsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the constraint violations system table
+func (cvt *ConstraintViolationsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sql.Text, Source: doltdb.ConstraintViolationsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "violation_type", Type: sql.Text, Source: doltdb.ConstraintViolationsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "name", Type: sql.Text, Source: doltdb.ConstraintViolationsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "row_key", Type: sql.Text, Source: doltdb.ConstraintViolationsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "message", Type: sql.Text, Source: doltdb.ConstraintViolationsTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (cvt *ConstraintViolationsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (cvt *ConstraintViolationsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	violations, err := merge.VerifyRoot(ctx, cvt.root)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(violations))
+	for i, v := range violations {
+		rows[i] = sql.NewRow(v.Table, string(v.Type), v.Name, v.Key, v.Message)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dtables
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var _ sql.Table = (*DiffSummaryTable)(nil)
+var _ sql.FilteredTable = (*DiffSummaryTable)(nil)
+
+// DiffSummaryTable is a sql.Table implementation that implements a system table which summarizes the changes made to
+// each table between two commits, like dolt diff --summary. Queries must filter it to a single from_commit and a
+// single to_commit, either of which may be "working" to refer to the working root.
+type DiffSummaryTable struct {
+	commitRangeFilters
+	ddb         *doltdb.DoltDB
+	workingRoot *doltdb.RootValue
+}
+
+// NewDiffSummaryTable creates a DiffSummaryTable
+func NewDiffSummaryTable(_ *sql.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue) sql.Table {
+	return &DiffSummaryTable{ddb: ddb, workingRoot: root}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffSummaryTableName
+func (dt *DiffSummaryTable) Name() string {
+	return doltdb.DiffSummaryTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffSummaryTableName
+func (dt *DiffSummaryTable) String() string {
+	return doltdb.DiffSummaryTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the diff summary system table
+func (dt *DiffSummaryTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: fromCommit, Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true, Nullable: false},
+		{Name: toCommit, Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true, Nullable: false},
+		{Name: "table_name", Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true, Nullable: false},
+		{Name: "rows_added", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "rows_modified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "rows_deleted", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "cells_modified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "schema_changed", Type: sql.Boolean, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (dt *DiffSummaryTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	if err := dt.checkFilters(); err != nil {
+		return nil, fmt.Errorf("error querying table %s: %w", dt.Name(), err)
+	}
+
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt *DiffSummaryTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	fromRoot, toRoot, fromName, toName, err := dt.roots(ctx, dt.ddb, dt.workingRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	rows := make([]sql.Row, len(deltas))
+	for i, td := range deltas {
+		fromSch, toSch, err := td.GetSchemas(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		schemasEqual, err := schema.SchemasAreEqual(fromSch, toSch)
+		if err != nil {
+			return nil, err
+		}
+
+		fromMap, toMap, err := td.GetMaps(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		summary, err := diff.SummaryTotals(ctx, fromMap, toMap)
+		if err != nil {
+			return nil, err
+		}
+
+		rows[i] = sql.NewRow(fromName, toName, td.CurName(), summary.Adds, summary.Changes, summary.Removes, summary.CellChanges, !schemasEqual)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// WithFilters returns a new sql.Table instance with the filters applied
+func (dt *DiffSummaryTable) WithFilters(_ []sql.Expression) sql.Table {
+	return dt
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/parse"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
@@ -28,7 +30,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/setalgebra"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -66,7 +70,13 @@ type DiffTable struct {
 	joiner           *rowconv.Joiner
 	sqlSch           sql.Schema
 	partitionFilters []sql.Expression
+	pkFilters        []sql.Expression
 	rowFilters       []sql.Expression
+
+	// keyRanges limit the keys diffed to those which can satisfy the pkFilters. A nil slice diffs every key.
+	keyRanges    []*noms.ReadRange
+	pkTag        uint64
+	pkFilterFunc expreval.ExpressionFunc
 }
 
 func NewDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, root *doltdb.RootValue, head *doltdb.Commit) (sql.Table, error) {
@@ -164,21 +174,109 @@ func splitPartitionFilters(filters []sql.Expression) (commitFilters, rowFilters
 	return splitFilters(filters, getColumnFilterCheck(partitionFilterCols))
 }
 
+// splitFilters splits the filters given into the filters applied when enumerating commit pairs, the filters on the
+// to_ and from_ columns of the first primary key column which limit the keys diffed, and the remaining filters, which
+// are left to the engine.
+func (dt *DiffTable) splitFilters(filters []sql.Expression) {
+	var rest []sql.Expression
+	dt.partitionFilters, rest = splitPartitionFilters(filters)
+	dt.pkFilters, dt.rowFilters = nil, rest
+	dt.keyRanges = nil
+
+	sch, err := dt.ss.GenerateSchema()
+	if err != nil || sch.GetPKCols().Size() == 0 {
+		return
+	}
+
+	pkCol := sch.GetPKCols().GetByIndex(0)
+	toPkCol, fromPkCol := pkCol, pkCol
+	toPkCol.Name, fromPkCol.Name = toNamer(pkCol.Name), fromNamer(pkCol.Name)
+	pkColNames := set.NewStrSet([]string{strings.ToLower(toPkCol.Name), strings.ToLower(fromPkCol.Name)})
+
+	pkFilters, rowFilters := splitFilters(rest, func(filter sql.Expression) bool {
+		return getColumnFilterCheck(pkColNames)(filter) && isKeyRangeFilter(filter)
+	})
+
+	if len(pkFilters) == 0 {
+		return
+	}
+
+	nbf := dt.ddb.Format()
+	var keySet setalgebra.Set = setalgebra.UniversalSet{}
+	for _, filter := range pkFilters {
+		for _, col := range []schema.Column{toPkCol, fromPkCol} {
+			setForFilter, err := getSetForKeyColumn(nbf, col, filter)
+			if err != nil {
+				return
+			}
+
+			keySet, err = keySet.Intersect(setForFilter)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	keyRanges, err := rangesForKeySet(nbf, types.Uint(pkCol.Tag), keySet)
+	if err != nil {
+		return
+	}
+
+	pkFilterFunc, err := expreval.ExpressionFuncFromSQLExpressions(nbf, dt.joiner.GetSchema(), pkFilters)
+	if err != nil {
+		return
+	}
+
+	dt.pkFilters, dt.rowFilters = pkFilters, rowFilters
+	dt.keyRanges = keyRanges
+	dt.pkTag = pkCol.Tag
+	dt.pkFilterFunc = pkFilterFunc
+}
+
+// isKeyRangeFilter returns whether the filter given is made up only of comparisons of a column to a literal, joined
+// by AND and OR, which can be both converted to key ranges and evaluated exactly against diff rows.
+func isKeyRangeFilter(filter sql.Expression) bool {
+	switch e := filter.(type) {
+	case *expression.And:
+		return isKeyRangeFilter(e.Left) && isKeyRangeFilter(e.Right)
+	case *expression.Or:
+		return isKeyRangeFilter(e.Left) && isKeyRangeFilter(e.Right)
+	case *expression.Equals:
+		return isColumnLiteralComparison(e.BinaryExpression)
+	case *expression.GreaterThan:
+		return isColumnLiteralComparison(e.BinaryExpression)
+	case *expression.GreaterThanOrEqual:
+		return isColumnLiteralComparison(e.BinaryExpression)
+	case *expression.LessThan:
+		return isColumnLiteralComparison(e.BinaryExpression)
+	case *expression.LessThanOrEqual:
+		return isColumnLiteralComparison(e.BinaryExpression)
+	}
+
+	return false
+}
+
+func isColumnLiteralComparison(be expression.BinaryExpression) bool {
+	_, isField := be.Left.(*expression.GetField)
+	_, isLiteral := be.Right.(*expression.Literal)
+	return isField && isLiteral
+}
+
 // HandledFilters returns the list of filters that will be handled by the table itself
 func (dt *DiffTable) HandledFilters(filters []sql.Expression) []sql.Expression {
-	dt.partitionFilters, dt.rowFilters = splitPartitionFilters(filters)
-	return dt.partitionFilters
+	dt.splitFilters(filters)
+	return dt.Filters()
 }
 
 // Filters returns the list of filters that are applied to this table.
 func (dt *DiffTable) Filters() []sql.Expression {
-	return dt.partitionFilters
+	return append(append([]sql.Expression{}, dt.partitionFilters...), dt.pkFilters...)
 }
 
 // WithFilters returns a new sql.Table instance with the filters applied
 func (dt *DiffTable) WithFilters(filters []sql.Expression) sql.Table {
 	if dt.partitionFilters == nil {
-		dt.partitionFilters, dt.rowFilters = splitPartitionFilters(filters)
+		dt.splitFilters(filters)
 	}
 
 	return dt
@@ -186,7 +284,7 @@ func (dt *DiffTable) WithFilters(filters []sql.Expression) sql.Table {
 
 func (dt *DiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
 	dp := part.(diffPartition)
-	return dp.getRowIter(ctx, dt.ddb, dt.ss, dt.joiner)
+	return dp.getRowIter(ctx, dt.ddb, dt.ss, dt.joiner, dt.keyRanges, dt.pkTag, dt.pkFilterFunc)
 }
 
 func tableData(ctx *sql.Context, tbl *doltdb.Table, ddb *doltdb.DoltDB) (types.Map, schema.Schema, error) {
@@ -219,12 +317,14 @@ func tableData(ctx *sql.Context, tbl *doltdb.Table, ddb *doltdb.DoltDB) (types.M
 var _ sql.RowIter = (*diffRowItr)(nil)
 
 type diffRowItr struct {
+	ctx            context.Context
 	ad             *diff.AsyncDiffer
 	diffSrc        *diff.RowDiffSource
 	joiner         *rowconv.Joiner
 	sch            schema.Schema
 	fromCommitInfo commitInfo
 	toCommitInfo   commitInfo
+	filter         expreval.ExpressionFunc
 }
 
 type commitInfo struct {
@@ -234,19 +334,25 @@ type commitInfo struct {
 	dateTag uint64
 }
 
-func newDiffRowItr(ctx context.Context, joiner *rowconv.Joiner, rowDataFrom, rowDataTo types.Map, convFrom, convTo *rowconv.RowConverter, from, to commitInfo) *diffRowItr {
+// newDiffRowItr returns an iterator over the diffs between the maps given. If keyRanges is non-nil only the keys within
+// those ranges are diffed, and if filter is non-nil only the diffs that satisfy it are returned.
+func newDiffRowItr(ctx context.Context, joiner *rowconv.Joiner, rowDataFrom, rowDataTo types.Map, convFrom, convTo *rowconv.RowConverter, from, to commitInfo, keyRanges []*noms.ReadRange, filter expreval.ExpressionFunc) *diffRowItr {
 	ad := diff.NewAsyncDiffer(1024)
-	ad.Start(ctx, rowDataFrom, rowDataTo)
+	if keyRanges != nil {
+		ad.StartInRanges(ctx, rowDataFrom, rowDataTo, keyRanges)
+	} else {
+		ad.Start(ctx, rowDataFrom, rowDataTo)
+	}
 
 	src := diff.NewRowDiffSource(ad, joiner)
 	src.AddInputRowConversion(convFrom, convTo)
 
-	return &diffRowItr{ad, src, joiner, joiner.GetSchema(), from, to}
+	return &diffRowItr{ctx, ad, src, joiner, joiner.GetSchema(), from, to, filter}
 }
 
 // Next returns the next row
 func (itr *diffRowItr) Next() (sql.Row, error) {
-	r, _, err := itr.diffSrc.NextDiff()
+	r, err := itr.nextDiff()
 
 	if err != nil {
 		return nil, err
@@ -284,7 +390,7 @@ func (itr *diffRowItr) Next() (sql.Row, error) {
 		}
 	}
 
-	sqlRow, err := sqlutil.DoltRowToSqlRow(r, itr.sch)
+	sqlRow, err := sqlutil.DoltRowToSqlRow(itr.ctx, r, itr.sch)
 
 	if err != nil {
 		return nil, err
@@ -301,6 +407,37 @@ func (itr *diffRowItr) Next() (sql.Row, error) {
 	return sqlRow, nil
 }
 
+// nextDiff returns the next joined row of the diff which satisfies the iterator's filter
+func (itr *diffRowItr) nextDiff() (row.Row, error) {
+	for {
+		r, _, err := itr.diffSrc.NextDiff()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if itr.filter == nil {
+			return r, nil
+		}
+
+		vals, err := row.GetTaggedVals(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		matches, err := itr.filter(itr.ctx, vals)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if matches {
+			return r, nil
+		}
+	}
+}
+
 // Close closes the iterator
 func (itr *diffRowItr) Close() (err error) {
 	defer itr.ad.Close()
@@ -336,7 +473,7 @@ func (dp diffPartition) Key() []byte {
 	return []byte(dp.toName + dp.fromName)
 }
 
-func (dp diffPartition) getRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, ss *schema.SuperSchema, joiner *rowconv.Joiner) (sql.RowIter, error) {
+func (dp diffPartition) getRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, ss *schema.SuperSchema, joiner *rowconv.Joiner, keyRanges []*noms.ReadRange, pkTag uint64, filter expreval.ExpressionFunc) (sql.RowIter, error) {
 	fromData, fromSch, err := tableData(ctx, dp.from, ddb)
 
 	if err != nil {
@@ -349,13 +486,13 @@ func (dp diffPartition) getRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, ss *sch
 		return nil, err
 	}
 
-	fromConv, err := rowConvForSchema(ss, fromSch)
+	fromConv, err := rowConvForSchema(ctx, ddb.ValueReadWriter(), ss, fromSch)
 
 	if err != nil {
 		return nil, err
 	}
 
-	toConv, err := rowConvForSchema(ss, toSch)
+	toConv, err := rowConvForSchema(ctx, ddb.ValueReadWriter(), ss, toSch)
 
 	if err != nil {
 		return nil, err
@@ -370,6 +507,12 @@ func (dp diffPartition) getRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, ss *sch
 	fromCmInfo := commitInfo{types.String(dp.fromName), dp.fromDate, fromCol.Tag, fromDateCol.Tag}
 	toCmInfo := commitInfo{types.String(dp.toName), dp.toDate, toCol.Tag, toDateCol.Tag}
 
+	// key ranges are built from the tag of the first primary key column, so they can only be used when it's the first
+	// primary key column on both sides of the diff
+	if !keyedByTag(fromSch, pkTag) || !keyedByTag(toSch, pkTag) {
+		keyRanges = nil
+	}
+
 	return newDiffRowItr(
 		ctx,
 		joiner,
@@ -379,9 +522,22 @@ func (dp diffPartition) getRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, ss *sch
 		toConv,
 		fromCmInfo,
 		toCmInfo,
+		keyRanges,
+		filter,
 	), nil
 }
 
+// keyedByTag returns whether the first primary key column of the schema given has the tag given. The empty schema of
+// a table missing on one side of a diff has no rows, so it's considered keyed by any tag.
+func keyedByTag(sch schema.Schema, tag uint64) bool {
+	pkCols := sch.GetPKCols()
+	if pkCols.Size() == 0 {
+		return sch.GetAllCols().Size() == 0
+	}
+
+	return pkCols.GetByIndex(0).Tag == tag
+}
+
 type partitionSelectFunc func(*sql.Context, diffPartition) (bool, error)
 
 func selectFuncForFilters(nbf *types.NomsBinFormat, filters []sql.Expression) (partitionSelectFunc, error) {
@@ -564,7 +720,7 @@ func (dp *diffPartitions) Close() error {
 }
 
 // creates a RowConverter for transforming rows with the the given schema to this super schema.
-func rowConvForSchema(ss *schema.SuperSchema, sch schema.Schema) (*rowconv.RowConverter, error) {
+func rowConvForSchema(ctx context.Context, vrw types.ValueReadWriter, ss *schema.SuperSchema, sch schema.Schema) (*rowconv.RowConverter, error) {
 	eq, err := schema.SchemasAreEqual(sch, schema.EmptySchema)
 	if err != nil {
 		return nil, err
@@ -591,5 +747,5 @@ func rowConvForSchema(ss *schema.SuperSchema, sch schema.Schema) (*rowconv.RowCo
 		return nil, err
 	}
 
-	return rowconv.NewRowConverter(fm)
+	return rowconv.NewRowConverter(ctx, vrw, fm)
 }
@@ -324,6 +324,50 @@ func rangesForFiniteSetOfPartialKeys(nbf *types.NomsBinFormat, tag types.Uint, f
 	return ranges, nil
 }
 
+// rangesForKeySet converts a set of values of the first primary key column with the tag given into the noms.ReadRanges
+// which read the rows with those values. A nil slice is returned for the universal set, which can't be limited to any
+// ranges.
+func rangesForKeySet(nbf *types.NomsBinFormat, tag types.Uint, keySet setalgebra.Set) ([]*noms.ReadRange, error) {
+	switch typedSet := keySet.(type) {
+	case setalgebra.EmptySet:
+		return []*noms.ReadRange{}, nil
+
+	case setalgebra.FiniteSet:
+		return rangesForFiniteSetOfPartialKeys(nbf, tag, typedSet)
+
+	case setalgebra.Interval:
+		r, err := rangeForInterval(nbf, tag, typedSet)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*noms.ReadRange{r}, nil
+
+	case setalgebra.CompositeSet:
+		ranges := make([]*noms.ReadRange, 0, len(typedSet.Intervals))
+		for _, interval := range typedSet.Intervals {
+			r, err := rangeForInterval(nbf, tag, interval)
+
+			if err != nil {
+				return nil, err
+			}
+
+			ranges = append(ranges, r)
+		}
+
+		rangesForFS, err := rangesForFiniteSetOfPartialKeys(nbf, tag, typedSet.Set)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return append(ranges, rangesForFS...), nil
+	}
+
+	return nil, nil
+}
+
 // rangeForInterval converts a setalgebra.Interval into a noms.ReadRange for reading the values within the interval
 // from a types.Map
 func rangeForInterval(nbf *types.NomsBinFormat, tag types.Uint, in setalgebra.Interval) (*noms.ReadRange, error) {
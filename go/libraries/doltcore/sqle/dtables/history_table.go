@@ -341,7 +341,7 @@ func newRowItrForTableAtCommit(
 		return nil, err
 	}
 
-	toSuperSchConv, err := rowConvForSchema(ss, tblSch)
+	toSuperSchConv, err := rowConvForSchema(ctx, root.VRW(), ss, tblSch)
 
 	if err != nil {
 		return nil, err
@@ -420,7 +420,7 @@ func (tblItr *rowItrForTableAtCommit) Next() (sql.Row, error) {
 		}
 	}
 
-	return sqlutil.DoltRowToSqlRow(r, tblItr.sch)
+	return sqlutil.DoltRowToSqlRow(tblItr.ctx, r, tblItr.sch)
 }
 
 // Close the iterator.
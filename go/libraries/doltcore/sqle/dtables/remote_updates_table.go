@@ -0,0 +1,106 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var _ sql.Table = (*RemoteUpdatesTable)(nil)
+
+const (
+	// RemoteUpdateCreated is the status of a ref which was created
+	RemoteUpdateCreated = "created"
+	// RemoteUpdateFastForward is the status of a ref which was fast forwarded to a descendant of its old commit
+	RemoteUpdateFastForward = "fast-forward"
+	// RemoteUpdateForced is the status of a ref which was overwritten with a commit which isn't a descendant of its
+	// old commit
+	RemoteUpdateForced = "forced"
+	// RemoteUpdateMerged is the status of a branch whose remote tracking branch was merged into the working set
+	RemoteUpdateMerged = "merged"
+	// RemoteUpdateConflicts is the status of a branch whose remote tracking branch was merged into the working set
+	// with conflicts, which can be found in dolt_conflicts
+	RemoteUpdateConflicts = "conflicts"
+	// RemoteUpdateUpToDate is the status of a ref which was already at the commit it would have been updated to
+	RemoteUpdateUpToDate = "up to date"
+)
+
+// RemoteUpdate describes a ref updated by a session's last DOLT_FETCH, DOLT_PULL or DOLT_PUSH.
+type RemoteUpdate struct {
+	// Ref is the path of the ref updated, e.g. origin/master for a remote tracking branch
+	Ref string
+	// OldHash is the hash of the commit the ref was at before the update, or the empty string if it was created
+	OldHash string
+	// NewHash is the hash of the commit the ref is at after the update
+	NewHash string
+	// Status is one of the RemoteUpdate statuses
+	Status string
+}
+
+// RemoteUpdatesTable is a sql.Table implementation that implements a system table which shows the refs updated by the
+// session's last DOLT_FETCH, DOLT_PULL or DOLT_PUSH
+type RemoteUpdatesTable struct {
+	updates []RemoteUpdate
+}
+
+// NewRemoteUpdatesTable creates a RemoteUpdatesTable
+func NewRemoteUpdatesTable(_ *sql.Context, updates []RemoteUpdate) sql.Table {
+	return &RemoteUpdatesTable{updates}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemoteUpdatesTableName
+func (rt *RemoteUpdatesTable) Name() string {
+	return doltdb.RemoteUpdatesTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemoteUpdatesTableName
+func (rt *RemoteUpdatesTable) String() string {
+	return doltdb.RemoteUpdatesTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the remote updates system table
+func (rt *RemoteUpdatesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "ref", Type: sql.Text, Source: doltdb.RemoteUpdatesTableName, PrimaryKey: true, Nullable: false},
+		{Name: "old_hash", Type: sql.Text, Source: doltdb.RemoteUpdatesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "new_hash", Type: sql.Text, Source: doltdb.RemoteUpdatesTableName, PrimaryKey: false, Nullable: false},
+		{Name: "status", Type: sql.Text, Source: doltdb.RemoteUpdatesTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (rt *RemoteUpdatesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (rt *RemoteUpdatesTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	rows := make([]sql.Row, len(rt.updates))
+	for i, update := range rt.updates {
+		var oldHash interface{}
+		if update.OldHash != "" {
+			oldHash = update.OldHash
+		}
+
+		rows[i] = sql.NewRow(update.Ref, oldHash, update.NewHash, update.Status)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var _ sql.Table = (*ReplicationStatusTable)(nil)
+
+const (
+	// ReplicationUpToDate is the state of a branch whose commits have all been replicated
+	ReplicationUpToDate = "up to date"
+	// ReplicationPending is the state of a branch with commits waiting to be replicated, or being retried
+	ReplicationPending = "pending"
+	// ReplicationFailed is the state of a branch whose latest commit could not be replicated, and is no longer retried
+	ReplicationFailed = "failed"
+)
+
+// ReplicationStatus describes the progress of replicating the commits made to a branch to a remote.
+type ReplicationStatus struct {
+	// Remote is the name of the remote commits are replicated to
+	Remote string
+	// Branch is the name of the branch whose commits are replicated
+	Branch string
+	// LastPushedCommit is the hash of the last commit successfully pushed, or the empty string if none has been
+	LastPushedCommit string
+	// LastPushedAt is the time of the last successful push, or the zero time if none has been
+	LastPushedAt time.Time
+	// Lag is the number of commits made which have not yet been pushed
+	Lag uint64
+	// LastError is the error of the last failed push, or the empty string if the last push succeeded
+	LastError string
+	// State is one of ReplicationUpToDate, ReplicationPending or ReplicationFailed
+	State string
+}
+
+// ReplicationStatusTable is a sql.Table implementation that implements a system table which shows the progress of
+// replicating commits to remotes
+type ReplicationStatusTable struct {
+	statuses []ReplicationStatus
+}
+
+// NewReplicationStatusTable creates a ReplicationStatusTable
+func NewReplicationStatusTable(_ *sql.Context, statuses []ReplicationStatus) sql.Table {
+	return &ReplicationStatusTable{statuses}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ReplicationStatusTableName
+func (rt *ReplicationStatusTable) Name() string {
+	return doltdb.ReplicationStatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ReplicationStatusTableName
+func (rt *ReplicationStatusTable) String() string {
+	return doltdb.ReplicationStatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the replication status system table
+func (rt *ReplicationStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "remote", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "branch", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "last_pushed_commit", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "last_pushed_at", Type: sql.Datetime, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "lag", Type: sql.Uint64, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "last_error", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "state", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (rt *ReplicationStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (rt *ReplicationStatusTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	rows := make([]sql.Row, len(rt.statuses))
+	for i, status := range rt.statuses {
+		var lastPushedCommit, lastPushedAt, lastError interface{}
+		if status.LastPushedCommit != "" {
+			lastPushedCommit = status.LastPushedCommit
+		}
+
+		if !status.LastPushedAt.IsZero() {
+			lastPushedAt = status.LastPushedAt
+		}
+
+		if status.LastError != "" {
+			lastError = status.LastError
+		}
+
+		rows[i] = sql.NewRow(status.Remote, status.Branch, lastPushedCommit, lastPushedAt, status.Lag, lastError, status.State)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
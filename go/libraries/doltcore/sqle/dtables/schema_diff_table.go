@@ -0,0 +1,246 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dtables
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+const (
+	schemaObjectTable      = "table"
+	schemaObjectColumn     = "column"
+	schemaObjectIndex      = "index"
+	schemaObjectForeignKey = "foreign key"
+
+	schemaDiffAdded    = "added"
+	schemaDiffDropped  = "dropped"
+	schemaDiffModified = "modified"
+	schemaDiffRenamed  = "renamed"
+)
+
+var _ sql.Table = (*SchemaDiffTable)(nil)
+var _ sql.FilteredTable = (*SchemaDiffTable)(nil)
+
+// SchemaDiffTable is a sql.Table implementation that implements a system table which shows the tables, columns,
+// indexes and foreign keys added, dropped or modified between two commits. Each changed table has a row holding its
+// CREATE TABLE statements, followed by a row for each of its columns, indexes and foreign keys that changed. Queries
+// must filter it to a single from_commit and a single to_commit, either of which may be "working" to refer to the
+// working root.
+type SchemaDiffTable struct {
+	commitRangeFilters
+	ddb         *doltdb.DoltDB
+	workingRoot *doltdb.RootValue
+	createStmt  CreateTableStmtFunc
+}
+
+// NewSchemaDiffTable creates a SchemaDiffTable
+func NewSchemaDiffTable(_ *sql.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, createStmt CreateTableStmtFunc) sql.Table {
+	return &SchemaDiffTable{ddb: ddb, workingRoot: root, createStmt: createStmt}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaDiffTableName
+func (st *SchemaDiffTable) Name() string {
+	return doltdb.SchemaDiffTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaDiffTableName
+func (st *SchemaDiffTable) String() string {
+	return doltdb.SchemaDiffTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema diff system table
+func (st *SchemaDiffTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: fromCommit, Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: toCommit, Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "table_name", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "object_type", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "object_name", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "diff_type", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: false, Nullable: false},
+		{Name: "from_definition", Type: sql.LongText, Source: doltdb.SchemaDiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: "to_definition", Type: sql.LongText, Source: doltdb.SchemaDiffTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (st *SchemaDiffTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	if err := st.checkFilters(); err != nil {
+		return nil, fmt.Errorf("error querying table %s: %w", st.Name(), err)
+	}
+
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *SchemaDiffTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	fromRoot, toRoot, fromName, toName, err := st.roots(ctx, st.ddb, st.workingRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	var rows []sql.Row
+	for _, td := range deltas {
+		if doltdb.HasDoltPrefix(td.CurName()) {
+			continue
+		}
+
+		changes, err := schemaChanges(ctx, fromRoot, td)
+		if err != nil {
+			return nil, err
+		}
+
+		var diffType string
+		switch {
+		case td.IsAdd():
+			diffType = schemaDiffAdded
+		case td.IsDrop():
+			diffType = schemaDiffDropped
+		case td.IsRename():
+			diffType = schemaDiffRenamed
+		case len(changes) > 0:
+			diffType = schemaDiffModified
+		default:
+			// only the table's data changed
+			continue
+		}
+
+		var fromStmt, toStmt interface{}
+		if td.FromTable != nil {
+			if fromStmt, err = st.createStmt(ctx, fromRoot, td.FromName); err != nil {
+				return nil, err
+			}
+		}
+
+		if td.ToTable != nil {
+			if toStmt, err = st.createStmt(ctx, toRoot, td.ToName); err != nil {
+				return nil, err
+			}
+		}
+
+		rows = append(rows, sql.NewRow(fromName, toName, td.CurName(), schemaObjectTable, td.CurName(), diffType, fromStmt, toStmt))
+		for _, change := range changes {
+			rows = append(rows, append(sql.NewRow(fromName, toName, td.CurName()), change...))
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// schemaChanges returns the object_type, object_name, diff_type, from_definition and to_definition of each column,
+// index and foreign key of a table changed between two roots. No changes are returned for added or dropped tables.
+func schemaChanges(ctx context.Context, fromRoot *doltdb.RootValue, td diff.TableDelta) ([]sql.Row, error) {
+	if td.IsAdd() || td.IsDrop() {
+		return nil, nil
+	}
+
+	fromSch, toSch, err := td.GetSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []sql.Row
+	colDiffs, tags := diff.DiffSchColumns(fromSch, toSch)
+	for _, tag := range tags {
+		cd := colDiffs[tag]
+		switch cd.DiffType {
+		case diff.SchDiffAdded:
+			changes = append(changes, sql.NewRow(schemaObjectColumn, cd.New.Name, schemaDiffAdded, nil, sqlfmt.FmtCol(0, 0, 0, *cd.New)))
+		case diff.SchDiffRemoved:
+			changes = append(changes, sql.NewRow(schemaObjectColumn, cd.Old.Name, schemaDiffDropped, sqlfmt.FmtCol(0, 0, 0, *cd.Old), nil))
+		case diff.SchDiffModified:
+			changes = append(changes, sql.NewRow(schemaObjectColumn, cd.New.Name, schemaDiffModified, sqlfmt.FmtCol(0, 0, 0, *cd.Old), sqlfmt.FmtCol(0, 0, 0, *cd.New)))
+		}
+	}
+
+	for _, idxDiff := range diff.DiffSchIndexes(fromSch, toSch) {
+		switch idxDiff.DiffType {
+		case diff.SchDiffAdded:
+			changes = append(changes, sql.NewRow(schemaObjectIndex, idxDiff.To.Name(), schemaDiffAdded, nil, sqlfmt.FmtIndex(idxDiff.To)))
+		case diff.SchDiffRemoved:
+			changes = append(changes, sql.NewRow(schemaObjectIndex, idxDiff.From.Name(), schemaDiffDropped, sqlfmt.FmtIndex(idxDiff.From), nil))
+		case diff.SchDiffModified:
+			changes = append(changes, sql.NewRow(schemaObjectIndex, idxDiff.To.Name(), schemaDiffModified, sqlfmt.FmtIndex(idxDiff.From), sqlfmt.FmtIndex(idxDiff.To)))
+		}
+	}
+
+	for _, fkDiff := range diff.DiffForeignKeys(td.FromFks, td.ToFks) {
+		var fromDef, toDef interface{}
+		if fkDiff.DiffType == diff.SchDiffRemoved || fkDiff.DiffType == diff.SchDiffModified {
+			parentSch, err := parentSchema(ctx, fromRoot, fkDiff.From.ReferencedTableName)
+			if err != nil {
+				return nil, err
+			}
+
+			fromDef = sqlfmt.FmtForeignKey(fkDiff.From, fromSch, parentSch)
+		}
+
+		if fkDiff.DiffType == diff.SchDiffAdded || fkDiff.DiffType == diff.SchDiffModified {
+			parentSch, ok := td.ToFksParentSch[fkDiff.To.ReferencedTableName]
+			if !ok {
+				parentSch = schema.EmptySchema
+			}
+
+			toDef = sqlfmt.FmtForeignKey(fkDiff.To, toSch, parentSch)
+		}
+
+		switch fkDiff.DiffType {
+		case diff.SchDiffAdded:
+			changes = append(changes, sql.NewRow(schemaObjectForeignKey, fkDiff.To.Name, schemaDiffAdded, fromDef, toDef))
+		case diff.SchDiffRemoved:
+			changes = append(changes, sql.NewRow(schemaObjectForeignKey, fkDiff.From.Name, schemaDiffDropped, fromDef, toDef))
+		case diff.SchDiffModified:
+			changes = append(changes, sql.NewRow(schemaObjectForeignKey, fkDiff.To.Name, schemaDiffModified, fromDef, toDef))
+		}
+	}
+
+	return changes, nil
+}
+
+// parentSchema returns the schema of the table named in the root given, or an empty schema if it doesn't exist.
+func parentSchema(ctx context.Context, root *doltdb.RootValue, tblName string) (schema.Schema, error) {
+	tbl, _, ok, err := root.GetTableInsensitive(ctx, tblName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return schema.EmptySchema, nil
+	}
+
+	return tbl.GetSchema(ctx)
+}
+
+// WithFilters returns a new sql.Table instance with the filters applied
+func (st *SchemaDiffTable) WithFilters(_ []sql.Expression) sql.Table {
+	return st
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dtables
+
+import (
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// CreateTableStmtFunc returns the CREATE TABLE statement of the table named in the root value given.
+type CreateTableStmtFunc func(ctx *sql.Context, root *doltdb.RootValue, tblName string) (string, error)
+
+var _ sql.Table = (*SchemaHistoryTable)(nil)
+
+// SchemaHistoryTable is a sql.Table implementation that implements a system table which shows the CREATE TABLE
+// statement of each table at each commit in the history of the head commit
+type SchemaHistoryTable struct {
+	ddb        *doltdb.DoltDB
+	head       *doltdb.Commit
+	createStmt CreateTableStmtFunc
+}
+
+// NewSchemaHistoryTable creates a SchemaHistoryTable for the history of the head commit given
+func NewSchemaHistoryTable(_ *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, createStmt CreateTableStmtFunc) sql.Table {
+	return &SchemaHistoryTable{ddb: ddb, head: head, createStmt: createStmt}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaHistoryTableName
+func (st *SchemaHistoryTable) Name() string {
+	return doltdb.SchemaHistoryTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaHistoryTableName
+func (st *SchemaHistoryTable) String() string {
+	return doltdb.SchemaHistoryTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema history system table
+func (st *SchemaHistoryTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "commit_hash", Type: sql.Text, Source: doltdb.SchemaHistoryTableName, PrimaryKey: true, Nullable: false},
+		{Name: "table_name", Type: sql.Text, Source: doltdb.SchemaHistoryTableName, PrimaryKey: true, Nullable: false},
+		{Name: "committer", Type: sql.Text, Source: doltdb.SchemaHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "commit_date", Type: sql.Datetime, Source: doltdb.SchemaHistoryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "create_statement", Type: sql.LongText, Source: doltdb.SchemaHistoryTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition for each commit in the history of the head
+// commit
+func (st *SchemaHistoryTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	return &commitPartitioner{ctx, doltdb.CommitItrForRoots(st.ddb, st.head)}, nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *SchemaHistoryTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	cp := part.(*commitPartition)
+
+	meta, err := cp.cm.GetCommitMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := cp.cm.GetRootValue()
+	if err != nil {
+		return nil, err
+	}
+
+	tblNames, err := root.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(tblNames)
+
+	var rows []sql.Row
+	for _, tblName := range tblNames {
+		if doltdb.HasDoltPrefix(tblName) {
+			continue
+		}
+
+		stmt, err := st.createStmt(ctx, root, tblName)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, sql.NewRow(cp.h.String(), tblName, meta.Name, meta.Time(), stmt))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
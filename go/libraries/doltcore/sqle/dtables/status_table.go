@@ -0,0 +1,131 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dtables
+
+import (
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+const (
+	newTableStatus = "new table"
+	modifiedStatus = "modified"
+	deletedStatus  = "deleted"
+	renamedStatus  = "renamed"
+	conflictStatus = "conflict"
+)
+
+var _ sql.Table = (*StatusTable)(nil)
+
+// StatusTable is a sql.Table implementation that implements a system table which shows the tables changed in the
+// staged root since the head commit, and in the working root since the staged root, like dolt status
+type StatusTable struct {
+	head    *doltdb.RootValue
+	staged  *doltdb.RootValue
+	working *doltdb.RootValue
+}
+
+// NewStatusTable creates a StatusTable for the head, staged and working roots given
+func NewStatusTable(_ *sql.Context, head, staged, working *doltdb.RootValue) sql.Table {
+	return &StatusTable{head: head, staged: staged, working: working}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StatusTableName
+func (st *StatusTable) Name() string {
+	return doltdb.StatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StatusTableName
+func (st *StatusTable) String() string {
+	return doltdb.StatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the status system table
+func (st *StatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sql.Text, Source: doltdb.StatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "staged", Type: sql.Boolean, Source: doltdb.StatusTableName, PrimaryKey: true, Nullable: false},
+		{Name: "status", Type: sql.Text, Source: doltdb.StatusTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (st *StatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *StatusTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	staged, err := diff.GetTableDeltas(ctx, st.head, st.staged)
+	if err != nil {
+		return nil, err
+	}
+
+	unstaged, err := diff.GetTableDeltas(ctx, st.staged, st.working)
+	if err != nil {
+		return nil, err
+	}
+
+	inConflict, err := st.working.TablesInConflict(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicted := make(map[string]bool, len(inConflict))
+	var rows []sql.Row
+	for _, tblName := range inConflict {
+		conflicted[tblName] = true
+		rows = append(rows, sql.NewRow(tblName, false, conflictStatus))
+	}
+
+	for _, td := range staged {
+		rows = append(rows, sql.NewRow(td.CurName(), true, deltaStatus(td)))
+	}
+
+	for _, td := range unstaged {
+		if !conflicted[td.CurName()] {
+			rows = append(rows, sql.NewRow(td.CurName(), false, deltaStatus(td)))
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i][0] != rows[j][0] {
+			return rows[i][0].(string) < rows[j][0].(string)
+		}
+
+		return rows[i][1].(bool)
+	})
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func deltaStatus(td diff.TableDelta) string {
+	switch {
+	case td.IsAdd():
+		return newTableStatus
+	case td.IsDrop():
+		return deletedStatus
+	case td.IsRename():
+		return renamedStatus
+	default:
+		return modifiedStatus
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expreval
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// ErrCheckConstraintViolated is returned when a row does not satisfy an enforced check constraint of its table.
+var ErrCheckConstraintViolated = errors.NewKind("Check constraint '%s' is violated.")
+
+// ErrInvalidCheckExpression is returned when the expression of a check constraint can't be resolved against the schema
+// of its table.
+var ErrInvalidCheckExpression = errors.NewKind("Check constraint '%s' has an invalid expression: %s")
+
+// CheckReferencesColumn returns whether the expression of the check constraint given references the named column.
+func CheckReferencesColumn(check schema.Check, colName string) (bool, error) {
+	return ExpressionReferencesColumn(check.Expression(), colName)
+}
+
+type compiledCheck struct {
+	check schema.Check
+	expr  sql.Expression
+}
+
+// CheckEvaluator evaluates the enforced check constraints of a schema against rows of that schema.
+type CheckEvaluator struct {
+	sch    schema.Schema
+	checks []compiledCheck
+}
+
+// NewCheckEvaluator returns a CheckEvaluator for the enforced check constraints of the schema given.
+func NewCheckEvaluator(sch schema.Schema) (*CheckEvaluator, error) {
+	ce := &CheckEvaluator{sch: sch}
+	for _, check := range sch.Checks().AllChecks() {
+		if !check.Enforced() {
+			continue
+		}
+		expr, err := ResolveExpression(sch, check.Expression())
+		if err != nil {
+			return nil, ErrInvalidCheckExpression.New(check.Name(), err.Error())
+		}
+		ce.checks = append(ce.checks, compiledCheck{check, expr})
+	}
+	return ce, nil
+}
+
+// HasChecks returns whether the schema of this evaluator has any enforced check constraints.
+func (ce *CheckEvaluator) HasChecks() bool {
+	return len(ce.checks) > 0
+}
+
+// Check returns ErrCheckConstraintViolated for the first enforced check constraint that the row given violates.
+func (ce *CheckEvaluator) Check(ctx context.Context, r row.Row) error {
+	violated, err := ce.Violations(ctx, r)
+	if err != nil {
+		return err
+	}
+	if len(violated) > 0 {
+		return ErrCheckConstraintViolated.New(violated[0].Name())
+	}
+	return nil
+}
+
+// Violations returns all of the enforced check constraints that the row given violates. As in MySQL, a check
+// constraint whose expression evaluates to NULL is satisfied.
+func (ce *CheckEvaluator) Violations(ctx context.Context, r row.Row) ([]schema.Check, error) {
+	if len(ce.checks) == 0 {
+		return nil, nil
+	}
+
+	sqlRow, err := toSqlRow(ctx, ce.sch, r)
+	if err != nil {
+		return nil, err
+	}
+	sqlCtx := toSqlContext(ctx)
+
+	var violated []schema.Check
+	for _, cc := range ce.checks {
+		res, err := cc.expr.Eval(sqlCtx, sqlRow)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			continue
+		}
+		satisfied, err := sql.ConvertToBool(res)
+		if err != nil {
+			return nil, err
+		}
+		if !satisfied {
+			violated = append(violated, cc.check)
+		}
+	}
+	return violated, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expreval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestCheckEvaluator(t *testing.T) {
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("a", 1, types.IntKind, false),
+		schema.NewColumn("b", 2, types.StringKind, false),
+	)
+	require.NoError(t, err)
+	sch := schema.MustSchemaFromCols(colColl)
+	sch.Checks().AddChecks(
+		schema.NewCheck("chk_a", "A < 10", true),
+		schema.NewCheck("chk_b", "length(b) > 1", true),
+		schema.NewCheck("chk_off", "a > 100", false),
+	)
+
+	ce, err := NewCheckEvaluator(sch)
+	require.NoError(t, err)
+	require.True(t, ce.HasChecks())
+
+	newRow := func(vals row.TaggedValues) row.Row {
+		r, err := row.New(types.Format_Default, sch, vals)
+		require.NoError(t, err)
+		return r
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		r        row.Row
+		violated []string
+	}{
+		{"satisfied", newRow(row.TaggedValues{0: types.Int(1), 1: types.Int(5), 2: types.String("ab")}), nil},
+		{"null satisfies", newRow(row.TaggedValues{0: types.Int(2)}), nil},
+		{"one violated", newRow(row.TaggedValues{0: types.Int(3), 1: types.Int(50), 2: types.String("ab")}), []string{"chk_a"}},
+		{"all violated", newRow(row.TaggedValues{0: types.Int(4), 1: types.Int(50), 2: types.String("a")}), []string{"chk_a", "chk_b"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violated, err := ce.Violations(ctx, test.r)
+			require.NoError(t, err)
+			var names []string
+			for _, check := range violated {
+				names = append(names, check.Name())
+			}
+			assert.Equal(t, test.violated, names)
+
+			err = ce.Check(ctx, test.r)
+			if len(test.violated) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, ErrCheckConstraintViolated.Is(err))
+			}
+		})
+	}
+}
+
+func TestResolveExpression(t *testing.T) {
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("a", 1, types.IntKind, false),
+	)
+	require.NoError(t, err)
+	sch := schema.MustSchemaFromCols(colColl)
+
+	_, err = ResolveExpression(sch, "a > pk")
+	assert.NoError(t, err)
+	_, err = ResolveExpression(sch, "c > 0")
+	assert.Error(t, err)
+	_, err = ResolveExpression(sch, "no_such_func(a)")
+	assert.Error(t, err)
+
+	sch.Checks().AddChecks(schema.NewCheck("chk", "c > 0", true))
+	_, err = NewCheckEvaluator(sch)
+	assert.True(t, ErrInvalidCheckExpression.Is(err))
+
+	ok, err := CheckReferencesColumn(schema.NewCheck("chk", "A + 1 > pk", true), "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = CheckReferencesColumn(schema.NewCheck("chk", "pk > 1", true), "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expreval
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrInvalidGeneratedExpression is returned when the expression of a generated column can't be resolved against the
+// schema of its table.
+var ErrInvalidGeneratedExpression = errors.NewKind("Generated column '%s' has an invalid expression: %s")
+
+// ErrGeneratedColumnNull is returned when the expression of a non-nullable generated column evaluates to NULL.
+var ErrGeneratedColumnNull = errors.NewKind("Generated column '%s' is non-nullable but its expression evaluated to NULL")
+
+type compiledGenerated struct {
+	idx  int
+	col  schema.Column
+	expr sql.Expression
+}
+
+// GeneratedEvaluator computes the values of the generated columns of a schema from the other values of a row.
+type GeneratedEvaluator struct {
+	vrw  types.ValueReadWriter
+	sch  schema.Schema
+	cols []compiledGenerated
+}
+
+// NewGeneratedEvaluator returns a GeneratedEvaluator for the generated columns of the schema given. The
+// ValueReadWriter is used to write generated values that are stored out-of-line.
+func NewGeneratedEvaluator(vrw types.ValueReadWriter, sch schema.Schema) (*GeneratedEvaluator, error) {
+	ge := &GeneratedEvaluator{vrw: vrw, sch: sch}
+	for idx, col := range sch.GetAllCols().GetColumns() {
+		if !col.IsGenerated() {
+			continue
+		}
+		expr, err := ResolveExpression(sch, col.Generated)
+		if err != nil {
+			return nil, ErrInvalidGeneratedExpression.New(col.Name, err.Error())
+		}
+		ge.cols = append(ge.cols, compiledGenerated{idx, col, expr})
+	}
+	return ge, nil
+}
+
+// HasGenerated returns whether the schema of this evaluator has any generated columns.
+func (ge *GeneratedEvaluator) HasGenerated() bool {
+	return len(ge.cols) > 0
+}
+
+// Generate returns the row given with the values of its generated columns computed from its other values. Generated
+// columns are computed in the order of the schema, so a generated column may use the generated columns before it.
+func (ge *GeneratedEvaluator) Generate(ctx context.Context, r row.Row) (row.Row, error) {
+	if len(ge.cols) == 0 {
+		return r, nil
+	}
+
+	sqlRow, err := toSqlRow(ctx, ge.sch, r)
+	if err != nil {
+		return nil, err
+	}
+	sqlCtx := toSqlContext(ctx)
+
+	for _, cg := range ge.cols {
+		val, err := cg.expr.Eval(sqlCtx, sqlRow)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil && !cg.col.IsNullable() {
+			return nil, ErrGeneratedColumnNull.New(cg.col.Name)
+		}
+		val, err = cg.col.TypeInfo.ToSqlType().Convert(val)
+		if err != nil {
+			return nil, err
+		}
+		sqlRow[cg.idx] = val
+
+		nomsVal, err := cg.col.TypeInfo.ConvertValueToNomsValue(ctx, ge.vrw, val)
+		if err != nil {
+			return nil, err
+		}
+		if types.IsNull(nomsVal) {
+			// NULL values are absent from rows
+			nomsVal = nil
+		}
+		r, err = r.SetColVal(cg.col.Tag, nomsVal, ge.sch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expreval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestGeneratedEvaluator(t *testing.T) {
+	lower := schema.NewColumn("lower_name", 2, types.StringKind, false)
+	lower.Generated = "lower(name)"
+	key := schema.NewColumn("key", 3, types.StringKind, false, schema.NotNullConstraint{})
+	key.Generated = "concat(lower_name, '-', pk)"
+	colColl, err := schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		lower,
+		key,
+	)
+	require.NoError(t, err)
+	sch := schema.MustSchemaFromCols(colColl)
+
+	ge, err := NewGeneratedEvaluator(nil, sch)
+	require.NoError(t, err)
+	require.True(t, ge.HasGenerated())
+
+	ctx := context.Background()
+	r, err := row.New(types.Format_Default, sch, row.TaggedValues{0: types.Int(1), 1: types.String("Ada"), 3: types.String("stale")})
+	require.NoError(t, err)
+	r, err = ge.Generate(ctx, r)
+	require.NoError(t, err)
+	val, _ := r.GetColVal(2)
+	assert.Equal(t, types.String("ada"), val)
+	val, _ = r.GetColVal(3)
+	assert.Equal(t, types.String("ada-1"), val)
+
+	// a NULL input makes a nullable generated column NULL, and a non-nullable one an error
+	r, err = row.New(types.Format_Default, sch, row.TaggedValues{0: types.Int(2)})
+	require.NoError(t, err)
+	_, err = ge.Generate(ctx, r)
+	assert.True(t, ErrGeneratedColumnNull.Is(err), "%v", err)
+
+	invalid := schema.NewColumn("bad", 4, types.IntKind, false)
+	invalid.Generated = "missing + 1"
+	colColl, err = colColl.Append(invalid)
+	require.NoError(t, err)
+	_, err = NewGeneratedEvaluator(nil, schema.MustSchemaFromCols(colColl))
+	assert.True(t, ErrInvalidGeneratedExpression.Is(err), "%v", err)
+}
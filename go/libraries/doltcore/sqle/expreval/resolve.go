@@ -0,0 +1,119 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expreval
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+var exprFunctions sql.FunctionRegistry
+var exprFunctionsOnce sync.Once
+
+// ResolveExpression parses an expression stored in a schema, such as the expression of a check constraint or a
+// generated column, and resolves it against the schema given. Columns are resolved to their index in
+// sch.GetAllCols(), which is the order in which the values of a row are given to the resolved expression.
+func ResolveExpression(sch schema.Schema, exprStr string) (sql.Expression, error) {
+	exprFunctionsOnce.Do(func() {
+		exprFunctions = sql.NewFunctionRegistry()
+		exprFunctions.MustRegister(function.Defaults...)
+	})
+
+	parsed, err := parse.StringToColumnDefaultValue(sql.NewEmptyContext(), exprStr)
+	if err != nil {
+		return nil, err
+	}
+
+	allCols := sch.GetAllCols().GetColumns()
+	expr, err := expression.TransformUp(parsed.Expression, func(e sql.Expression) (sql.Expression, error) {
+		switch e := e.(type) {
+		case *expression.UnresolvedColumn:
+			for idx, col := range allCols {
+				if strings.ToLower(col.Name) == strings.ToLower(e.Name()) {
+					return expression.NewGetField(idx, col.TypeInfo.ToSqlType(), col.Name, col.IsNullable()), nil
+				}
+			}
+			return nil, errUnknownColumn.New(e.Name())
+		case *expression.UnresolvedFunction:
+			fn, err := exprFunctions.Function(e.Name())
+			if err != nil {
+				return nil, err
+			}
+			return fn.Call(e.Arguments...)
+		default:
+			return e, nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !expr.Resolved() {
+		return nil, errNotImplemented.New(expr.String())
+	}
+
+	return expr, nil
+}
+
+// ExpressionReferencesColumn returns whether the expression given references the named column.
+func ExpressionReferencesColumn(exprStr string, colName string) (bool, error) {
+	parsed, err := parse.StringToColumnDefaultValue(sql.NewEmptyContext(), exprStr)
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	sql.Inspect(parsed.Expression, func(e sql.Expression) bool {
+		if col, ok := e.(*expression.UnresolvedColumn); ok && strings.ToLower(col.Name()) == strings.ToLower(colName) {
+			found = true
+		}
+		return !found
+	})
+	return found, nil
+}
+
+// toSqlRow returns the values of the row given in the order of sch.GetAllCols(), which is the order expected by
+// expressions resolved with ResolveExpression.
+func toSqlRow(ctx context.Context, sch schema.Schema, r row.Row) (sql.Row, error) {
+	allCols := sch.GetAllCols().GetColumns()
+	sqlRow := make(sql.Row, len(allCols))
+	for i, col := range allCols {
+		val, ok := r.GetColVal(col.Tag)
+		if !ok {
+			continue
+		}
+		var err error
+		sqlRow[i], err = col.TypeInfo.ConvertNomsValueToValue(ctx, val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sqlRow, nil
+}
+
+func toSqlContext(ctx context.Context) *sql.Context {
+	if sqlCtx, ok := ctx.(*sql.Context); ok {
+		return sqlCtx
+	}
+	return sql.NewContext(ctx)
+}
@@ -0,0 +1,640 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/alterschema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
+)
+
+// The engine does not support CHECK constraints, spatial column types or generated columns, and its parser discards
+// primary key changes and stored procedures, so statements that use them are recognized here from their tokens and
+// executed directly. CREATE TABLE statements have their CHECK clauses and generation clauses stripped and their
+// spatial column types replaced with BLOB before they're run by the engine, after which the new table is given its
+// spatial column types, generated columns and check constraints. The recognized statements are:
+//
+//   CREATE TABLE [IF NOT EXISTS] t (create_definition, ...) [table_options]
+//   ALTER TABLE t ADD [COLUMN] c column_definition
+//   ALTER TABLE t ADD [CONSTRAINT [name]] CHECK (expr) [[NOT] ENFORCED]
+//   ALTER TABLE t DROP CHECK name
+//   ALTER TABLE t DROP CONSTRAINT name
+//   ALTER TABLE t ALTER {CHECK | CONSTRAINT} name [NOT] ENFORCED
+//   ALTER TABLE t ADD [CONSTRAINT [name]] PRIMARY KEY (c, ...)
+//   ALTER TABLE t DROP PRIMARY KEY
+//
+// where column definitions may have a spatial type, a generation clause and CHECK clauses, and create definitions may
+// also be CHECK constraints, along with the CREATE PROCEDURE and DROP PROCEDURE statements described in procedures.go.
+// Other statements, including CREATE TABLE ... SELECT and ALTER TABLE statements with several alterations, are left
+// to the engine.
+//
+// TODO: this is a stopgap until the vitess parser supports these clauses, after which the engine should handle them.
+
+// ddlToken is a token of a SQL statement, with its offsets in the statement. Quoted tokens are identifiers or strings
+// whatever their values.
+type ddlToken struct {
+	typ        int
+	val        string
+	start, end int
+	quoted     bool
+}
+
+// ddlEdit replaces the text of a statement between two offsets.
+type ddlEdit struct {
+	start, end int
+	text       string
+}
+
+// extendedDDLFunc executes a statement recognized by parseExtendedDDL, and returns whether it was handled.
+type extendedDDLFunc func(ctx *sql.Context, e *sqle.Engine) (bool, error)
+
+// ExecuteExtendedDDL executes the query given if it uses check constraints, spatial column types or generated columns,
+// changes a primary key, or creates or drops a stored procedure, and returns whether it did so. Queries that are not returned as handled should be executed as usual.
+func ExecuteExtendedDDL(ctx *sql.Context, e *sqle.Engine, query string) (bool, error) {
+	exec, ok, err := parseExtendedDDL(query)
+	if !ok || err != nil {
+		return ok, err
+	}
+	return exec(ctx, e)
+}
+
+// MayBeExtendedDDL returns whether the query given is a statement that may use check constraints, spatial column
+// types or generated columns, change a primary key, or create or drop a stored procedure. It's cheaper than
+// ExecuteExtendedDDL for callers that need to prepare before such a statement is executed.
+func MayBeExtendedDDL(query string) bool {
+	_, ok, _ := parseExtendedDDL(query)
+	return ok
+}
+
+// parseExtendedDDL returns the function that executes the query given if it's one of the statements recognized here.
+// Recognized statements whose clauses are malformed are returned with an error.
+func parseExtendedDDL(query string) (extendedDDLFunc, bool, error) {
+	toks, ok := tokenizeDDL(query)
+	if !ok || len(toks) < 3 {
+		return nil, false, nil
+	}
+
+	if _, ok := isCreateProcedure(toks); ok {
+		return parseCreateProcedureExtended(query, toks)
+	}
+
+	switch {
+	case toks[0].typ == sqlparser.CREATE && toks[1].typ == sqlparser.TABLE:
+		return parseCreateTableExtended(query, toks)
+	case toks[0].typ == sqlparser.ALTER && toks[1].typ == sqlparser.TABLE:
+		return parseAlterTableExtended(query, toks)
+	case toks[0].typ == sqlparser.DROP && isWordToken(toks, 1, "procedure"):
+		return parseDropProcedureExtended(toks)
+	}
+	return nil, false, nil
+}
+
+// tokenizeDDL returns the tokens of the query given, without its comments.
+func tokenizeDDL(query string) ([]ddlToken, bool) {
+	var toks []ddlToken
+	tkn := sqlparser.NewStringTokenizer(query)
+	prevEnd := 0
+	for {
+		typ, val := tkn.Scan()
+		switch typ {
+		case 0:
+			// trailing semicolons end the statement
+			for len(toks) > 0 && toks[len(toks)-1].typ == ';' {
+				toks = toks[:len(toks)-1]
+			}
+			return toks, true
+		case sqlparser.LEX_ERROR:
+			return nil, false
+		}
+
+		// tokens are separated by whitespace only, as comments are tokens too, and the values of quoted tokens don't
+		// include their quotes
+		end := tkn.Position - 1
+		start := prevEnd
+		for start < end && isDDLSpace(query[start]) {
+			start++
+		}
+		prevEnd = end
+
+		if typ == sqlparser.COMMENT {
+			continue
+		}
+		quoted := start < end && (query[start] == '`' || query[start] == '\'' || query[start] == '"')
+		toks = append(toks, ddlToken{typ: typ, val: string(val), start: start, end: end, quoted: quoted})
+	}
+}
+
+func isDDLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isSpatialTypeToken(typ int) bool {
+	return typ == sqlparser.GEOMETRY || typ == sqlparser.POINT || typ == sqlparser.LINESTRING || typ == sqlparser.POLYGON
+}
+
+// isIndexDefToken returns whether a create definition or an added definition that starts with the token given defines
+// an index or a constraint other than a check constraint, rather than a column.
+func isIndexDefToken(typ int) bool {
+	switch typ {
+	case sqlparser.PRIMARY, sqlparser.KEY, sqlparser.INDEX, sqlparser.UNIQUE, sqlparser.FOREIGN, sqlparser.FULLTEXT,
+		sqlparser.SPATIAL, sqlparser.CONSTRAINT, sqlparser.CHECK, '(':
+		return true
+	}
+	return false
+}
+
+// parseDDLTableName parses the possibly qualified table name starting at toks[i], and returns the index of the token
+// following it.
+func parseDDLTableName(toks []ddlToken, i int) (dbName, tblName string, next int, ok bool) {
+	if i >= len(toks) || toks[i].val == "" {
+		return "", "", 0, false
+	}
+	if i+2 < len(toks) && toks[i+1].typ == '.' && toks[i+2].val != "" {
+		return toks[i].val, toks[i+2].val, i + 3, true
+	}
+	return "", toks[i].val, i + 1, true
+}
+
+// ddlText returns the text of the tokens toks[first:last+1] in the query given, without any comments between them.
+func ddlText(query string, toks []ddlToken, first, last int) string {
+	var sb strings.Builder
+	for j := first; j <= last; j++ {
+		if j > first && toks[j].start > toks[j-1].end {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(query[toks[j].start:toks[j].end])
+	}
+	return sb.String()
+}
+
+// applyDDLEdits returns the query given with the edits given, which are in order, applied.
+func applyDDLEdits(query string, edits []ddlEdit) string {
+	var sb strings.Builder
+	prev := 0
+	for _, edit := range edits {
+		sb.WriteString(query[prev:edit.start])
+		sb.WriteString(edit.text)
+		prev = edit.end
+	}
+	sb.WriteString(query[prev:])
+	return sb.String()
+}
+
+// spatialColumnEdit returns the edit that replaces the spatial column type at toks[i] with a type the engine supports.
+func spatialColumnEdit(toks []ddlToken, i int) ddlEdit {
+	return ddlEdit{start: toks[i].start, end: toks[i].end, text: "BLOB"}
+}
+
+// definitionEnd returns the index of the comma that ends the definition starting at toks[i], or end if the definition
+// is the last before toks[end].
+func definitionEnd(toks []ddlToken, i, end int) int {
+	depth := 0
+	for j := i; j < end; j++ {
+		switch toks[j].typ {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return end
+}
+
+// parseColumnClauses parses the column definition in toks[i:end], which starts with the column's name, and adds the
+// edits that remove the clauses the engine doesn't support from it to those given. inlineChecks is whether CHECK
+// clauses are allowed.
+func parseColumnClauses(query string, toks []ddlToken, i, end int, cols extendedColumns, edits []ddlEdit, inlineChecks bool) ([]checkDef, []ddlEdit, error) {
+	colName := toks[i].val
+	var defs []checkDef
+	if i+1 < end && isSpatialTypeToken(toks[i+1].typ) {
+		cols.spatialTypes[colName], _ = typeinfo.GeometryTypeFromName(toks[i+1].val)
+		edits = append(edits, spatialColumnEdit(toks, i+1))
+	}
+
+	depth := 0
+	for k := i + 2; k < end; k++ {
+		switch {
+		case toks[k].typ == '(':
+			depth++
+		case toks[k].typ == ')':
+			depth--
+		case depth == 0 && toks[k].typ == sqlparser.CHECK:
+			if !inlineChecks {
+				return nil, nil, fmt.Errorf("CHECK clauses are not supported in ADD COLUMN, use ADD CHECK instead")
+			}
+			def, first, last, err := parseCheckClause(query, toks, k)
+			if err != nil {
+				return nil, nil, err
+			}
+			defs = append(defs, def)
+			edits = append(edits, ddlEdit{start: toks[first].start, end: toks[last].end})
+			k = last
+		case depth == 0 && isGeneratedClause(toks, k):
+			expr, last, err := parseGeneratedClause(query, toks, k)
+			if err != nil {
+				return nil, nil, err
+			}
+			cols.generated[colName] = expr
+			edits = append(edits, ddlEdit{start: toks[k].start, end: toks[last].end})
+			k = last
+		}
+	}
+	return defs, edits, nil
+}
+
+// parseCreateTableExtended parses a CREATE TABLE statement with create definitions that use clauses the engine doesn't
+// support.
+func parseCreateTableExtended(query string, toks []ddlToken) (extendedDDLFunc, bool, error) {
+	i := 2
+	if i+2 < len(toks) && toks[i].typ == sqlparser.IF && toks[i+1].typ == sqlparser.NOT && toks[i+2].typ == sqlparser.EXISTS {
+		i += 3
+	}
+	_, _, i, ok := parseDDLTableName(toks, i)
+	if !ok || i >= len(toks) || toks[i].typ != '(' {
+		return nil, false, nil
+	}
+	defsEnd := matchParen(toks, i)
+	if defsEnd < 0 {
+		return nil, false, nil
+	}
+	for j := defsEnd + 1; j < len(toks); j++ {
+		if toks[j].typ == sqlparser.SELECT {
+			return nil, false, nil
+		}
+	}
+
+	var defs []checkDef
+	var edits []ddlEdit
+	cols := newExtendedColumns()
+	for start := i + 1; start < defsEnd; {
+		end := definitionEnd(toks, start, defsEnd)
+		switch {
+		case toks[start].typ == sqlparser.CHECK || toks[start].typ == sqlparser.CONSTRAINT:
+			k := start
+			for k < end && k <= start+2 && toks[k].typ != sqlparser.CHECK {
+				k++
+			}
+			if k == end || toks[k].typ != sqlparser.CHECK {
+				// another kind of constraint
+				break
+			}
+			def, first, last, err := parseCheckClause(query, toks, k)
+			if err != nil {
+				return nil, true, err
+			}
+			if first != start || last != end-1 {
+				return nil, true, fmt.Errorf("unexpected tokens after CHECK constraint")
+			}
+			// the constraint's separator goes with it
+			edit := ddlEdit{start: toks[start].start, end: toks[last].end}
+			if toks[start-1].typ == ',' {
+				edit.start = toks[start-1].start
+			} else if end < defsEnd {
+				edit.end = toks[end].end
+			}
+			defs = append(defs, def)
+			edits = append(edits, edit)
+		case !isIndexDefToken(toks[start].typ):
+			colDefs, colEdits, err := parseColumnClauses(query, toks, start, end, cols, edits, true)
+			if err != nil {
+				return nil, true, err
+			}
+			defs = append(defs, colDefs...)
+			edits = colEdits
+		}
+		start = end + 1
+	}
+	if len(edits) == 0 {
+		return nil, false, nil
+	}
+	edited := applyDDLEdits(query, edits)
+
+	return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+		return true, createTableExtended(ctx, e, edited, defs, cols)
+	}, true, nil
+}
+
+// createTableExtended runs the CREATE TABLE statement given, which is stripped of the clauses the engine doesn't
+// support, and then gives the new table its extended columns and check constraints.
+func createTableExtended(ctx *sql.Context, e *sqle.Engine, edited string, defs []checkDef, cols extendedColumns) error {
+	stmt, err := sqlparser.Parse(edited)
+	if err != nil {
+		return err
+	}
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.Action != sqlparser.CreateStr {
+		return fmt.Errorf("expected a CREATE TABLE statement")
+	}
+
+	db, err := ddlDatabase(ctx, e, ddl.Table.Qualifier.String())
+	if err != nil {
+		return err
+	}
+	tblName := ddl.Table.Name.String()
+
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if exists, err := root.HasTable(ctx, tblName); err != nil {
+		return err
+	} else if exists && ddl.IfNotExists {
+		return nil
+	}
+
+	if err := queryDDL(ctx, e, edited); err != nil {
+		return err
+	}
+
+	err = cols.apply(ctx, db, tblName)
+	if err == nil && len(defs) > 0 {
+		err = addChecks(ctx, db, tblName, defs)
+	}
+	if err != nil {
+		// don't leave the table behind without its extended columns and check constraints
+		if rollbackErr := db.SetRoot(ctx, root); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	return nil
+}
+
+// parseAlterTableExtended parses an ALTER TABLE statement with a single alteration that uses clauses the engine
+// doesn't support.
+func parseAlterTableExtended(query string, toks []ddlToken) (extendedDDLFunc, bool, error) {
+	dbName, tblName, i, ok := parseDDLTableName(toks, 2)
+	if !ok || i >= len(toks) || definitionEnd(toks, i, len(toks)) != len(toks) {
+		return nil, false, nil
+	}
+
+	if toks[i].typ == sqlparser.ADD {
+		j := i + 1
+		if j < len(toks) && toks[j].typ == sqlparser.COLUMN {
+			j++
+		}
+		if j < len(toks) && !isIndexDefToken(toks[j].typ) {
+			cols := newExtendedColumns()
+			_, edits, err := parseColumnClauses(query, toks, j, len(toks), cols, nil, false)
+			if err != nil {
+				return nil, true, err
+			}
+			if len(edits) == 0 {
+				return nil, false, nil
+			}
+			edited := applyDDLEdits(query, edits)
+			return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+				return true, addExtendedColumn(ctx, e, edited, dbName, tblName, cols)
+			}, true, nil
+		}
+	}
+
+	if exec, ok, err := parseAlterPrimaryKey(toks, dbName, tblName, i); ok || err != nil {
+		return exec, ok, err
+	}
+
+	return parseAlterTableChecks(query, toks, dbName, tblName, i)
+}
+
+// parseAlterPrimaryKey parses the ALTER TABLE statement given if it adds or drops the primary key of the named table.
+// The table's alteration starts at toks[i].
+func parseAlterPrimaryKey(toks []ddlToken, dbName, tblName string, i int) (extendedDDLFunc, bool, error) {
+	spec := toks[i:]
+
+	switch {
+	case len(spec) == 3 && spec[0].typ == sqlparser.DROP && spec[1].typ == sqlparser.PRIMARY && spec[2].typ == sqlparser.KEY:
+		return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+			tbl, err := ddlAlterableTable(ctx, e, dbName, tblName)
+			if err != nil {
+				return true, err
+			}
+			return true, tbl.DropPrimaryKey(ctx)
+		}, true, nil
+	case spec[0].typ == sqlparser.ADD:
+		j := 1
+		if j < len(spec) && spec[j].typ == sqlparser.CONSTRAINT {
+			j++
+			if j < len(spec) && spec[j].typ != sqlparser.PRIMARY {
+				j++
+			}
+		}
+		if j+1 >= len(spec) || spec[j].typ != sqlparser.PRIMARY || spec[j+1].typ != sqlparser.KEY {
+			return nil, false, nil
+		}
+
+		var columns []sql.IndexColumn
+		j += 2
+		if j >= len(spec) || spec[j].typ != '(' {
+			return nil, true, fmt.Errorf("expected '(' after PRIMARY KEY")
+		}
+		for j++; j+1 < len(spec) && spec[j].val != "" && (spec[j+1].typ == ',' || spec[j+1].typ == ')'); j += 2 {
+			columns = append(columns, sql.IndexColumn{Name: spec[j].val})
+			if spec[j+1].typ == ')' {
+				break
+			}
+		}
+		if j+2 != len(spec) || spec[j+1].typ != ')' {
+			return nil, true, fmt.Errorf("only a list of columns is supported in PRIMARY KEY definitions")
+		}
+		return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+			tbl, err := ddlAlterableTable(ctx, e, dbName, tblName)
+			if err != nil {
+				return true, err
+			}
+			return true, tbl.CreatePrimaryKey(ctx, columns)
+		}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// addExtendedColumn runs the ALTER TABLE statement given, which adds a column without the parts of its definition the
+// engine doesn't support, and then completes the new column's definition.
+func addExtendedColumn(ctx *sql.Context, e *sqle.Engine, query, dbName, tblName string, cols extendedColumns) error {
+	db, err := ddlDatabase(ctx, e, dbName)
+	if err != nil {
+		return err
+	}
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := queryDDL(ctx, e, query); err != nil {
+		return err
+	}
+
+	if err = cols.apply(ctx, db, tblName); err != nil {
+		if rollbackErr := db.SetRoot(ctx, root); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	return nil
+}
+
+// extendedColumns are the parts of column definitions that the engine doesn't support, by column name. They're
+// applied to the columns after the engine has created them.
+type extendedColumns struct {
+	spatialTypes map[string]typeinfo.TypeInfo
+	generated    map[string]string
+}
+
+func newExtendedColumns() extendedColumns {
+	return extendedColumns{
+		spatialTypes: make(map[string]typeinfo.TypeInfo),
+		generated:    make(map[string]string),
+	}
+}
+
+// apply gives the named columns of a table their spatial types and generation expressions. Existing rows get the
+// computed values of new generated columns, which must satisfy the table's check constraints.
+func (ec extendedColumns) apply(ctx *sql.Context, db Database, tblName string) error {
+	if len(ec.spatialTypes) == 0 && len(ec.generated) == 0 {
+		return nil
+	}
+
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return err
+	}
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrTableNotFound.New(tblName)
+	}
+
+	for colName, ti := range ec.spatialTypes {
+		tbl, err = alterColumn(ctx, tbl, tblName, colName, func(col *schema.Column) {
+			// the column holds no values, so it doesn't need converting
+			col.Kind = ti.NomsKind()
+			col.TypeInfo = ti
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for colName, expr := range ec.generated {
+		expr := expr
+		tbl, err = alterColumn(ctx, tbl, tblName, colName, func(col *schema.Column) {
+			col.Generated = expr
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(ec.generated) > 0 {
+		tbl, err = merge.RegenerateColumns(ctx, tbl)
+		if err != nil {
+			return err
+		}
+		violations, err := merge.CheckViolations(ctx, tbl)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			return expreval.ErrCheckConstraintViolated.New(violations[0].Check.Name())
+		}
+	}
+
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return err
+	}
+	return db.SetRoot(ctx, root)
+}
+
+// alterColumn applies the function given to the definition of the named column. The column keeps its tag.
+func alterColumn(ctx *sql.Context, tbl *doltdb.Table, tblName, colName string, alter func(col *schema.Column)) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	col, ok := sch.GetAllCols().GetByNameCaseInsensitive(colName)
+	if !ok {
+		return nil, fmt.Errorf("column %s not found in table %s", colName, tblName)
+	}
+	newCol := col
+	alter(&newCol)
+	return alterschema.ModifyColumn(ctx, tbl, col, newCol, nil)
+}
+
+// queryDDL runs the statement given, which returns no rows, through the engine.
+func queryDDL(ctx *sql.Context, e *sqle.Engine, query string) error {
+	_, iter, err := e.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	if _, err = sql.RowIterToRows(iter); err != nil {
+		_ = iter.Close()
+		return err
+	}
+	return nil
+}
+
+// ddlAlterableTable returns the named table of the dolt database with the name given, or of the current database if
+// the database name is empty.
+func ddlAlterableTable(ctx *sql.Context, e *sqle.Engine, dbName, tblName string) (*AlterableDoltTable, error) {
+	db, err := ddlDatabase(ctx, e, dbName)
+	if err != nil {
+		return nil, err
+	}
+	tbl, ok, err := db.GetTableInsensitive(ctx, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, sql.ErrTableNotFound.New(tblName)
+	}
+	alterable, ok := tbl.(*AlterableDoltTable)
+	if !ok {
+		return nil, ErrSystemTableAlter.New(tblName)
+	}
+	return alterable, nil
+}
+
+// ddlDatabase returns the dolt database with the name given, or the current database if the name is empty.
+func ddlDatabase(ctx *sql.Context, e *sqle.Engine, dbName string) (Database, error) {
+	if dbName == "" {
+		dbName = ctx.GetCurrentDatabase()
+	}
+	sqlDb, err := e.Catalog.Database(dbName)
+	if err != nil {
+		return Database{}, err
+	}
+	db, ok := sqlDb.(Database)
+	if !ok {
+		return Database{}, fmt.Errorf("database %s does not support check constraints or spatial types", dbName)
+	}
+	return db, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+func TestMayBeExtendedDDL(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"CREATE TABLE t (pk INT PRIMARY KEY, p POINT)", true},
+		{"create table t (pk int primary key, g geometry)", true},
+		{"CREATE TABLE t (pk INT PRIMARY KEY, a INT CHECK (a > 0))", true},
+		{"CREATE TABLE t (pk INT PRIMARY KEY, a INT, CONSTRAINT c CHECK (a > 0))", true},
+		{"CREATE TABLE t (pk INT PRIMARY KEY, a INT AS (pk + 1) STORED)", true},
+		{"ALTER TABLE t ADD COLUMN l LINESTRING", true},
+		{"ALTER TABLE t ADD CONSTRAINT c CHECK (pk > 0)", true},
+		{"ALTER TABLE t DROP CHECK c", true},
+		{"CREATE TABLE t (pk INT PRIMARY KEY, b BLOB)", false},
+		{"CREATE TABLE t AS SELECT pk AS p FROM u", false},
+		{"CREATE TABLE t (pk INT PRIMARY KEY) AS SELECT pk FROM u", false},
+		{"CREATE TABLE t (pk INT PRIMARY KEY) SELECT CAST(pk AS CHAR) AS c FROM u", false},
+		{"CREATE TABLE t (pk INT PRIMARY KEY, `check` INT, `point` INT)", false},
+		{"CREATE TABLE t (pk INT PRIMARY KEY, a VARCHAR(10) DEFAULT 'check' COMMENT 'point')", false},
+		{"CREATE TABLE t (pk INT PRIMARY KEY /* CHECK (pk > 0) */, a POINT -- POINT\n)", true},
+		{"CREATE TABLE t (pk INT PRIMARY KEY /* CHECK (pk > 0) */, a INT)", false},
+		{"ALTER TABLE t ADD COLUMN `point` INT", false},
+		{"ALTER TABLE t ADD COLUMN a INT, ADD COLUMN p POINT", false},
+		{"SELECT ST_AsText(p) FROM t", false},
+		{"INSERT INTO t VALUES (1, 'POINT(1 2)')", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, MayBeExtendedDDL(test.query), test.query)
+	}
+}
+
+func TestExtendedDDLClauses(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(context.Background())
+	require.NoError(t, err)
+
+	// quoted identifiers named after keywords, and comments, are left in place
+	root, err = ExecuteSql(dEnv, root, "CREATE TABLE t (\n"+
+		"  pk INT PRIMARY KEY,\n"+
+		"  `check` INT /* must be positive */ CHECK (`check` /* > 1 */ > 0), -- CHECK (`check` > 1)\n"+
+		"  `point` POINT COMMENT 'not a CHECK (x) clause',\n"+
+		"  twice INT AS (`check` * 2) STORED\n"+
+		");\n"+
+		"INSERT INTO t (pk, `check`) VALUES (1, 1)")
+	require.NoError(t, err)
+
+	tbl, ok, err := root.GetTable(context.Background(), "t")
+	require.NoError(t, err)
+	require.True(t, ok)
+	sch, err := tbl.GetSchema(context.Background())
+	require.NoError(t, err)
+	checks := sch.Checks().AllChecks()
+	require.Len(t, checks, 1)
+	assert.Equal(t, "`check` > 0", checks[0].Expression())
+	pointCol, ok := sch.GetAllCols().GetByName("point")
+	require.True(t, ok)
+	assert.Equal(t, typeinfo.PointType, pointCol.TypeInfo)
+	assert.Equal(t, "not a CHECK (x) clause", pointCol.Comment)
+
+	rows, err := ExecuteSelect(dEnv, dEnv.DoltDB, root, "SELECT pk, `check`, twice FROM t")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(1), int32(1), int32(2)}}, rows)
+
+	_, err = ExecuteSql(dEnv, root, "INSERT INTO t (pk, `check`) VALUES (2, -1)")
+	assert.Error(t, err)
+
+	// CREATE TABLE ... SELECT is left to the engine
+	handled, err := ExecuteExtendedDDL(NewTestSQLCtx(context.Background()), NewDefaultEngine(), "CREATE TABLE u AS SELECT `check` AS `point` FROM t")
+	require.NoError(t, err)
+	assert.False(t, handled)
+
+	// malformed clauses are errors rather than being passed on
+	_, err = ExecuteSql(dEnv, root, "CREATE TABLE bad (pk INT PRIMARY KEY, CHECK (pk > 0) pk)")
+	assert.Error(t, err)
+}
+
+func TestAlterPrimaryKey(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(context.Background())
+	require.NoError(t, err)
+
+	pkNames := func(root *doltdb.RootValue) []string {
+		tbl, ok, err := root.GetTable(context.Background(), "t")
+		require.NoError(t, err)
+		require.True(t, ok)
+		sch, err := tbl.GetSchema(context.Background())
+		require.NoError(t, err)
+		var names []string
+		_ = sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			names = append(names, col.Name)
+			return false, nil
+		})
+		return names
+	}
+
+	root, err = ExecuteSql(dEnv, root, "CREATE TABLE t (pk INT PRIMARY KEY, a INT NOT NULL, b INT);\n"+
+		"INSERT INTO t VALUES (1, 10, 1), (2, 20, 1)")
+	require.NoError(t, err)
+
+	root, err = ExecuteSql(dEnv, root, "ALTER TABLE t ADD PRIMARY KEY (a)")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, pkNames(root))
+
+	root, err = ExecuteSql(dEnv, root, "ALTER TABLE t DROP PRIMARY KEY")
+	require.NoError(t, err)
+	assert.Empty(t, pkNames(root))
+
+	root, err = ExecuteSql(dEnv, root, "ALTER TABLE t ADD CONSTRAINT PRIMARY KEY (pk, b)")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pk", "b"}, pkNames(root))
+
+	rows, err := ExecuteSelect(dEnv, dEnv.DoltDB, root, "SELECT pk, a, b FROM t ORDER BY pk")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(1), int32(10), int32(1)}, {int32(2), int32(20), int32(1)}}, rows)
+
+	_, err = ExecuteSql(dEnv, root, "ALTER TABLE t ADD PRIMARY KEY (b)")
+	assert.Error(t, err)
+	_, err = ExecuteSql(dEnv, root, "ALTER TABLE t ADD PRIMARY KEY (a(10))")
+	assert.Error(t, err)
+	_, err = ExecuteSql(dEnv, root, "ALTER TABLE dolt_log DROP PRIMARY KEY")
+	assert.Error(t, err)
+}
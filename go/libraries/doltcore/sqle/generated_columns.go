@@ -0,0 +1,78 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// isGeneratedClause returns whether toks[i] starts the generation clause of a column definition:
+//
+//	[GENERATED ALWAYS] AS (expr) [VIRTUAL | STORED]
+func isGeneratedClause(toks []ddlToken, i int) bool {
+	if isWordToken(toks, i, "generated") {
+		return isWordToken(toks, i+1, "always") && i+2 < len(toks) && toks[i+2].typ == sqlparser.AS
+	}
+	return toks[i].typ == sqlparser.AS && i+1 < len(toks) && toks[i+1].typ == '('
+}
+
+// parseGeneratedClause parses the generation clause starting at toks[i]. It returns the clause's expression and the
+// index of its last token. Generated columns are always stored, so VIRTUAL generated columns are not supported.
+func parseGeneratedClause(query string, toks []ddlToken, i int) (expr string, last int, err error) {
+	open := i + 1
+	if toks[i].typ != sqlparser.AS {
+		open = i + 3
+	}
+	if open >= len(toks) || toks[open].typ != '(' {
+		return "", 0, fmt.Errorf("expected '(' after AS in generated column definition")
+	}
+	last = matchParen(toks, open)
+	if last < 0 {
+		return "", 0, fmt.Errorf("unbalanced parentheses in generated column definition")
+	}
+	expr = ddlText(query, toks, open+1, last-1)
+
+	if isWordToken(toks, last+1, "stored") {
+		last++
+	} else if isWordToken(toks, last+1, "virtual") {
+		return "", 0, fmt.Errorf("VIRTUAL generated columns are not supported, use STORED instead")
+	}
+	return expr, last, nil
+}
+
+// matchParen returns the index of the token that closes the parenthesis at toks[i], or -1 if there is none.
+func matchParen(toks []ddlToken, i int) int {
+	depth := 0
+	for j := i; j < len(toks); j++ {
+		switch toks[j].typ {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+// isWordToken returns whether toks[i] is the unquoted word given.
+func isWordToken(toks []ddlToken, i int, word string) bool {
+	return i < len(toks) && !toks[i].quoted && strings.ToLower(toks[i].val) == word
+}
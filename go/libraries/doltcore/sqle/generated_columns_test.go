@@ -0,0 +1,52 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedColumns(t *testing.T) {
+	ts := newTestSession(t)
+	generated := func(colName string) string {
+		col, ok := ts.tableSchema("t").GetAllCols().GetByName(colName)
+		require.True(t, ok)
+		return col.Generated
+	}
+
+	require.NoError(t, ts.exec("CREATE TABLE t (pk INT PRIMARY KEY, a VARCHAR(20), b VARCHAR(20) GENERATED ALWAYS AS (upper(a)) STORED)"))
+	assert.Equal(t, "upper(a)", generated("b"))
+
+	require.NoError(t, ts.exec("INSERT INTO t (pk, a) VALUES (1, 'one'), (2, NULL)"))
+	require.NoError(t, ts.exec("UPDATE t SET a = 'two' WHERE pk = 2"))
+	assert.Equal(t, []sql.Row{{int32(1), "one", "ONE"}, {int32(2), "two", "TWO"}}, ts.query("SELECT * FROM t ORDER BY pk"))
+
+	// added generated columns are computed for the existing rows
+	require.NoError(t, ts.exec("ALTER TABLE t ADD COLUMN c INT AS (pk * 10)"))
+	assert.Equal(t, "pk * 10", generated("c"))
+	assert.Equal(t, []sql.Row{{int32(1), int32(10)}, {int32(2), int32(20)}}, ts.query("SELECT pk, c FROM t ORDER BY pk"))
+
+	assert.Error(t, ts.exec("ALTER TABLE t ADD COLUMN d INT AS (pk + 1) VIRTUAL"))
+	assert.Error(t, ts.exec("ALTER TABLE t ADD COLUMN d INT AS (no_such_col + 1)"))
+
+	// columns used by generated columns can't be dropped
+	assert.Error(t, ts.exec("ALTER TABLE t DROP COLUMN a"))
+	require.NoError(t, ts.exec("ALTER TABLE t DROP COLUMN b"))
+	require.NoError(t, ts.exec("ALTER TABLE t DROP COLUMN a"))
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/geometry"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+func TestSpatialColumns(t *testing.T) {
+	ts := newTestSession(t)
+	colTypes := func(tblName string) map[string]typeinfo.TypeInfo {
+		types := make(map[string]typeinfo.TypeInfo)
+		for _, col := range ts.tableSchema(tblName).GetAllCols().GetColumns() {
+			types[col.Name] = col.TypeInfo
+		}
+		return types
+	}
+	literal := func(g geometry.Geometry) string {
+		return fmt.Sprintf("X'%s'", hex.EncodeToString(geometry.Serialize(g)))
+	}
+
+	require.NoError(t, ts.exec("CREATE TABLE t (pk INT PRIMARY KEY, p POINT, g GEOMETRY NOT NULL, CHECK (pk > 0))"))
+	types := colTypes("t")
+	assert.Equal(t, typeinfo.PointType, types["p"])
+	assert.Equal(t, typeinfo.GeometryType, types["g"])
+
+	p := geometry.Point{SRID: 4326, X: 1, Y: 2}
+	l := geometry.LineString{Points: []geometry.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+	require.NoError(t, ts.exec(fmt.Sprintf("INSERT INTO t VALUES (1, %s, %s), (2, NULL, %s)", literal(p), literal(l), literal(p))))
+	assert.Error(t, ts.exec(fmt.Sprintf("INSERT INTO t VALUES (3, %s, %s)", literal(l), literal(l))))
+	assert.Error(t, ts.exec(fmt.Sprintf("INSERT INTO t VALUES (-1, NULL, %s)", literal(l))))
+	assert.Equal(t, []sql.Row{{int32(1), p, l}, {int32(2), nil, p}}, ts.query("SELECT * FROM t ORDER BY pk"))
+
+	require.NoError(t, ts.exec("ALTER TABLE t ADD COLUMN poly POLYGON"))
+	assert.Equal(t, typeinfo.PolygonType, colTypes("t")["poly"])
+	require.NoError(t, ts.exec("ALTER TABLE t ADD l LINESTRING"))
+	assert.Equal(t, typeinfo.LineStringType, colTypes("t")["l"])
+	require.NoError(t, ts.exec(fmt.Sprintf("UPDATE t SET l = %s WHERE pk = 2", literal(l))))
+	assert.Equal(t, []sql.Row{{int32(2), l}}, ts.query("SELECT pk, l FROM t WHERE l IS NOT NULL"))
+
+	// a failed statement leaves no table behind
+	assert.Error(t, ts.exec("CREATE TABLE bad (pk INT PRIMARY KEY, p POINT, CHECK (missing > 0))"))
+	_, ok, err := ts.db.GetTableInsensitive(ts.ctx, "bad")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
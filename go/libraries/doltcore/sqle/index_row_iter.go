@@ -120,7 +120,7 @@ func (i *indexLookupRowIterAdapter) queueRows() {
 }
 
 // processKey is called within queueRows and processes each key, sending the resulting row to the row channel.
-func (i *indexLookupRowIterAdapter) processKey(_ context.Context, valInt interface{}) error {
+func (i *indexLookupRowIterAdapter) processKey(ctx context.Context, valInt interface{}) error {
 	val := valInt.(keyPos)
 
 	tableData := i.idx.TableData()
@@ -143,7 +143,7 @@ func (i *indexLookupRowIterAdapter) processKey(_ context.Context, valInt interfa
 		return err
 	}
 
-	sqlRow, err := sqlutil.DoltRowToSqlRow(r, i.idx.Schema())
+	sqlRow, err := sqlutil.DoltRowToSqlRow(ctx, r, i.idx.Schema())
 	if err != nil {
 		return err
 	}
@@ -17,6 +17,7 @@ package sqle
 import (
 	"github.com/dolthub/go-mysql-server/sql"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 )
 
@@ -51,6 +52,10 @@ func (idt *IndexedDoltTable) Schema() sql.Schema {
 	return idt.table.Schema()
 }
 
+func (idt *IndexedDoltTable) doltSchema() schema.Schema {
+	return idt.table.doltSchema()
+}
+
 func (idt *IndexedDoltTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 	return sqlutil.NewSinglePartitionIter(), nil
 }
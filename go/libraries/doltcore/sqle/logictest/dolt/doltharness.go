@@ -335,7 +335,7 @@ func resetEnv(root *doltdb.RootValue) *doltdb.RootValue {
 
 func sqlNewEngine(dEnv *env.DoltEnv) (*sqle.Engine, error) {
 	db := dsql.NewDatabase("dolt", dEnv.DoltDB, dEnv.RepoState, dEnv.RepoStateWriter())
-	engine := sqle.NewDefault()
+	engine := dsql.NewDefaultEngine()
 	engine.AddDatabase(db)
 
 	return engine, nil
@@ -0,0 +1,307 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// The engine does not support stored procedures, so CREATE PROCEDURE and DROP PROCEDURE statements are executed with
+// the other extended DDL statements, and CALL statements are expanded into the statements of the procedure's body
+// before they're run. Procedures are stored in the dolt_schemas table as they were created, and are parsed again when
+// they're called. The supported statements are:
+//
+//   CREATE [DEFINER = user] PROCEDURE [db.]p ([IN] param type, ...) [characteristic ...] body
+//   DROP PROCEDURE [IF EXISTS] [db.]p
+//   CALL [db.]p[(arg, ...)]
+//
+// where the body is a single statement, or BEGIN statement; ... END. Parameters are replaced with the arguments of the
+// call wherever they're used as unqualified identifiers in the body. DELIMITER isn't supported, so a BEGIN ... END body
+// has to be run as a single query rather than in batch input, which is split into statements at every semicolon.
+
+// storedProcedure is a parsed CREATE PROCEDURE statement.
+type storedProcedure struct {
+	name   string
+	params []string
+	body   []string
+}
+
+// isCreateProcedure returns whether the tokens given are those of a CREATE PROCEDURE statement, and if so, the index of
+// the PROCEDURE token.
+func isCreateProcedure(toks []ddlToken) (int, bool) {
+	if len(toks) < 3 || toks[0].typ != sqlparser.CREATE {
+		return 0, false
+	}
+	if isWordToken(toks, 1, "procedure") {
+		return 1, true
+	}
+	if !isWordToken(toks, 1, "definer") {
+		return 0, false
+	}
+	// the definer is an account name, such as 'user'@'host' or CURRENT_USER()
+	for i := 2; i < len(toks) && i < 8; i++ {
+		if isWordToken(toks, i, "procedure") {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// parseCreateProcedureExtended parses a CREATE PROCEDURE statement.
+func parseCreateProcedureExtended(query string, toks []ddlToken) (extendedDDLFunc, bool, error) {
+	dbName, proc, err := parseCreateProcedure(query, toks)
+	if err != nil {
+		return nil, true, err
+	}
+	definition := strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")
+
+	return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+		db, err := ddlDatabase(ctx, e, dbName)
+		if err != nil {
+			return true, err
+		}
+		return true, db.CreateProcedure(ctx, ProcedureDefinition{Name: proc.name, CreateStatement: definition})
+	}, true, nil
+}
+
+// parseCreateProcedure parses the CREATE PROCEDURE statement given, returning the procedure and the name of the
+// database it's created in, which is empty for the current database.
+func parseCreateProcedure(query string, toks []ddlToken) (string, storedProcedure, error) {
+	i, _ := isCreateProcedure(toks)
+	dbName, name, i, ok := parseDDLTableName(toks, i+1)
+	if !ok || i >= len(toks) || toks[i].typ != '(' {
+		return "", storedProcedure{}, fmt.Errorf("expected a parameter list after PROCEDURE %s", name)
+	}
+	proc := storedProcedure{name: name}
+
+	paramsEnd := matchParen(toks, i)
+	if paramsEnd < 0 {
+		return "", storedProcedure{}, fmt.Errorf("unbalanced parentheses in parameter list of PROCEDURE %s", name)
+	}
+	for start := i + 1; start < paramsEnd; {
+		end := definitionEnd(toks, start, paramsEnd)
+		j := start
+		if isWordToken(toks, j, "out") || isWordToken(toks, j, "inout") {
+			return "", storedProcedure{}, fmt.Errorf("only IN parameters are supported in PROCEDURE %s", name)
+		} else if toks[j].typ == sqlparser.IN {
+			j++
+		}
+		if j+1 >= end || toks[j].val == "" {
+			return "", storedProcedure{}, fmt.Errorf("expected a parameter name and type in PROCEDURE %s", name)
+		}
+		proc.params = append(proc.params, toks[j].val)
+		start = end + 1
+	}
+
+	i = skipProcedureCharacteristics(toks, paramsEnd+1)
+	if i >= len(toks) {
+		return "", storedProcedure{}, fmt.Errorf("PROCEDURE %s has no body", name)
+	}
+
+	if toks[i].typ != sqlparser.BEGIN {
+		proc.body = []string{ddlText(query, toks, i, len(toks)-1)}
+	} else {
+		last := len(toks) - 1
+		if toks[last].typ != sqlparser.END {
+			return "", storedProcedure{}, fmt.Errorf("expected END at the end of PROCEDURE %s", name)
+		}
+		start := i + 1
+		for j := start; j <= last; j++ {
+			if j == last || toks[j].typ == ';' {
+				if j > start {
+					proc.body = append(proc.body, ddlText(query, toks, start, j-1))
+				}
+				start = j + 1
+			}
+		}
+	}
+	if len(proc.body) == 0 {
+		return "", storedProcedure{}, fmt.Errorf("PROCEDURE %s has no body", name)
+	}
+
+	for _, stmt := range proc.body {
+		if MayBeExtendedDDL(stmt) {
+			continue
+		}
+		if _, err := sqlparser.Parse(stmt); err != nil {
+			return "", storedProcedure{}, fmt.Errorf("invalid statement in PROCEDURE %s: %w", name, err)
+		}
+	}
+
+	return dbName, proc, nil
+}
+
+// skipProcedureCharacteristics returns the index of the first token from toks[i] that isn't part of a procedure
+// characteristic, such as COMMENT 'text' or READS SQL DATA.
+func skipProcedureCharacteristics(toks []ddlToken, i int) int {
+	for i < len(toks) {
+		switch {
+		case toks[i].typ == sqlparser.COMMENT_KEYWORD && i+1 < len(toks) && toks[i+1].typ == sqlparser.STRING:
+			i += 2
+		case isWordToken(toks, i, "language") && isWordToken(toks, i+1, "sql"):
+			i += 2
+		case isWordToken(toks, i, "deterministic"):
+			i++
+		case toks[i].typ == sqlparser.NOT && isWordToken(toks, i+1, "deterministic"):
+			i += 2
+		case isWordToken(toks, i, "contains") && isWordToken(toks, i+1, "sql"),
+			isWordToken(toks, i, "no") && isWordToken(toks, i+1, "sql"):
+			i += 2
+		case (isWordToken(toks, i, "reads") || isWordToken(toks, i, "modifies")) && isWordToken(toks, i+1, "sql") && isWordToken(toks, i+2, "data"):
+			i += 3
+		case isWordToken(toks, i, "sql") && isWordToken(toks, i+1, "security") &&
+			(isWordToken(toks, i+2, "definer") || isWordToken(toks, i+2, "invoker")):
+			i += 3
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// parseDropProcedureExtended parses a DROP PROCEDURE statement.
+func parseDropProcedureExtended(toks []ddlToken) (extendedDDLFunc, bool, error) {
+	i := 2
+	ifExists := false
+	if i+1 < len(toks) && toks[i].typ == sqlparser.IF && toks[i+1].typ == sqlparser.EXISTS {
+		ifExists = true
+		i += 2
+	}
+	dbName, name, i, ok := parseDDLTableName(toks, i)
+	if !ok || i != len(toks) {
+		return nil, true, fmt.Errorf("expected a procedure name after DROP PROCEDURE")
+	}
+
+	return func(ctx *sql.Context, e *sqle.Engine) (bool, error) {
+		db, err := ddlDatabase(ctx, e, dbName)
+		if err != nil {
+			return true, err
+		}
+		err = db.DropProcedure(ctx, name)
+		if ifExists && ErrProcedureDoesNotExist.Is(err) {
+			return true, nil
+		}
+		return true, err
+	}, true, nil
+}
+
+// IsCall returns whether the query given is a CALL statement.
+func IsCall(query string) bool {
+	toks, ok := tokenizeDDL(query)
+	return ok && len(toks) > 1 && isWordToken(toks, 0, "call")
+}
+
+// ExpandCall returns the statement to run in place of the query given if it's a CALL statement, and the query itself
+// otherwise. The statements of the called procedure's body before its last are run here, and its last statement is
+// returned for the caller to run, so that its results are the results of the call.
+func ExpandCall(ctx *sql.Context, e *sqle.Engine, query string) (string, error) {
+	if !IsCall(query) {
+		return query, nil
+	}
+	toks, _ := tokenizeDDL(query)
+
+	dbName, name, i, ok := parseDDLTableName(toks, 1)
+	if !ok {
+		return "", fmt.Errorf("expected a procedure name after CALL")
+	}
+	var args []string
+	if i < len(toks) {
+		if toks[i].typ != '(' || matchParen(toks, i) != len(toks)-1 {
+			return "", fmt.Errorf("expected an argument list after CALL %s", name)
+		}
+		for start := i + 1; start < len(toks)-1; {
+			end := definitionEnd(toks, start, len(toks)-1)
+			args = append(args, ddlText(query, toks, start, end-1))
+			start = end + 1
+		}
+	}
+
+	db, err := ddlDatabase(ctx, e, dbName)
+	if err != nil {
+		return "", err
+	}
+	procs, err := db.GetProcedures(ctx)
+	if err != nil {
+		return "", err
+	}
+	var def ProcedureDefinition
+	for _, p := range procs {
+		if strings.EqualFold(p.Name, name) {
+			def = p
+		}
+	}
+	if def.Name == "" {
+		return "", ErrProcedureDoesNotExist.New(name)
+	}
+
+	defToks, ok := tokenizeDDL(def.CreateStatement)
+	if !ok {
+		return "", fmt.Errorf("invalid definition of PROCEDURE %s", name)
+	}
+	_, proc, err := parseCreateProcedure(def.CreateStatement, defToks)
+	if err != nil {
+		return "", err
+	}
+	if len(args) != len(proc.params) {
+		return "", fmt.Errorf("incorrect number of arguments for PROCEDURE %s; expected %d, got %d", proc.name, len(proc.params), len(args))
+	}
+
+	stmts := make([]string, len(proc.body))
+	for i, stmt := range proc.body {
+		stmts[i] = bindProcedureArgs(stmt, proc.params, args)
+	}
+	for _, stmt := range stmts[:len(stmts)-1] {
+		if handled, err := ExecuteExtendedDDL(ctx, e, stmt); err != nil {
+			return "", err
+		} else if handled {
+			continue
+		}
+		if err := queryDDL(ctx, e, stmt); err != nil {
+			return "", err
+		}
+	}
+	return stmts[len(stmts)-1], nil
+}
+
+// bindProcedureArgs returns the statement given with the arguments given in place of the parameters they're bound to.
+// Qualified identifiers and function names are not parameters.
+func bindProcedureArgs(stmt string, params, args []string) string {
+	toks, ok := tokenizeDDL(stmt)
+	if !ok {
+		return stmt
+	}
+
+	var edits []ddlEdit
+	for i, tok := range toks {
+		if tok.val == "" || tok.typ == sqlparser.STRING {
+			continue
+		}
+		if (i > 0 && toks[i-1].typ == '.') || (i+1 < len(toks) && (toks[i+1].typ == '.' || toks[i+1].typ == '(')) {
+			continue
+		}
+		for j, param := range params {
+			if strings.EqualFold(tok.val, param) {
+				edits = append(edits, ddlEdit{start: tok.start, end: tok.end, text: "(" + args[j] + ")"})
+				break
+			}
+		}
+	}
+	return applyDDLEdits(stmt, edits)
+}
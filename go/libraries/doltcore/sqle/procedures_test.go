@@ -0,0 +1,75 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+)
+
+func TestProcedures(t *testing.T) {
+	dEnv := dtestutils.CreateTestEnv()
+	root, err := dEnv.WorkingRoot(context.Background())
+	require.NoError(t, err)
+
+	root, err = ExecuteSql(dEnv, root, "CREATE TABLE t (pk INT PRIMARY KEY, v INT);\n"+
+		"CREATE PROCEDURE add_one(x INT) COMMENT 'adds one' SELECT x + 1;\n"+
+		"CREATE DEFINER = CURRENT_USER PROCEDURE insert_twice(IN pk INT, v INT) MODIFIES SQL DATA BEGIN INSERT INTO t VALUES (pk, v); INSERT INTO t VALUES (pk + 1, v * 2); END;\n"+
+		"CREATE PROCEDURE t_count() BEGIN SELECT COUNT(*) FROM t; END")
+	require.NoError(t, err)
+
+	// procedures are stored alongside views in dolt_schemas
+	rows, err := ExecuteSelect(dEnv, dEnv.DoltDB, root, "SELECT name FROM dolt_schemas WHERE type = 'procedure' ORDER BY name")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{"add_one"}, {"insert_twice"}, {"t_count"}}, rows)
+
+	rows, err = ExecuteSelect(dEnv, dEnv.DoltDB, root, "CALL add_one(41)")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int64(42)}}, rows)
+
+	root, err = ExecuteSql(dEnv, root, "CALL insert_twice(1, 10);\nCALL INSERT_TWICE(3, 5)")
+	require.NoError(t, err)
+	rows, err = ExecuteSelect(dEnv, dEnv.DoltDB, root, "SELECT pk, v FROM t ORDER BY pk")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(1), int32(10)}, {int32(2), int32(20)}, {int32(3), int32(5)}, {int32(4), int32(10)}}, rows)
+	rows, err = ExecuteSelect(dEnv, dEnv.DoltDB, root, "CALL t_count")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int64(4)}}, rows)
+
+	_, err = ExecuteSelect(dEnv, dEnv.DoltDB, root, "CALL add_one()")
+	assert.EqualError(t, err, "incorrect number of arguments for PROCEDURE add_one; expected 1, got 0")
+	_, err = ExecuteSelect(dEnv, dEnv.DoltDB, root, "CALL missing()")
+	assert.True(t, ErrProcedureDoesNotExist.Is(err))
+	_, err = ExecuteSql(dEnv, root, "CREATE PROCEDURE Add_One() SELECT 1")
+	assert.True(t, ErrProcedureExists.Is(err))
+	_, err = ExecuteSql(dEnv, root, "CREATE PROCEDURE p(OUT x INT) SELECT 1")
+	assert.EqualError(t, err, "only IN parameters are supported in PROCEDURE p")
+	_, err = ExecuteSql(dEnv, root, "CREATE PROCEDURE p() SELEC 1")
+	assert.Error(t, err)
+
+	root, err = ExecuteSql(dEnv, root, "DROP PROCEDURE add_one;\nDROP PROCEDURE IF EXISTS add_one")
+	require.NoError(t, err)
+	_, err = ExecuteSql(dEnv, root, "DROP PROCEDURE add_one")
+	assert.True(t, ErrProcedureDoesNotExist.Is(err))
+	rows, err = ExecuteSelect(dEnv, dEnv.DoltDB, root, "SELECT name FROM dolt_schemas WHERE type = 'procedure' ORDER BY name")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{"insert_twice"}, {"t_count"}}, rows)
+}
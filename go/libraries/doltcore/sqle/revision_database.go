@@ -0,0 +1,116 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+const (
+	// DbRevisionDelimiter separates a database name from the branch it is bound to, e.g. `mydb/feature-branch`
+	DbRevisionDelimiter = "/"
+	// DbCommitDelimiter separates a database name from the commit it is bound to, e.g. `mydb@HEAD~2`
+	DbCommitDelimiter = "@"
+)
+
+// SplitRevisionDbName splits a database name of the form `db/branch` or `db@commit` into the name of the underlying
+// database and the revision. isCommit is true when the revision names a commit rather than a branch. ok is false
+// when the name given does not name a revision.
+func SplitRevisionDbName(dbName string) (baseName, revision string, isCommit, ok bool) {
+	idx := strings.IndexAny(dbName, DbRevisionDelimiter+DbCommitDelimiter)
+	if idx <= 0 || idx == len(dbName)-1 {
+		return "", "", false, false
+	}
+
+	return dbName[:idx], dbName[idx+1:], dbName[idx:idx+1] == DbCommitDelimiter, true
+}
+
+// branchState is the repo state of a branch of a database, and the lock held while committing transactions to it.
+type branchState struct {
+	rsr    env.RepoStateReader
+	rsw    env.RepoStateWriter
+	txLock *sync.Mutex
+}
+
+// branchStates holds the state of each branch of a database which sessions are working on. The state of a branch is
+// shared by every session working on it, so that their transactions are merged when they're committed. The working
+// sets of branches other than the one checked out in the database's repo state are kept in memory, starting from the
+// roots of their head commits, for as long as the database is served.
+type branchStates struct {
+	ddb    *doltdb.DoltDB
+	base   branchState
+	mu     *sync.Mutex
+	states map[string]branchState
+}
+
+func newBranchStates(ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw env.RepoStateWriter, txLock *sync.Mutex) *branchStates {
+	return &branchStates{
+		ddb:    ddb,
+		base:   branchState{rsr, rsw, txLock},
+		mu:     &sync.Mutex{},
+		states: make(map[string]branchState),
+	}
+}
+
+// get returns the state of the branch given. The branch checked out in the database's repo state uses the database's
+// own repo state.
+func (bs *branchStates) get(ctx context.Context, branch ref.BranchRef) (branchState, error) {
+	if ref.Equals(branch, bs.base.rsr.CWBHeadRef()) {
+		return bs.base, nil
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if state, ok := bs.states[branch.GetPath()]; ok {
+		return state, nil
+	}
+
+	cm, err := bs.ddb.ResolveRef(ctx, branch)
+	if err != nil {
+		return branchState{}, err
+	}
+
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return branchState{}, err
+	}
+
+	rootHash, err := root.HashOf()
+	if err != nil {
+		return branchState{}, err
+	}
+
+	headSpec, _ := doltdb.NewCommitSpec("HEAD")
+	rs := env.NewMemoryRepoState(branch, headSpec, rootHash)
+	state := branchState{rs, rs, &sync.Mutex{}}
+	bs.states[branch.GetPath()] = state
+
+	return state, nil
+}
+
+// remove discards the working set of the branch given, such as when the branch is deleted.
+func (bs *branchStates) remove(branch ref.BranchRef) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	delete(bs.states, branch.GetPath())
+}
@@ -15,10 +15,12 @@
 package sqle
 
 import (
+	"context"
 	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -32,6 +34,12 @@ type doltTableRowIter struct {
 	nomsIter types.MapIterator
 	end      types.LesserValuable
 	nbf      *types.NomsBinFormat
+	keyless  bool
+
+	// repeatRow is returned repeatCount more times before the iterator advances, as the rows of keyless tables with a
+	// cardinality above 1 stand for several identical rows
+	repeatRow   sql.Row
+	repeatCount uint64
 }
 
 // Returns a new row iterator for the table given
@@ -74,11 +82,16 @@ func newRowIterator(tbl *DoltTable, ctx *sql.Context, partition *doltTablePartit
 		return nil, err
 	}
 
-	return &doltTableRowIter{table: tbl, rowData: rowData, ctx: ctx, nomsIter: mapIter, end: end, nbf: rowData.Format()}, nil
+	return &doltTableRowIter{table: tbl, rowData: rowData, ctx: ctx, nomsIter: mapIter, end: end, nbf: rowData.Format(), keyless: schema.IsKeyless(tbl.sch)}, nil
 }
 
 // Next returns the next row in this row iterator, or an io.EOF error if there aren't any more.
 func (itr *doltTableRowIter) Next() (sql.Row, error) {
+	if itr.repeatCount > 0 {
+		itr.repeatCount--
+		return itr.repeatRow.Copy(), nil
+	}
+
 	key, val, err := itr.nomsIter.Next(itr.ctx)
 
 	if err != nil {
@@ -113,7 +126,27 @@ func (itr *doltTableRowIter) Next() (sql.Row, error) {
 		return nil, err
 	}
 
-	return sqlRowFromNomsTupleValueSlices(keySl, valSl, itr.table.sch)
+	r, err := sqlRowFromNomsTupleValueSlices(itr.ctx, keySl, valSl, itr.table.sch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if itr.keyless {
+		card, err := row.ParseCardinality(val.(types.Tuple))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if card > 1 {
+			itr.repeatRow = r
+			itr.repeatCount = card - 1
+			return r.Copy(), nil
+		}
+	}
+
+	return r, nil
 }
 
 // Close required by sql.RowIter interface
@@ -121,7 +154,7 @@ func (itr *doltTableRowIter) Close() error {
 	return nil
 }
 
-func sqlRowFromNomsTupleValueSlices(keySl, valSl types.TupleValueSlice, sch schema.Schema) (sql.Row, error) {
+func sqlRowFromNomsTupleValueSlices(ctx context.Context, keySl, valSl types.TupleValueSlice, sch schema.Schema) (sql.Row, error) {
 	allCols := sch.GetAllCols()
 	colVals := make(sql.Row, allCols.Size())
 
@@ -130,7 +163,7 @@ func sqlRowFromNomsTupleValueSlices(keySl, valSl types.TupleValueSlice, sch sche
 		err := sl.Iter(func(tag uint64, val types.Value) (stop bool, err error) {
 			if idx, ok := allCols.TagToIdx[tag]; ok {
 				col := allCols.GetByIndex(idx)
-				colVals[idx], convErr = col.TypeInfo.ConvertNomsValueToValue(val)
+				colVals[idx], convErr = col.TypeInfo.ConvertNomsValueToValue(ctx, val)
 
 				if convErr != nil {
 					return false, err
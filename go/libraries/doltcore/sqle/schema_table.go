@@ -19,6 +19,7 @@ import (
 	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
@@ -27,6 +28,16 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+// The types of the fragments stored in the `dolt_schemas` table.
+const (
+	viewFragment      = "view"
+	triggerFragment   = "trigger"
+	procedureFragment = "procedure"
+)
+
+var ErrProcedureExists = errors.NewKind("procedure `%s` already exists")
+var ErrProcedureDoesNotExist = errors.NewKind("procedure `%s` does not exist")
+
 // The fixed SQL schema for the `dolt_schemas` table.
 func SchemasTableSqlSchema() sql.Schema {
 	sqlSchema, err := sqlutil.FromDoltSchema(doltdb.SchemasTableName, SchemasTableSchema())
@@ -161,7 +172,7 @@ func migrateOldSchemasTableToNew(
 		if err != nil {
 			return err
 		}
-		sqlRow, err := sqlutil.DoltRowToSqlRow(dRow, schemasTable.sch)
+		sqlRow, err := sqlutil.DoltRowToSqlRow(ctx, dRow, schemasTable.sch)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,54 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaDiffTable(t *testing.T) {
+	ts := newTestSession(t)
+	ts.query("CREATE TABLE a (pk INT PRIMARY KEY, c1 INT)")
+
+	diffQuery := "SELECT table_name, object_type, object_name, diff_type FROM dolt_schema_diff WHERE from_commit = 'master' AND to_commit = 'working'"
+	assert.Equal(t, []sql.Row{{"a", "table", "a", "added"}}, ts.query(diffQuery))
+
+	ts.commit("add a")
+	rows := ts.query("SELECT table_name, committer, create_statement FROM dolt_schema_history")
+	require.Len(t, rows, 1)
+	assert.Equal(t, "a", rows[0][0])
+	assert.Equal(t, "Bill Billerson", rows[0][1])
+	assert.True(t, strings.HasPrefix(rows[0][2].(string), "CREATE TABLE `a`"))
+
+	ts.query("ALTER TABLE a ADD COLUMN c2 INT")
+	ts.query("CREATE INDEX c1_idx ON a (c1)")
+	assert.Equal(t, []sql.Row{
+		{"a", "table", "a", "modified"},
+		{"a", "column", "c2", "added"},
+		{"a", "index", "c1_idx", "added"},
+	}, ts.query(diffQuery))
+
+	rows = ts.query("SELECT from_definition, to_definition FROM dolt_schema_diff WHERE from_commit = 'master' AND to_commit = 'working' AND object_type = 'column'")
+	require.Len(t, rows, 1)
+	assert.Nil(t, rows[0][0])
+	assert.True(t, strings.HasPrefix(rows[0][1].(string), "`c2` INT"))
+
+	assert.Error(t, ts.exec("SELECT * FROM dolt_schema_diff WHERE from_commit = 'master'"))
+}
@@ -17,9 +17,13 @@ package sqle
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 )
 
 // These functions cannot be in the sqlfmt package as the reliance on the sqle package creates a circular reference.
@@ -30,7 +34,7 @@ func PrepareCreateTableStmt(ctx context.Context, sqlDb sql.Database) (*sql.Conte
 		sql.WithSession(dsess),
 		sql.WithIndexRegistry(sql.NewIndexRegistry()),
 		sql.WithViewRegistry(sql.NewViewRegistry()))
-	engine := sqle.NewDefault()
+	engine := NewDefaultEngine()
 	engine.AddDatabase(sqlDb)
 	dsess.SetCurrentDatabase(sqlDb.Name())
 	return sqlCtx, engine, dsess
@@ -52,5 +56,78 @@ func GetCreateTableStmt(ctx *sql.Context, engine *sqle.Engine, tableName string)
 	if !ok {
 		return "", fmt.Errorf("expected string statement from SHOW CREATE TABLE")
 	}
+
+	stmt, err = addDoltSchemaClauses(ctx, engine, tableName, stmt)
+	if err != nil {
+		return "", err
+	}
 	return stmt + ";", nil
 }
+
+// doltSchemaTable is a table with a dolt schema, whose generated columns and check constraints are added to its CREATE
+// TABLE statement. The engine doesn't know about either, so SHOW CREATE TABLE can't include them itself.
+type doltSchemaTable interface {
+	doltSchema() schema.Schema
+}
+
+func addDoltSchemaClauses(ctx *sql.Context, engine *sqle.Engine, tableName, stmt string) (string, error) {
+	sqlDb, err := engine.Catalog.Database(ctx.GetCurrentDatabase())
+	if err != nil {
+		return "", err
+	}
+	tbl, ok, err := sqlDb.GetTableInsensitive(ctx, tableName)
+	if err != nil || !ok {
+		return stmt, err
+	}
+	dt, ok := tbl.(doltSchemaTable)
+	if !ok {
+		return stmt, nil
+	}
+
+	stmt, err = addGeneratedColumns(dt.doltSchema(), tableName, stmt)
+	if err != nil {
+		return "", err
+	}
+	return appendCheckConstraints(dt.doltSchema(), tableName, stmt)
+}
+
+// addGeneratedColumns adds the generation clauses of the generated columns of the schema given to their definitions.
+// The engine is given generated columns as nullable, so their NOT NULL constraints are added here too.
+func addGeneratedColumns(sch schema.Schema, tableName, stmt string) (string, error) {
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if !col.IsGenerated() {
+			continue
+		}
+		colDef := fmt.Sprintf("\n  `%s` %s", col.Name, strings.ToLower(col.TypeInfo.ToSqlType().String()))
+		idx := strings.Index(stmt, colDef)
+		if idx < 0 {
+			return "", fmt.Errorf("unexpected CREATE TABLE statement for table %s", tableName)
+		}
+		clause := " " + sqlfmt.FmtGenerated(col)
+		if !col.IsNullable() {
+			clause += " NOT NULL"
+		}
+		end := idx + len(colDef)
+		stmt = stmt[:end] + clause + stmt[end:]
+	}
+	return stmt, nil
+}
+
+func appendCheckConstraints(sch schema.Schema, tableName, stmt string) (string, error) {
+	if sch.Checks().Count() == 0 {
+		return stmt, nil
+	}
+
+	end := strings.LastIndex(stmt, "\n)")
+	if end < 0 {
+		return "", fmt.Errorf("unexpected CREATE TABLE statement for table %s", tableName)
+	}
+	var sb strings.Builder
+	sb.WriteString(stmt[:end])
+	for _, check := range sch.Checks().AllChecks() {
+		sb.WriteString(",\n  ")
+		sb.WriteString(sqlfmt.FmtCheck(check))
+	}
+	sb.WriteString(stmt[end:])
+	return sb.String(), nil
+}
@@ -83,6 +83,10 @@ func (db *SingleTableInfoDatabase) Schema() sql.Schema {
 	return sqlSch
 }
 
+func (db *SingleTableInfoDatabase) doltSchema() schema.Schema {
+	return db.sch
+}
+
 // Partitions implements sql.Table.
 func (db *SingleTableInfoDatabase) Partitions(*sql.Context) (sql.PartitionIter, error) {
 	return nil, fmt.Errorf("cannot get paritions of a single table information database")
@@ -60,6 +60,14 @@ func TestCreateTable(t *testing.T) {
 				schemaNewColumn(t, "id", 4817, sql.Int32, true, schema.NotNullConstraint{}),
 				schemaNewColumn(t, "age", 7208, sql.Int32, false)),
 		},
+		{
+			name:          "Test create json column",
+			query:         "create table testTable (id int primary key, doc json)",
+			expectedTable: "testTable",
+			expectedSchema: dtestutils.CreateSchema(
+				schemaNewColumn(t, "id", 4817, sql.Int32, true, schema.NotNullConstraint{}),
+				schemaNewColumn(t, "doc", 5315, sql.JSON, false)),
+		},
 		{
 			name:          "Test syntax error",
 			query:         "create table testTable id int, age int",
@@ -67,9 +75,12 @@ func TestCreateTable(t *testing.T) {
 			expectedErr:   "syntax error",
 		},
 		{
-			name:        "Test no primary keys",
-			query:       "create table testTable (id int, age int)",
-			expectedErr: "no primary key columns",
+			name:          "Test no primary keys",
+			query:         "create table testTable (id int, age int)",
+			expectedTable: "testTable",
+			expectedSchema: dtestutils.CreateSchema(
+				schemaNewColumn(t, "id", 4817, sql.Int32, false),
+				schemaNewColumn(t, "age", 7208, sql.Int32, false)),
 		},
 		{
 			name:        "Test bad table name",
@@ -610,9 +621,24 @@ func TestModifyAndChangeColumn(t *testing.T) {
 			expectedErr: "incompatible type for default value",
 		},
 		{
-			name:        "alter modify column with type change",
-			query:       "alter table people modify rating varchar(10)",
-			expectedErr: "column types cannot be changed",
+			name:  "alter modify column with type change",
+			query: "alter table people modify first_name varchar(100) not null",
+			expectedSchema: dtestutils.CreateSchema(
+				schema.NewColumn("id", IdTag, types.IntKind, true, schema.NotNullConstraint{}),
+				schemaNewColumn(t, "first_name", FirstNameTag, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 100), false, schema.NotNullConstraint{}),
+				schema.NewColumn("last_name", LastNameTag, types.StringKind, false, schema.NotNullConstraint{}),
+				schema.NewColumn("is_married", IsMarriedTag, types.BoolKind, false),
+				schema.NewColumn("age", AgeTag, types.IntKind, false),
+				schema.NewColumn("rating", RatingTag, types.FloatKind, false),
+				schema.NewColumn("uuid", UuidTag, types.UUIDKind, false),
+				schema.NewColumn("num_episodes", NumEpisodesTag, types.UintKind, false),
+			),
+			expectedRows: AllPeopleRows,
+		},
+		{
+			name:        "alter modify column with type change, values don't fit",
+			query:       "alter table people modify first_name varchar(3) not null",
+			expectedErr: "cannot convert value",
 		},
 		{
 			name:        "alter modify column not null, existing null values",
@@ -1058,9 +1084,12 @@ func TestParseCreateTableStatement(t *testing.T) {
 			expectedErr:   "syntax error",
 		},
 		{
-			name:        "Test no primary keys",
-			query:       "create table testTable (id int, age int)",
-			expectedErr: "no primary key columns",
+			name:          "Test no primary keys",
+			query:         "create table testTable (id int, age int)",
+			expectedTable: "testTable",
+			expectedSchema: dtestutils.CreateSchema(
+				schemaNewColumn(t, "id", 4817, sql.Int32, false),
+				schemaNewColumn(t, "age", 7208, sql.Int32, false)),
 		},
 		{
 			name:        "Test bad table name begins with number",
@@ -1383,10 +1412,9 @@ INSERT INTO fail_unique VALUES (1, 1, 1), (2, 2, 2), (3, 2, 3);
 }
 
 func schemasTableDoltSchema() schema.Schema {
-	// this is a dummy test environment and will not be used,
-	// dolt_schema table tags will be parsed from the comments in SchemaTableSchema()
-	testEnv := dtestutils.CreateTestEnv()
-	return mustGetDoltSchema(SchemasTableSqlSchema(), doltdb.SchemasTableName, testEnv)
+	// the fragment columns are LONGTEXT, which would be read back from the SQL schema as blobs, so the dolt schema
+	// is used as it is stored
+	return SchemasTableSchema()
 }
 
 func assertFails(t *testing.T, dEnv *env.DoltEnv, query, expectedErr string) {
@@ -16,11 +16,13 @@ package sqlfmt
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/geometry"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
@@ -39,7 +41,7 @@ func QuoteComment(s string) string {
 	return `'` + strings.ReplaceAll(s, `'`, `\'`) + `'`
 }
 
-func RowAsInsertStmt(r row.Row, tableName string, tableSch schema.Schema) (string, error) {
+func RowAsInsertStmt(ctx context.Context, r row.Row, tableName string, tableSch schema.Schema) (string, error) {
 	var b strings.Builder
 	b.WriteString("INSERT INTO ")
 	b.WriteString(QuoteIdentifier(tableName))
@@ -69,7 +71,7 @@ func RowAsInsertStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 			b.WriteRune(',')
 		}
 		col, _ := tableSch.GetAllCols().GetByTag(tag)
-		sqlString, err := valueAsSqlString(col.TypeInfo, val)
+		sqlString, err := valueAsSqlString(ctx, col.TypeInfo, val)
 		if err != nil {
 			return true, err
 		}
@@ -87,27 +89,34 @@ func RowAsInsertStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 	return b.String(), nil
 }
 
-func RowAsDeleteStmt(r row.Row, tableName string, tableSch schema.Schema) (string, error) {
+func RowAsDeleteStmt(ctx context.Context, r row.Row, tableName string, tableSch schema.Schema) (string, error) {
 	var b strings.Builder
 	b.WriteString("DELETE FROM ")
 	b.WriteString(QuoteIdentifier(tableName))
 
+	// rows of keyless tables are matched on every column, and only one of any identical rows is deleted
+	keyless := schema.IsKeyless(tableSch)
+
 	b.WriteString(" WHERE (")
 	seenOne := false
 	_, err := r.IterSchema(tableSch, func(tag uint64, val types.Value) (stop bool, err error) {
 		col, _ := tableSch.GetAllCols().GetByTag(tag)
-		if col.IsPartOfPK {
+		if col.IsPartOfPK || keyless {
 			if seenOne {
 				b.WriteString(" AND ")
 			}
-			sqlString, err := valueAsSqlString(col.TypeInfo, val)
+			seenOne = true
+			b.WriteString(QuoteIdentifier(col.Name))
+			if types.IsNull(val) {
+				b.WriteString(" IS NULL")
+				return false, nil
+			}
+			sqlString, err := valueAsSqlString(ctx, col.TypeInfo, val)
 			if err != nil {
 				return true, err
 			}
-			b.WriteString(QuoteIdentifier(col.Name))
 			b.WriteRune('=')
 			b.WriteString(sqlString)
-			seenOne = true
 		}
 		return false, nil
 	})
@@ -116,11 +125,15 @@ func RowAsDeleteStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 		return "", err
 	}
 
-	b.WriteString(");")
+	b.WriteString(")")
+	if keyless {
+		b.WriteString(" LIMIT 1")
+	}
+	b.WriteString(";")
 	return b.String(), nil
 }
 
-func RowAsUpdateStmt(r row.Row, tableName string, tableSch schema.Schema) (string, error) {
+func RowAsUpdateStmt(ctx context.Context, r row.Row, tableName string, tableSch schema.Schema) (string, error) {
 	var b strings.Builder
 	b.WriteString("UPDATE ")
 	b.WriteString(QuoteIdentifier(tableName))
@@ -134,7 +147,7 @@ func RowAsUpdateStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 			if seenOne {
 				b.WriteRune(',')
 			}
-			sqlString, err := valueAsSqlString(col.TypeInfo, val)
+			sqlString, err := valueAsSqlString(ctx, col.TypeInfo, val)
 			if err != nil {
 				return true, err
 			}
@@ -158,7 +171,7 @@ func RowAsUpdateStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 			if seenOne {
 				b.WriteString(" AND ")
 			}
-			sqlString, err := valueAsSqlString(col.TypeInfo, val)
+			sqlString, err := valueAsSqlString(ctx, col.TypeInfo, val)
 			if err != nil {
 				return true, err
 			}
@@ -178,12 +191,12 @@ func RowAsUpdateStmt(r row.Row, tableName string, tableSch schema.Schema) (strin
 	return b.String(), nil
 }
 
-func valueAsSqlString(ti typeinfo.TypeInfo, value types.Value) (string, error) {
+func valueAsSqlString(ctx context.Context, ti typeinfo.TypeInfo, value types.Value) (string, error) {
 	if types.IsNull(value) {
 		return "NULL", nil
 	}
 
-	str, err := ti.FormatValue(value)
+	str, err := ti.FormatValue(ctx, value)
 
 	if err != nil {
 		return "", err
@@ -210,6 +223,15 @@ func valueAsSqlString(ti typeinfo.TypeInfo, value types.Value) (string, error) {
 			return "", fmt.Errorf("typeinfo.VarStringTypeIdentifier is not types.String")
 		}
 		return quoteAndEscapeString(string(s)), nil
+	case typeinfo.BlobStringTypeIdentifier, typeinfo.VarBinaryTypeIdentifier, typeinfo.JSONTypeIdentifier:
+		return quoteAndEscapeString(*str), nil
+	case typeinfo.GeometryTypeIdentifier:
+		g, err := ti.ConvertNomsValueToValue(ctx, value)
+		if err != nil {
+			return "", err
+		}
+		geom := g.(geometry.Geometry)
+		return fmt.Sprintf("ST_GeomFromText(%s, %d)", quoteAndEscapeString(geometry.ToWKT(geom)), geom.GetSRID()), nil
 	default:
 		return *str, nil
 	}
@@ -15,6 +15,7 @@
 package sqlfmt
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -34,6 +35,8 @@ const expectedAddColSql = "ALTER TABLE `table_name` ADD `c0` BIGINT NOT NULL;"
 const expectedDropColSql = "ALTER TABLE `table_name` DROP `first_name`;"
 const expectedRenameColSql = "ALTER TABLE `table_name` RENAME COLUMN `id` TO `pk`;"
 const expectedRenameTableSql = "RENAME TABLE `table_name` TO `new_table_name`;"
+const expectedDropPrimaryKeySql = "ALTER TABLE `table_name` DROP PRIMARY KEY;"
+const expectedAddPrimaryKeySql = "ALTER TABLE `table_name` ADD PRIMARY KEY (`id`,`first_name`);"
 
 type test struct {
 	name           string
@@ -73,6 +76,14 @@ func TestAlterTableRenameColStmt(t *testing.T) {
 	assert.Equal(t, expectedRenameColSql, stmt)
 }
 
+func TestAlterTablePrimaryKeyStmts(t *testing.T) {
+	stmt := AlterTableDropPrimaryKeyStmt("table_name")
+	assert.Equal(t, expectedDropPrimaryKeySql, stmt)
+
+	stmt = AlterTableAddPrimaryKeyStmt("table_name", []string{"id", "first_name"})
+	assert.Equal(t, expectedAddPrimaryKeySql, stmt)
+}
+
 func TestRenameTableStmt(t *testing.T) {
 	stmt := RenameTableStmt("table_name", "new_table_name")
 
@@ -118,7 +129,7 @@ func TestRowAsInsertStmt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			stmt, err := RowAsInsertStmt(tt.row, tableName, tt.sch)
+			stmt, err := RowAsInsertStmt(context.Background(), tt.row, tableName, tt.sch)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedOutput, stmt)
 		})
@@ -131,6 +142,10 @@ func TestRowAsDeleteStmt(t *testing.T) {
 		schema.NewColumn("anotherCol", 0, types.FloatKind, false),
 		schema.NewColumn("a name with spaces", 1, types.IntKind, true),
 	)
+	keylessSch := dtestutils.CreateSchema(
+		schema.NewColumn("anotherCol", 0, types.FloatKind, false),
+		schema.NewColumn("a name with spaces", 1, types.IntKind, false),
+	)
 
 	tests := []test{
 		{
@@ -139,11 +154,17 @@ func TestRowAsDeleteStmt(t *testing.T) {
 			sch:            trickySch,
 			expectedOutput: "DELETE FROM `tricky` WHERE (`a name with spaces`=-42);",
 		},
+		{
+			name:           "keyless table",
+			row:            dtestutils.NewRow(keylessSch, types.Float(-3.14), types.NullValue),
+			sch:            keylessSch,
+			expectedOutput: "DELETE FROM `tricky` WHERE (`anotherCol`=-3.14 AND `a name with spaces` IS NULL) LIMIT 1;",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			stmt, err := RowAsDeleteStmt(tt.row, tableName, tt.sch)
+			stmt, err := RowAsDeleteStmt(context.Background(), tt.row, tableName, tt.sch)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedOutput, stmt)
 		})
@@ -189,7 +210,7 @@ func TestRowAsUpdateStmt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			stmt, err := RowAsUpdateStmt(tt.row, tableName, tt.sch)
+			stmt, err := RowAsUpdateStmt(context.Background(), tt.row, tableName, tt.sch)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedOutput, stmt)
 		})
@@ -240,7 +261,7 @@ func TestValueAsSqlString(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			act, err := valueAsSqlString(test.ti, test.val)
+			act, err := valueAsSqlString(context.Background(), test.ti, test.val)
 			require.NoError(t, err)
 			assert.Equal(t, test.exp, act)
 		})
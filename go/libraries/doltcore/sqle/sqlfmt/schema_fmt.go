@@ -23,7 +23,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 )
 
-//  FmtCol converts a column to a string with a given indent space count, name width, and type width.  If nameWidth or
+//	FmtCol converts a column to a string with a given indent space count, name width, and type width.  If nameWidth or
+//
 // typeWidth are 0 or less than the length of the name or type, then the length of the name or type will be used
 func FmtCol(indent, nameWidth, typeWidth int, col schema.Column) string {
 	sqlType := col.TypeInfo.ToSqlType()
@@ -38,6 +39,10 @@ func FmtColWithNameAndType(indent, nameWidth, typeWidth int, colName, typeStr st
 	fmtStr := fmt.Sprintf("%%%ds%%%ds %%%ds", indent, nameWidth, typeWidth)
 	colStr := fmt.Sprintf(fmtStr, "", colName, typeStr)
 
+	if col.IsGenerated() {
+		colStr += " " + FmtGenerated(col)
+	}
+
 	for _, cnst := range col.Constraints {
 		switch cnst.GetConstraintType() {
 		case schema.NotNullConstraintType:
@@ -125,6 +130,25 @@ func FmtForeignKey(fk doltdb.ForeignKey, sch, parentSch schema.Schema) string {
 	return sb.String()
 }
 
+// FmtGenerated formats the generation clause of a generated column as it's written in a CREATE TABLE statement.
+func FmtGenerated(col schema.Column) string {
+	return "GENERATED ALWAYS AS (" + col.Generated + ") STORED"
+}
+
+// FmtCheck formats a check constraint as it's written in a CREATE TABLE statement.
+func FmtCheck(check schema.Check) string {
+	sb := strings.Builder{}
+	sb.WriteString("CONSTRAINT ")
+	sb.WriteString(QuoteIdentifier(check.Name()))
+	sb.WriteString(" CHECK (")
+	sb.WriteString(check.Expression())
+	sb.WriteRune(')')
+	if !check.Enforced() {
+		sb.WriteString(" NOT ENFORCED")
+	}
+	return sb.String()
+}
+
 func DropTableStmt(tableName string) string {
 	var b strings.Builder
 	b.WriteString("DROP TABLE ")
@@ -184,6 +208,27 @@ func RenameTableStmt(fromName string, toName string) string {
 	return b.String()
 }
 
+func AlterTableDropPrimaryKeyStmt(tableName string) string {
+	var b strings.Builder
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(QuoteIdentifier(tableName))
+	b.WriteString(" DROP PRIMARY KEY;")
+	return b.String()
+}
+
+func AlterTableAddPrimaryKeyStmt(tableName string, pkColNames []string) string {
+	var b strings.Builder
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(QuoteIdentifier(tableName))
+	b.WriteString(" ADD PRIMARY KEY ")
+	var cols []string
+	for _, cn := range pkColNames {
+		cols = append(cols, QuoteIdentifier(cn))
+	}
+	b.WriteString("(" + strings.Join(cols, ",") + ");")
+	return b.String()
+}
+
 func AlterTableAddIndexStmt(tableName string, idx schema.Index) string {
 	var b strings.Builder
 	b.WriteString("ALTER TABLE ")
@@ -715,7 +715,7 @@ var BasicSelectTests = []SelectTest{
 				"0o2rnf5pq2s1nq3hj3609e1lt0socuf1",
 				"billy bob",
 				"bigbillieb@fake.horse",
-				time.Date(1970, 1, 1, 0, 0, 0, 0, &time.Location{}),
+				time.Unix(0, 0),
 				"Initialize data repository",
 			},
 		},
@@ -744,7 +744,7 @@ var BasicSelectTests = []SelectTest{
 				"master",
 				"0o2rnf5pq2s1nq3hj3609e1lt0socuf1",
 				"billy bob", "bigbillieb@fake.horse",
-				time.Date(1970, 1, 1, 0, 0, 0, 0, &time.Location{}),
+				time.Unix(0, 0),
 				"Initialize data repository",
 			},
 		},
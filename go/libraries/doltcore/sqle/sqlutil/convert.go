@@ -29,14 +29,14 @@ import (
 )
 
 // Returns a SQL row representation for the dolt row given.
-func DoltRowToSqlRow(doltRow row.Row, sch schema.Schema) (sql.Row, error) {
+func DoltRowToSqlRow(ctx context.Context, doltRow row.Row, sch schema.Schema) (sql.Row, error) {
 	colVals := make(sql.Row, sch.GetAllCols().Size())
 
 	i := 0
 	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		var innerErr error
 		value, _ := doltRow.GetColVal(tag)
-		colVals[i], innerErr = col.TypeInfo.ConvertNomsValueToValue(value)
+		colVals[i], innerErr = col.TypeInfo.ConvertNomsValueToValue(ctx, value)
 		if innerErr != nil {
 			return true, innerErr
 		}
@@ -51,8 +51,9 @@ func DoltRowToSqlRow(doltRow row.Row, sch schema.Schema) (sql.Row, error) {
 	return sql.NewRow(colVals...), nil
 }
 
-// Returns a Dolt row representation for SQL row given
-func SqlRowToDoltRow(nbf *types.NomsBinFormat, r sql.Row, doltSchema schema.Schema) (row.Row, error) {
+// Returns a Dolt row representation for SQL row given. Values which are stored out-of-line are written to the
+// ValueReadWriter given.
+func SqlRowToDoltRow(ctx context.Context, vrw types.ValueReadWriter, r sql.Row, doltSchema schema.Schema) (row.Row, error) {
 	taggedVals := make(row.TaggedValues)
 	allCols := doltSchema.GetAllCols()
 	for i, val := range r {
@@ -60,7 +61,7 @@ func SqlRowToDoltRow(nbf *types.NomsBinFormat, r sql.Row, doltSchema schema.Sche
 		schCol := allCols.TagToCol[tag]
 		if val != nil {
 			var err error
-			taggedVals[tag], err = schCol.TypeInfo.ConvertValueToNomsValue(val)
+			taggedVals[tag], err = schCol.TypeInfo.ConvertValueToNomsValue(ctx, vrw, val)
 			if err != nil {
 				return nil, err
 			}
@@ -68,7 +69,7 @@ func SqlRowToDoltRow(nbf *types.NomsBinFormat, r sql.Row, doltSchema schema.Sche
 			return nil, fmt.Errorf("column <%v> received nil but is non-nullable", schCol.Name)
 		}
 	}
-	return row.New(nbf, doltSchema, taggedVals)
+	return row.New(vrw.Format(), doltSchema, taggedVals)
 }
 
 // ToDoltResultSchema returns a dolt Schema from the sql schema given, suitable for use as a result set. For
@@ -97,16 +98,23 @@ func FromDoltSchema(tableName string, sch schema.Schema) (sql.Schema, error) {
 	var i int
 	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		sqlType := col.TypeInfo.ToSqlType()
+		var extra string
+		if col.IsGenerated() {
+			extra = "STORED GENERATED"
+		}
 		cols[i] = &sqle.ColumnWithRawDefault{
 			SqlColumn: &sql.Column{
-				Name:          col.Name,
-				Type:          sqlType,
-				Default:       nil,
-				Nullable:      col.IsNullable(),
+				Name:    col.Name,
+				Type:    sqlType,
+				Default: nil,
+				// the values of generated columns are computed when rows are written, so the engine must accept rows
+				// without them
+				Nullable:      col.IsNullable() || col.IsGenerated(),
 				Source:        tableName,
 				PrimaryKey:    col.IsPartOfPK,
 				AutoIncrement: col.AutoIncrement,
 				Comment:       col.Comment,
+				Extra:         extra,
 			},
 			Default: col.Default,
 		}
@@ -15,6 +15,8 @@
 package sqlutil
 
 import (
+	"github.com/dolthub/dolt/go/store/types"
+
 	"context"
 
 	sqle "github.com/dolthub/go-mysql-server"
@@ -28,7 +30,7 @@ import (
 )
 
 // ApplyDefaults applies the default values to the given indices, returning the resulting row.
-func ApplyDefaults(ctx context.Context, doltSchema schema.Schema, sqlSchema sql.Schema, indicesOfColumns []int, dRow row.Row) (row.Row, error) {
+func ApplyDefaults(ctx context.Context, vrw types.ValueReadWriter, doltSchema schema.Schema, sqlSchema sql.Schema, indicesOfColumns []int, dRow row.Row) (row.Row, error) {
 	if len(indicesOfColumns) == 0 {
 		return dRow, nil
 	}
@@ -42,7 +44,7 @@ func ApplyDefaults(ctx context.Context, doltSchema schema.Schema, sqlSchema sql.
 		val, ok := dRow.GetColVal(tag)
 		if ok {
 			var err error
-			oldSqlRow[i], err = doltCols.TagToCol[tag].TypeInfo.ConvertNomsValueToValue(val)
+			oldSqlRow[i], err = doltCols.TagToCol[tag].TypeInfo.ConvertNomsValueToValue(ctx, val)
 			if err != nil {
 				return nil, err
 			}
@@ -59,7 +61,7 @@ func ApplyDefaults(ctx context.Context, doltSchema schema.Schema, sqlSchema sql.
 		if newSqlRow[i] == nil {
 			continue
 		}
-		val, err := doltCols.TagToCol[tag].TypeInfo.ConvertValueToNomsValue(newSqlRow[i])
+		val, err := doltCols.TagToCol[tag].TypeInfo.ConvertValueToNomsValue(ctx, vrw, newSqlRow[i])
 		if err != nil {
 			return nil, err
 		}
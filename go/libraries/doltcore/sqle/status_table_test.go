@@ -0,0 +1,45 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusTable(t *testing.T) {
+	ts := newTestSession(t)
+	assert.Empty(t, ts.query("SELECT * FROM dolt_status"))
+
+	ts.query("CREATE TABLE a (pk INT PRIMARY KEY)")
+	dSess := DSessFromSess(ts.ctx.Session)
+	staged, ok := dSess.GetRoot("dolt")
+	require.True(t, ok)
+	dSess.SetStagedRoot("dolt", staged)
+
+	ts.query("INSERT INTO a VALUES (1)")
+	ts.query("CREATE TABLE b (pk INT PRIMARY KEY)")
+
+	expected := []sql.Row{
+		{"a", true, "new table"},
+		{"a", false, "modified"},
+		{"b", false, "new table"},
+	}
+	assert.Equal(t, expected, ts.query("SELECT * FROM dolt_status"))
+	assert.Equal(t, []sql.Row{{"b"}}, ts.query("SELECT table_name FROM dolt_status WHERE status = 'new table' AND NOT staged"))
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 )
 
@@ -33,8 +34,11 @@ import (
 // editor after every SQL statement is incorrect and will return incorrect results. The single reliable exception is an
 // unbroken chain of INSERT statements, where we have taken pains to batch writes to speed things up.
 type sqlTableEditor struct {
+	ctx         *sql.Context
 	t           *WritableDoltTable
 	tableEditor *doltdb.SessionedTableEditor
+	generated   *expreval.GeneratedEvaluator
+	checks      *expreval.CheckEvaluator
 }
 
 var _ sql.RowReplacer = (*sqlTableEditor)(nil)
@@ -47,23 +51,40 @@ func newSqlTableEditor(ctx *sql.Context, t *WritableDoltTable) (*sqlTableEditor,
 	if err != nil {
 		return nil, err
 	}
+	generated, err := expreval.NewGeneratedEvaluator(t.table.ValueReadWriter(), t.sch)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := expreval.NewCheckEvaluator(t.sch)
+	if err != nil {
+		return nil, err
+	}
 	return &sqlTableEditor{
+		ctx:         ctx,
 		t:           t,
 		tableEditor: tableEditor,
+		generated:   generated,
+		checks:      checks,
 	}, nil
 }
 
 func (te *sqlTableEditor) Insert(ctx *sql.Context, sqlRow sql.Row) error {
-	dRow, err := sqlutil.SqlRowToDoltRow(te.t.table.Format(), sqlRow, te.t.sch)
+	dRow, err := sqlutil.SqlRowToDoltRow(ctx, te.t.table.ValueReadWriter(), sqlRow, te.t.sch)
 	if err != nil {
 		return err
 	}
+	if dRow, err = te.generated.Generate(ctx, dRow); err != nil {
+		return err
+	}
+	if err = te.checks.Check(ctx, dRow); err != nil {
+		return err
+	}
 
 	return te.tableEditor.InsertRow(ctx, dRow)
 }
 
 func (te *sqlTableEditor) Delete(ctx *sql.Context, sqlRow sql.Row) error {
-	dRow, err := sqlutil.SqlRowToDoltRow(te.t.table.Format(), sqlRow, te.t.sch)
+	dRow, err := sqlutil.SqlRowToDoltRow(ctx, te.t.table.ValueReadWriter(), sqlRow, te.t.sch)
 	if err != nil {
 		return err
 	}
@@ -72,25 +93,31 @@ func (te *sqlTableEditor) Delete(ctx *sql.Context, sqlRow sql.Row) error {
 }
 
 func (te *sqlTableEditor) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) error {
-	dOldRow, err := sqlutil.SqlRowToDoltRow(te.t.table.Format(), oldRow, te.t.sch)
+	dOldRow, err := sqlutil.SqlRowToDoltRow(ctx, te.t.table.ValueReadWriter(), oldRow, te.t.sch)
 	if err != nil {
 		return err
 	}
-	dNewRow, err := sqlutil.SqlRowToDoltRow(te.t.table.Format(), newRow, te.t.sch)
+	dNewRow, err := sqlutil.SqlRowToDoltRow(ctx, te.t.table.ValueReadWriter(), newRow, te.t.sch)
 	if err != nil {
 		return err
 	}
+	if dNewRow, err = te.generated.Generate(ctx, dNewRow); err != nil {
+		return err
+	}
+	if err = te.checks.Check(ctx, dNewRow); err != nil {
+		return err
+	}
 
 	return te.tableEditor.UpdateRow(ctx, dOldRow, dNewRow)
 }
 
 func (te *sqlTableEditor) GetAutoIncrementValue() (interface{}, error) {
 	val := te.tableEditor.GetAutoIncrementValue()
-	return te.t.DoltTable.autoIncCol.TypeInfo.ConvertNomsValueToValue(val)
+	return te.t.DoltTable.autoIncCol.TypeInfo.ConvertNomsValueToValue(te.ctx, val)
 }
 
 func (te *sqlTableEditor) SetAutoIncrementValue(ctx *sql.Context, val interface{}) error {
-	nomsVal, err := te.t.DoltTable.autoIncCol.TypeInfo.ConvertValueToNomsValue(val)
+	nomsVal, err := te.t.DoltTable.autoIncCol.TypeInfo.ConvertValueToNomsValue(ctx, te.t.table.ValueReadWriter(), val)
 	if err != nil {
 		return err
 	}
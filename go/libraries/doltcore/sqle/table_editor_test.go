@@ -189,7 +189,7 @@ func TestTableEditor(t *testing.T) {
 }
 
 func r(row row.Row, sch schema.Schema) sql.Row {
-	sqlRow, err := sqlutil.DoltRowToSqlRow(row, sch)
+	sqlRow, err := sqlutil.DoltRowToSqlRow(context.Background(), row, sch)
 	if err != nil {
 		panic(err)
 	}
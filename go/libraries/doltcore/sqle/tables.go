@@ -28,10 +28,13 @@ import (
 	"github.com/dolthub/vitess/go/sqltypes"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/alterschema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/types"
@@ -117,6 +120,11 @@ func (t *DoltTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 		return nil, err
 	}
 
+	// keyless tables have neither a primary key nor any other index
+	if schema.IsKeyless(sch) {
+		return nil, nil
+	}
+
 	rowData, err := tbl.GetRowData(ctx)
 	if err != nil {
 		return nil, err
@@ -171,7 +179,7 @@ func (t *DoltTable) GetAutoIncrementValue(ctx *sql.Context) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
-	return t.autoIncCol.TypeInfo.ConvertNomsValueToValue(val)
+	return t.autoIncCol.TypeInfo.ConvertNomsValueToValue(ctx, val)
 }
 
 // Name returns the name of the table.
@@ -189,6 +197,10 @@ func (t *DoltTable) Schema() sql.Schema {
 	return t.sqlSchema()
 }
 
+func (t *DoltTable) doltSchema() schema.Schema {
+	return t.sch
+}
+
 func (t *DoltTable) sqlSchema() sql.Schema {
 	if t.sqlSch != nil {
 		return t.sqlSch
@@ -521,6 +533,10 @@ func (t *AlterableDoltTable) DropColumn(ctx *sql.Context, columnName string) err
 		return err
 	}
 
+	if err = checkColumnNotReferenced(sch, columnName); err != nil {
+		return err
+	}
+
 	for _, index := range sch.Indexes().IndexesWithColumn(columnName) {
 		_, err = sch.Indexes().RemoveIndex(index.Name())
 		if err != nil {
@@ -579,11 +595,23 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 		panic(fmt.Sprintf("Column %s not found. This is a bug.", columnName))
 	}
 
+	if strings.ToLower(column.Name) != strings.ToLower(columnName) {
+		if err = checkColumnNotReferenced(sch, columnName); err != nil {
+			return err
+		}
+	}
+
 	col, err := sqlutil.ToDoltCol(existingCol.Tag, column)
 	if err != nil {
 		return err
 	}
 
+	// LONGTEXT columns created before long text was stored as blobs keep their storage unless the type changes
+	if typeinfo.IsStringType(existingCol.TypeInfo) && typeinfo.IsBlobType(col.TypeInfo) && existingCol.TypeInfo.ToSqlType() == column.Type {
+		col.TypeInfo = existingCol.TypeInfo
+		col.Kind = existingCol.Kind
+	}
+
 	fkCollection, err := root.GetForeignKeyCollection(ctx)
 	if err != nil {
 		return err
@@ -596,10 +624,24 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 		}
 	}
 
-	updatedTable, err := alterschema.ModifyColumn(ctx, table, existingCol, col, orderToOrder(order))
+	updatedTable, err := alterschema.ModifyColumnWithConversion(ctx, table, existingCol, col, orderToOrder(order), typeConversionForSession(ctx))
+	if err != nil {
+		return err
+	}
+
+	// generated columns that use the column are recomputed from its converted values, and the results must still
+	// satisfy the table's check constraints
+	updatedTable, err = merge.RegenerateColumns(ctx, updatedTable)
+	if err != nil {
+		return err
+	}
+	violations, err := merge.CheckViolations(ctx, updatedTable)
 	if err != nil {
 		return err
 	}
+	if len(violations) > 0 {
+		return expreval.ErrCheckConstraintViolated.New(violations[0].Check.Name())
+	}
 
 	newRoot, err := root.PutTable(ctx, t.name, updatedTable)
 	if err != nil {
@@ -609,6 +651,105 @@ func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, c
 	return t.db.SetRoot(ctx, newRoot)
 }
 
+// CreatePrimaryKey replaces the primary key of the table with the columns given, re-keying every row. Unlike MySQL,
+// this doesn't require the existing key to be dropped first.
+func (t *AlterableDoltTable) CreatePrimaryKey(ctx *sql.Context, columns []sql.IndexColumn) error {
+	root, err := t.db.GetRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	table, _, err := root.GetTable(ctx, t.name)
+	if err != nil {
+		return err
+	}
+
+	colNames := make([]string, len(columns))
+	for i, col := range columns {
+		colNames[i] = col.Name
+	}
+
+	updatedTable, err := alterschema.ChangePrimaryKey(ctx, table, colNames)
+	if err != nil {
+		return err
+	}
+
+	newRoot, err := root.PutTable(ctx, t.name, updatedTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.updateFromRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+	return t.db.SetRoot(ctx, newRoot)
+}
+
+// DropPrimaryKey drops the primary key of the table, making it a keyless table.
+func (t *AlterableDoltTable) DropPrimaryKey(ctx *sql.Context) error {
+	root, err := t.db.GetRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	fkc, err := root.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return err
+	}
+	declaredFks, referencedByFks := fkc.KeysForTable(t.name)
+	if len(declaredFks) > 0 || len(referencedByFks) > 0 {
+		return fmt.Errorf("cannot drop the primary key of table `%s` as it has foreign keys", t.name)
+	}
+
+	table, _, err := root.GetTable(ctx, t.name)
+	if err != nil {
+		return err
+	}
+
+	updatedTable, err := alterschema.DropPrimaryKey(ctx, table)
+	if err != nil {
+		return fmt.Errorf("cannot drop the primary key of table `%s`: %w", t.name, err)
+	}
+
+	newRoot, err := root.PutTable(ctx, t.name, updatedTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.updateFromRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+	return t.db.SetRoot(ctx, newRoot)
+}
+
+// mysqlDefaultSqlMode is the sql_mode MySQL sessions start with.
+const mysqlDefaultSqlMode = "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION"
+
+// typeConversionForSession returns the conversion used for column type changes in the given session. Like MySQL,
+// values that don't fit the new type are an error unless the session's sql_mode has no strict mode.
+func typeConversionForSession(ctx *sql.Context) alterschema.TypeConversion {
+	mode := strings.ToUpper(sessionSqlMode(ctx))
+	if strings.Contains(mode, "STRICT_TRANS_TABLES") || strings.Contains(mode, "STRICT_ALL_TABLES") {
+		return alterschema.StrictConversion
+	}
+
+	return alterschema.LossyConversion
+}
+
+// sessionSqlMode returns the sql_mode of the given session. Sessions start with the engine's default sql_mode, which
+// is empty, so the MySQL default is returned in its place until the session sets sql_mode, even to an empty one.
+func sessionSqlMode(ctx *sql.Context) string {
+	isDefault, val := sql.HasDefaultValue(ctx.Session, "sql_mode")
+	if dsess, ok := ctx.Session.(*DoltSession); isDefault && (!ok || !dsess.sqlModeSet) {
+		return mysqlDefaultSqlMode
+	}
+
+	mode, _ := val.(string)
+	return mode
+}
+
 // CreateIndex implements sql.IndexAlterableTable
 func (t *AlterableDoltTable) CreateIndex(ctx *sql.Context, indexName string, using sql.IndexUsing, constraint sql.IndexConstraint, columns []sql.IndexColumn, comment string) error {
 	ret, err := createIndexForTable(ctx, t.table, indexName, using, constraint, columns, true, comment)
@@ -782,6 +923,10 @@ func (t *AlterableDoltTable) CreateForeignKey(
 		return err
 	}
 
+	if schema.IsKeyless(t.sch) || schema.IsKeyless(refSch) {
+		return fmt.Errorf("foreign keys are not supported on tables without a primary key")
+	}
+
 	refColTags := make([]uint64, len(refColumns))
 	for i, name := range refColumns {
 		refCol, ok := refSch.GetAllCols().GetByNameCaseInsensitive(name)
@@ -881,7 +1026,11 @@ func (t *AlterableDoltTable) CreateForeignKey(
 	if err != nil {
 		return err
 	}
-	err = foreignKey.ConstraintIsSatisfied(ctx, tableIndexData, refTableIndexData, tableIndex, refTableIndex)
+	tableRowData, err := t.table.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	err = foreignKey.ConstraintIsSatisfied(ctx, t.table.ValueReadWriter(), tableRowData, tableIndexData, refTableIndexData, tableIndex, refTableIndex)
 	if err != nil {
 		return err
 	}
@@ -1011,6 +1160,10 @@ func createIndexForTable(
 		return nil, err
 	}
 
+	if schema.IsKeyless(sch) {
+		return nil, row.ErrKeylessIndex
+	}
+
 	// get the real column names as CREATE INDEX columns are case-insensitive
 	var realColNames []string
 	allTableCols := sch.GetAllCols()
@@ -18,9 +18,27 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/alterschema"
 )
 
 func TestMinRowsPerPartitionInTests(t *testing.T) {
 	// If this fails then the method for determining if we are running in a test doesn't work all the time.
 	assert.Equal(t, uint64(2), MinRowsPerPartition)
 }
+
+func TestTypeConversionForSession(t *testing.T) {
+	ts := newTestSession(t)
+	assert.Equal(t, alterschema.StrictConversion, typeConversionForSession(ts.ctx))
+
+	require.NoError(t, ts.exec("SET sql_mode = 'ANSI_QUOTES'"))
+	assert.Equal(t, alterschema.LossyConversion, typeConversionForSession(ts.ctx))
+
+	require.NoError(t, ts.exec("SET sql_mode = 'STRICT_ALL_TABLES'"))
+	assert.Equal(t, alterschema.StrictConversion, typeConversionForSession(ts.ctx))
+
+	// an empty sql_mode set explicitly has no strict mode, although it's the engine's default
+	require.NoError(t, ts.exec("SET sql_mode = ''"))
+	assert.Equal(t, alterschema.LossyConversion, typeConversionForSession(ts.ctx))
+}
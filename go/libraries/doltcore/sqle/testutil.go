@@ -44,6 +44,26 @@ func ExecuteSql(dEnv *env.DoltEnv, root *doltdb.RootValue, statements string) (*
 			continue
 		}
 
+		if IsCall(query) {
+			if err = db.Flush(ctx); err != nil {
+				return nil, err
+			}
+			if query, err = ExpandCall(ctx, engine, query); err != nil {
+				return nil, err
+			}
+		}
+
+		if MayBeExtendedDDL(query) {
+			if err = db.Flush(ctx); err != nil {
+				return nil, err
+			}
+			if handled, err := ExecuteExtendedDDL(ctx, engine, query); err != nil {
+				return nil, err
+			} else if handled {
+				continue
+			}
+		}
+
 		sqlStatement, err := sqlparser.Parse(query)
 		if err != nil {
 			return nil, err
@@ -100,7 +120,7 @@ func NewTestSQLCtx(ctx context.Context) *sql.Context {
 
 // NewTestEngine creates a new default engine, and a *sql.Context and initializes indexes and schema fragments.
 func NewTestEngine(ctx context.Context, db Database, root *doltdb.RootValue) (*sqle.Engine, *sql.Context, error) {
-	engine := sqle.NewDefault()
+	engine := NewDefaultEngine()
 	engine.AddDatabase(db)
 
 	sqlCtx := NewTestSQLCtx(ctx)
@@ -130,6 +150,11 @@ func ExecuteSelect(dEnv *env.DoltEnv, ddb *doltdb.DoltDB, root *doltdb.RootValue
 		return nil, err
 	}
 
+	query, err = ExpandCall(ctx, engine, query)
+	if err != nil {
+		return nil, err
+	}
+
 	_, rowIter, err := engine.Query(ctx, query)
 	if err != nil {
 		return nil, err
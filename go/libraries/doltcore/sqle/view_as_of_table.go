@@ -0,0 +1,164 @@
+// Copyright 2020 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sqle
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+// viewAsOfTable is a sql.Table that evaluates a view using its definition in a root other than the working root. The
+// analyzer only resolves views registered from the working root, so views named in an AS OF query which don't exist
+// in the working root are resolved as tables of this type.
+type viewAsOfTable struct {
+	name  string
+	root  *doltdb.RootValue
+	views map[string]string
+	sch   sql.Schema
+}
+
+var _ sql.Table = (*viewAsOfTable)(nil)
+
+// newViewAsOfTable returns a table evaluating the view with the name given in the root given, or false if the root
+// has no such view.
+func newViewAsOfTable(ctx *sql.Context, root *doltdb.RootValue, viewName string) (sql.Table, bool, error) {
+	views, err := getViewDefinitions(ctx, root)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var name string
+	for curr := range views {
+		if strings.EqualFold(curr, viewName) {
+			name = curr
+			break
+		}
+	}
+
+	if name == "" {
+		return nil, false, nil
+	}
+
+	vt := &viewAsOfTable{name: name, root: root, views: views}
+	sch, iter, err := vt.query(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = iter.Close()
+	if err != nil {
+		return nil, false, err
+	}
+
+	vt.sch = make(sql.Schema, len(sch))
+	for i, col := range sch {
+		viewCol := *col
+		viewCol.Source = name
+		vt.sch[i] = &viewCol
+	}
+
+	return vt, true, nil
+}
+
+// Name implements sql.Table
+func (vt *viewAsOfTable) Name() string {
+	return vt.name
+}
+
+// String implements sql.Table
+func (vt *viewAsOfTable) String() string {
+	return vt.name
+}
+
+// Schema implements sql.Table
+func (vt *viewAsOfTable) Schema() sql.Schema {
+	return vt.sch
+}
+
+// Partitions implements sql.Table
+func (vt *viewAsOfTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(), nil
+}
+
+// PartitionRows implements sql.Table
+func (vt *viewAsOfTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	_, iter, err := vt.query(ctx)
+	return iter, err
+}
+
+// query selects from the view using an engine over the table's root, with every view in the root registered.
+func (vt *viewAsOfTable) query(ctx *sql.Context) (sql.Schema, sql.RowIter, error) {
+	userSpaceDb := NewUserSpaceDatabase(vt.root)
+	sqlCtx, engine, _ := PrepareCreateTableStmt(ctx, userSpaceDb)
+
+	for name, definition := range vt.views {
+		cv, err := parse.Parse(sqlCtx, fmt.Sprintf("create view %s as %s", sqlfmt.QuoteIdentifier(name), definition))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = sqlCtx.Register(userSpaceDb.Name(), cv.(*plan.CreateView).Definition.AsView())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return engine.Query(sqlCtx, fmt.Sprintf("SELECT * FROM %s", sqlfmt.QuoteIdentifier(vt.name)))
+}
+
+// getViewDefinitions returns the definitions of the views stored in the dolt_schemas table of the root given, keyed by
+// view name.
+func getViewDefinitions(ctx *sql.Context, root *doltdb.RootValue) (map[string]string, error) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.SchemasTableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dt := NewDoltTable(doltdb.SchemasTableName, sch, tbl, NewUserSpaceDatabase(root))
+	iter, err := newRowIterator(&dt, ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	views := make(map[string]string)
+	for {
+		r, err := iter.Next()
+		if err == io.EOF {
+			return views, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if r[0] == viewFragment {
+			views[r[1].(string)] = r[2].(string)
+		}
+	}
+}
@@ -76,7 +76,7 @@ func TestReader(t *testing.T) {
 	sch, err := schema.SchemaFromCols(colColl)
 	require.NoError(t, err)
 
-	reader, err := OpenJSONReader(types.Format_LD_1, "file.json", fs, sch)
+	reader, err := OpenJSONReader(types.NewMemoryValueStore(), "file.json", fs, sch)
 	require.NoError(t, err)
 
 	verifySchema, err := reader.VerifySchema(sch)
@@ -156,7 +156,7 @@ func TestReaderBadJson(t *testing.T) {
 	sch, err := schema.SchemaFromCols(colColl)
 	require.NoError(t, err)
 
-	reader, err := OpenJSONReader(types.Format_LD_1, "file.json", fs, sch)
+	reader, err := OpenJSONReader(types.NewMemoryValueStore(), "file.json", fs, sch)
 	require.NoError(t, err)
 
 	err = nil
@@ -177,7 +177,7 @@ func newRow(sch schema.Schema, id int, first, last string) row.Row {
 		2: types.String(last),
 	}
 
-	r, err := row.New(types.Format_LD_1, sch, vals)
+	r, err := row.New(types.Format_7_18, sch, vals)
 
 	if err != nil {
 		panic(err)
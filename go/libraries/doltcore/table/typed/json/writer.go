@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/geometry"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
@@ -84,7 +85,8 @@ func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
 		}
 
 		switch col.TypeInfo.GetTypeIdentifier() {
-		case typeinfo.DatetimeTypeIdentifier,
+		case typeinfo.BlobStringTypeIdentifier,
+			typeinfo.DatetimeTypeIdentifier,
 			typeinfo.DecimalTypeIdentifier,
 			typeinfo.EnumTypeIdentifier,
 			typeinfo.InlineBlobTypeIdentifier,
@@ -94,12 +96,30 @@ func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
 			typeinfo.UuidTypeIdentifier,
 			typeinfo.VarBinaryTypeIdentifier,
 			typeinfo.YearTypeIdentifier:
-			v, err := col.TypeInfo.FormatValue(val)
+			v, err := col.TypeInfo.FormatValue(ctx, val)
 			if err != nil {
 				return true, err
 			}
 			val = types.String(*v)
 
+		case typeinfo.JSONTypeIdentifier:
+			v, err := col.TypeInfo.FormatValue(ctx, val)
+			if err != nil {
+				return true, err
+			}
+			// documents are written as nested JSON rather than as strings
+			colValMap[col.Name] = json.RawMessage(*v)
+			return false, nil
+
+		case typeinfo.GeometryTypeIdentifier:
+			g, err := col.TypeInfo.ConvertNomsValueToValue(ctx, val)
+			if err != nil {
+				return true, err
+			}
+			// spatial values are written as GeoJSON
+			colValMap[col.Name] = geometry.ToGeoJSON(g.(geometry.Geometry))
+			return false, nil
+
 		case typeinfo.BitTypeIdentifier,
 			typeinfo.BoolTypeIdentifier,
 			typeinfo.VarStringTypeIdentifier,
@@ -16,6 +16,7 @@ package csv
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -85,7 +87,7 @@ func NewCSVWriter(wr io.WriteCloser, outSch schema.Schema, info *CSVFileInfo) (*
 			return nil, err
 		}
 
-		err = csvw.write(colNames)
+		err = csvw.write(context.Background(), colNames, nil)
 
 		if err != nil {
 			wr.Close()
@@ -106,6 +108,7 @@ func (csvw *CSVWriter) WriteRow(ctx context.Context, r row.Row) error {
 	allCols := csvw.sch.GetAllCols()
 
 	colValStrs := make([]*string, 0, allCols.Size())
+	blobs := make(map[int]types.Blob)
 	_, err := r.IterSchema(csvw.sch, func(tag uint64, val types.Value) (stop bool, err error) {
 		val, ok := r.GetColVal(tag)
 		if !ok || types.IsNull(val) {
@@ -113,8 +116,22 @@ func (csvw *CSVWriter) WriteRow(ctx context.Context, r row.Row) error {
 			return false, nil
 		}
 
+		// blobs are streamed to the output when the row is written rather than being read into memory
+		if val.Kind() == types.BlobKind {
+			blobs[len(colValStrs)] = val.(types.Blob)
+			colValStrs = append(colValStrs, nil)
+			return false, nil
+		}
+
 		var v string
-		if val.Kind() == types.StringKind {
+		if col, _ := allCols.GetByTag(tag); col.TypeInfo != nil && col.TypeInfo.GetTypeIdentifier() == typeinfo.GeometryTypeIdentifier {
+			// spatial values are written as (E)WKT rather than as their storage format
+			str, err := col.TypeInfo.FormatValue(ctx, val)
+			if err != nil {
+				return false, err
+			}
+			v = *str
+		} else if val.Kind() == types.StringKind {
 			v = string(val.(types.String))
 		} else {
 			v, err = types.EncodedValue(ctx, val)
@@ -131,7 +148,7 @@ func (csvw *CSVWriter) WriteRow(ctx context.Context, r row.Row) error {
 		return err
 	}
 
-	return csvw.write(colValStrs)
+	return csvw.write(ctx, colValStrs, blobs)
 }
 
 // Close should flush all writes, release resources being held
@@ -148,7 +165,8 @@ func (csvw *CSVWriter) Close(ctx context.Context) error {
 
 // write is directly copied from csv.Writer.Write() with the addition of the `isNull []bool` parameter
 // this method has been adapted for Dolt's special quoting logic, ie `10,,""` -> (10,NULL,"")
-func (csvw *CSVWriter) write(record []*string) error {
+// Fields whose index is in |blobs| are written from the contents of the blob.
+func (csvw *CSVWriter) write(ctx context.Context, record []*string, blobs map[int]types.Blob) error {
 	for n, field := range record {
 		if n > 0 {
 			if _, err := csvw.wr.WriteString(csvw.info.Delim); err != nil {
@@ -156,6 +174,13 @@ func (csvw *CSVWriter) write(record []*string) error {
 			}
 		}
 
+		if blob, ok := blobs[n]; ok {
+			if err := csvw.writeBlob(ctx, blob); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if field == nil {
 			if _, err := csvw.wr.WriteString(""); err != nil {
 				return err
@@ -227,19 +252,18 @@ func (csvw *CSVWriter) write(record []*string) error {
 // Below is the method comment from csv.Writer.fieldNeedsQuotes. It is relevant
 // to Dolt's quoting logic for NULLs and ""s, and for import/export compatibility
 //
-// 		fieldNeedsQuotes reports whether our field must be enclosed in quotes.
-// 		Fields with a Comma, fields with a quote or newline, and
-// 		fields which start with a space must be enclosed in quotes.
-// 		We used to quote empty strings, but we do not anymore (as of Go 1.4).
-// 		The two representations should be equivalent, but Postgres distinguishes
-// 		quoted vs non-quoted empty string during database imports, and it has
-// 		an option to force the quoted behavior for non-quoted CSV but it has
-// 		no option to force the non-quoted behavior for quoted CSV, making
-// 		CSV with quoted empty strings strictly less useful.
-// 		Not quoting the empty string also makes this package match the behavior
-// 		of Microsoft Excel and Google Drive.
-// 		For Postgres, quote the data terminating string `\.`.
-//
+//	fieldNeedsQuotes reports whether our field must be enclosed in quotes.
+//	Fields with a Comma, fields with a quote or newline, and
+//	fields which start with a space must be enclosed in quotes.
+//	We used to quote empty strings, but we do not anymore (as of Go 1.4).
+//	The two representations should be equivalent, but Postgres distinguishes
+//	quoted vs non-quoted empty string during database imports, and it has
+//	an option to force the quoted behavior for non-quoted CSV but it has
+//	no option to force the non-quoted behavior for quoted CSV, making
+//	CSV with quoted empty strings strictly less useful.
+//	Not quoting the empty string also makes this package match the behavior
+//	of Microsoft Excel and Google Drive.
+//	For Postgres, quote the data terminating string `\.`.
 func (csvw *CSVWriter) fieldNeedsQuotes(field *string) bool {
 	if field != nil && *field == "" {
 		// special Dolt logic
@@ -253,3 +277,100 @@ func (csvw *CSVWriter) fieldNeedsQuotes(field *string) bool {
 	r1, _ := utf8.DecodeRuneInString(*field)
 	return unicode.IsSpace(r1)
 }
+
+// writeBlob writes the contents of a blob as a field, applying the same quoting logic as fieldNeedsQuotes. The blob is
+// streamed twice: once to determine whether it needs to be quoted, and once to write it.
+func (csvw *CSVWriter) writeBlob(ctx context.Context, blob types.Blob) error {
+	var needsQuotes bool
+	if blob.Len() <= uint64(len(`\.`)) {
+		// short values are checked as strings, which covers the special cases of empty fields and `\.`
+		sb := &strings.Builder{}
+		if _, err := io.Copy(sb, blob.Reader(ctx)); err != nil {
+			return err
+		}
+		field := sb.String()
+		needsQuotes = csvw.fieldNeedsQuotes(&field)
+	} else {
+		qs := &quoteScanner{delim: []byte(csvw.info.Delim)}
+		if _, err := io.Copy(qs, blob.Reader(ctx)); err != nil {
+			return err
+		}
+		needsQuotes = qs.needsQuotes
+	}
+
+	if !needsQuotes {
+		_, err := io.Copy(csvw.wr, blob.Reader(ctx))
+		return err
+	}
+
+	if err := csvw.wr.WriteByte('"'); err != nil {
+		return err
+	}
+	if _, err := io.Copy(&quotedFieldWriter{csvw}, blob.Reader(ctx)); err != nil {
+		return err
+	}
+	return csvw.wr.WriteByte('"')
+}
+
+// quoteScanner is a writer which determines whether the data written to it must be quoted as a field.
+type quoteScanner struct {
+	delim       []byte
+	tail        []byte
+	written     bool
+	needsQuotes bool
+}
+
+func (qs *quoteScanner) Write(p []byte) (int, error) {
+	if qs.needsQuotes || len(p) == 0 {
+		return len(p), nil
+	}
+
+	if !qs.written {
+		r1, _ := utf8.DecodeRune(p)
+		qs.needsQuotes = unicode.IsSpace(r1)
+		qs.written = true
+	}
+
+	// the delimiter may span the boundary between writes, so the end of the previous write is kept
+	data := append(qs.tail, p...)
+	if bytes.ContainsAny(p, "\"\r\n") || bytes.Contains(data, qs.delim) {
+		qs.needsQuotes = true
+	}
+
+	if keep := len(qs.delim) - 1; keep > 0 && len(data) >= keep {
+		qs.tail = append([]byte{}, data[len(data)-keep:]...)
+	}
+
+	return len(p), nil
+}
+
+// quotedFieldWriter writes data within a quoted field, escaping its quotes and line endings.
+type quotedFieldWriter struct {
+	csvw *CSVWriter
+}
+
+func (w *quotedFieldWriter) Write(p []byte) (int, error) {
+	for i, c := range p {
+		var err error
+		switch c {
+		case '"':
+			_, err = w.csvw.wr.WriteString(`""`)
+		case '\r':
+			if !w.csvw.useCRLF {
+				err = w.csvw.wr.WriteByte('\r')
+			}
+		case '\n':
+			if w.csvw.useCRLF {
+				_, err = w.csvw.wr.WriteString("\r\n")
+			} else {
+				err = w.csvw.wr.WriteByte('\n')
+			}
+		default:
+			err = w.csvw.wr.WriteByte(c)
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
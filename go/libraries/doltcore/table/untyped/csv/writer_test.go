@@ -16,6 +16,7 @@ package csv
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
@@ -102,3 +103,60 @@ Andy Anderson,27,
 		t.Errorf(`%s != %s`, results, expected)
 	}
 }
+
+func TestWriterBlobs(t *testing.T) {
+	const root = "/"
+	const path = "/file.csv"
+	const expected = `name,bio
+Bill Billerson,likes long walks
+Rob Robertson,"says ""hi"", often"
+John Johnson,""
+Andy Anderson,"line one
+line two"
+Ann Anderson,
+`
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+	mustBlob := func(s string) types.Value {
+		b, err := types.NewBlob(ctx, vrw, strings.NewReader(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	const bioColName = "bio"
+	const bioColTag = 1
+	colColl, _ := schema.NewColCollection(
+		schema.Column{Name: nameColName, Tag: nameColTag, Kind: types.StringKind, IsPartOfPK: true},
+		schema.Column{Name: bioColName, Tag: bioColTag, Kind: types.BlobKind},
+	)
+	rowSch := schema.MustSchemaFromCols(colColl)
+	rows := []row.Row{
+		mustRow(row.New(vrw.Format(), rowSch, row.TaggedValues{nameColTag: types.String("Bill Billerson"), bioColTag: mustBlob("likes long walks")})),
+		mustRow(row.New(vrw.Format(), rowSch, row.TaggedValues{nameColTag: types.String("Rob Robertson"), bioColTag: mustBlob(`says "hi", often`)})),
+		mustRow(row.New(vrw.Format(), rowSch, row.TaggedValues{nameColTag: types.String("John Johnson"), bioColTag: mustBlob("")})),
+		mustRow(row.New(vrw.Format(), rowSch, row.TaggedValues{nameColTag: types.String("Andy Anderson"), bioColTag: mustBlob("line one\nline two")})),
+		mustRow(row.New(vrw.Format(), rowSch, row.TaggedValues{nameColTag: types.String("Ann Anderson")})),
+	}
+
+	fs := filesys.NewInMemFS(nil, nil, root)
+	csvWr, err := OpenCSVWriter(path, fs, rowSch, NewCSVInfo())
+	if err != nil {
+		t.Fatal("Could not open CSVWriter", err)
+	}
+
+	for _, r := range rows {
+		if err := csvWr.WriteRow(ctx, r); err != nil {
+			t.Fatal("Failed to write row", err)
+		}
+	}
+	if err := csvWr.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := fs.ReadFile(path)
+	if string(results) != expected {
+		t.Errorf(`%s != %s`, results, expected)
+	}
+}
@@ -93,7 +93,7 @@ func (w *SqlExportWriter) WriteRow(ctx context.Context, r row.Row) error {
 		return err
 	}
 
-	stmt, err := sqlfmt.RowAsInsertStmt(r, w.tableName, w.sch)
+	stmt, err := sqlfmt.RowAsInsertStmt(ctx, r, w.tableName, w.sch)
 
 	if err != nil {
 		return err
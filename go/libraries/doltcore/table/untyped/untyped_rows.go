@@ -83,7 +83,7 @@ func UntypeSchema(sch schema.Schema) (schema.Schema, error) {
 	var cols []schema.Column
 	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		col.Kind = types.StringKind
-		col.TypeInfo = typeinfo.StringDefaultType
+		col.TypeInfo = typeinfo.FromKind(types.StringKind)
 		cols = append(cols, col)
 		return false, nil
 	})
@@ -133,7 +133,7 @@ func UntypeUnkeySchema(sch schema.Schema) (schema.Schema, error) {
 		col.Kind = types.StringKind
 		col.IsPartOfPK = false
 		col.Constraints = nil
-		col.TypeInfo = typeinfo.StringDefaultType
+		col.TypeInfo = typeinfo.FromKind(types.StringKind)
 		cols = append(cols, col)
 		return false, nil
 	})
@@ -105,6 +105,13 @@ func newTestValueStore() *ValueStore {
 	return NewValueStore(ts.NewView())
 }
 
+// NewMemoryValueStore creates a ValueStore backed by a chunks.MemoryStorage. It's suited to values which are not
+// persisted, such as the rows of query results.
+func NewMemoryValueStore() *ValueStore {
+	ms := &chunks.MemoryStorage{}
+	return NewValueStore(ms.NewView())
+}
+
 // NewValueStore returns a ValueStore instance that owns the provided
 // ChunkStore and manages its lifetime. Calling Close on the returned
 // ValueStore will Close() cs.